@@ -13,7 +13,8 @@ type Type struct {
 	// ConType is the concrete Go type for this type.
 	ConType string
 
-	// SymType is the Z3 symbolic type for this type.
+	// SymType is the Z3 symbolic type for this type, or "" if
+	// this type is represented by more than one Z3 value.
 	SymType string
 
 	Flags Flags
@@ -61,6 +62,14 @@ var Types = []Type{
 	{"Uint64", "uint64", "BV", IsInteger | IsUnsigned, 64},
 	{"Uintptr", "uintptr", "BV", IsInteger | IsUnsigned, ptrBits()},
 
+	{"Float32", "float32", "Float", IsFloat, 32},
+	{"Float64", "float64", "Float", IsFloat, 64},
+
+	{"Complex64", "complex64", "", IsComplex, 64},
+	{"Complex128", "complex128", "", IsComplex, 128},
+
+	{"String", "string", "Seq", IsString, 0},
+
 	{"Integer", "*big.Int", "Int", IsBigInt, 0},
 	{"Real", "*big.Rat", "Real", IsBigRat, 0},
 }
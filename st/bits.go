@@ -0,0 +1,89 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import "github.com/aclements/go-z3/z3"
+
+// This file implements the symbolic encodings of the math/bits
+// operations on unsigned integer types. The methods themselves are
+// generated. Each helper takes and returns a bit-vector of the same
+// width as its argument.
+
+// bvConst returns the bit-vector constant v truncated to x's width.
+func bvConst(x z3.BV, v uint64) z3.BV {
+	sort := x.Sort()
+	if n := sort.BVSize(); n < 64 {
+		v &= 1<<uint(n) - 1
+	}
+	return x.Context().FromInt(int64(v), sort).(z3.BV)
+}
+
+// bvLeadingZeros returns the number of leading zero bits in x.
+//
+// This is a binary search: at each step, if the top s bits of x are
+// zero, it adds s to the count and shifts them out.
+func bvLeadingZeros(x z3.BV) z3.BV {
+	n := x.Sort().BVSize()
+	zero := bvConst(x, 0)
+	count := zero
+	for s := n / 2; s >= 1; s /= 2 {
+		top := x.Extract(n-1, n-s)
+		isZero := top.Eq(bvConst(top, 0))
+		count = isZero.IfThenElse(count.Add(bvConst(x, uint64(s))), count).(z3.BV)
+		x = isZero.IfThenElse(x.Lsh(bvConst(x, uint64(s))), x).(z3.BV)
+	}
+	// Unless x was 0, its top bit is now set.
+	return count.Add(x.Extract(n-1, n-1).Not().ZeroExtend(n - 1))
+}
+
+// bvTrailingZeros returns the number of trailing zero bits in x.
+func bvTrailingZeros(x z3.BV) z3.BV {
+	return bvLeadingZeros(bvReverse(x))
+}
+
+// bvOnesCount returns the number of one bits in x.
+//
+// This uses the same parallel bit counting as math/bits.
+func bvOnesCount(x z3.BV) z3.BV {
+	n := x.Sort().BVSize()
+	const m0 = 0x5555555555555555
+	const m1 = 0x3333333333333333
+	const m2 = 0x0f0f0f0f0f0f0f0f
+	const h01 = 0x0101010101010101
+	k := func(v uint64) z3.BV { return bvConst(x, v) }
+	x = x.Sub(x.URsh(k(1)).And(k(m0)))
+	x = x.And(k(m1)).Add(x.URsh(k(2)).And(k(m1)))
+	x = x.Add(x.URsh(k(4))).And(k(m2))
+	// Each byte of x now holds the count of its bits. Sum them
+	// into the top byte.
+	return x.Mul(k(h01)).URsh(k(uint64(n - 8)))
+}
+
+// bvReverse returns x with its bits in reversed order.
+func bvReverse(x z3.BV) z3.BV {
+	n := x.Sort().BVSize()
+	res := x.Extract(0, 0)
+	for i := 1; i < n; i++ {
+		res = res.Concat(x.Extract(i, i))
+	}
+	return res
+}
+
+// bvReverseBytes returns x with its bytes in reversed order.
+func bvReverseBytes(x z3.BV) z3.BV {
+	n := x.Sort().BVSize()
+	res := x.Extract(7, 0)
+	for i := 8; i < n; i += 8 {
+		res = res.Concat(x.Extract(i+7, i))
+	}
+	return res
+}
+
+// bvRotateLeft returns x rotated left by k mod x's width bits. k
+// must have the same width as x.
+func bvRotateLeft(x, k z3.BV) z3.BV {
+	n := x.Sort().BVSize()
+	return x.RotateLeft(k.And(bvConst(k, uint64(n-1))))
+}
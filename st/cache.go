@@ -4,7 +4,11 @@
 
 package st
 
-import "github.com/aclements/go-z3/z3"
+import (
+	"reflect"
+
+	"github.com/aclements/go-z3/z3"
+)
 
 //go:generate go run gen.go -o types.go
 
@@ -12,6 +16,18 @@ type cache struct {
 	z3 *z3.Context
 
 	sorts
+
+	// stringLTDecl is the recursive function implementing
+	// lexicographic string comparison, or the zero FuncDecl if it
+	// hasn't been created yet. Use stringLTFunc to access this.
+	stringLTDecl z3.FuncDecl
+
+	// structSorts maps Go struct types to their tuple sorts. Use
+	// structSort to access this.
+	structSorts map[reflect.Type]z3.Sort
+
+	// panics is the active PanicLog, or nil.
+	panics *PanicLog
 }
 
 type cacheKeyType struct{}
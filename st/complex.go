@@ -0,0 +1,408 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// SymComplex is the symbolic value of a complex number, represented as
+// a pair of floating-point numbers.
+type SymComplex struct {
+	Real, Imag z3.Float
+}
+
+// Context returns the Context of x, or nil if x is the zero value.
+func (x SymComplex) Context() *z3.Context {
+	return x.Real.Context()
+}
+
+// String returns x as a string.
+func (x SymComplex) String() string {
+	return fmt.Sprintf("(complex %s %s)", x.Real, x.Imag)
+}
+
+// eq returns x == y following IEEE 754 equality of both parts.
+func (x SymComplex) eq(y SymComplex) z3.Bool {
+	return x.Real.IEEEEq(y.Real).And(x.Imag.IEEEEq(y.Imag))
+}
+
+// convert rounds both parts of x to floating-point sort s.
+func (x SymComplex) convert(s z3.Sort) SymComplex {
+	return SymComplex{x.Real.ToFloat(s), x.Imag.ToFloat(s)}
+}
+
+// complexMul returns x * y, computed at float64 precision and rounded
+// to sort s like Go.
+func (c *cache) complexMul(x, y SymComplex, s z3.Sort) SymComplex {
+	x, y = x.convert(c.sortFloat64), y.convert(c.sortFloat64)
+	a, b, cc, d := x.Real, x.Imag, y.Real, y.Imag
+	res := SymComplex{a.Mul(cc).Sub(b.Mul(d)), a.Mul(d).Add(b.Mul(cc))}
+	return res.convert(s)
+}
+
+// complexDiv returns x / y, computed at float64 precision and rounded
+// to sort s. This follows the algorithm used by the Go runtime,
+// including its corrections for infinities and zeros.
+//
+// Z3 has only one NaN, which is neither positive nor negative, so
+// results that depend on the sign of a NaN may differ from Go.
+func (c *cache) complexDiv(x, y SymComplex, s z3.Sort) SymComplex {
+	x, y = x.convert(c.sortFloat64), y.convert(c.sortFloat64)
+	a, b, cc, d := x.Real, x.Imag, y.Real, y.Imag
+	f64 := func(v float64) z3.Float {
+		return c.z3.FromFloat64(v, c.sortFloat64)
+	}
+	zero, one, inf := f64(0), f64(1), c.z3.FloatInf(c.sortFloat64, false)
+	ite := func(cond z3.Bool, x, y z3.Float) z3.Float {
+		return cond.IfThenElse(x, y).(z3.Float)
+	}
+	copysign := func(x, sign z3.Float) z3.Float {
+		return ite(sign.IsNegative(), x.Abs().Neg(), x.Abs())
+	}
+	inf2one := func(x z3.Float) z3.Float {
+		return copysign(ite(x.IsInfinite(), one, zero), x)
+	}
+	isFinite := func(x z3.Float) z3.Bool {
+		return x.IsInfinite().Or(x.IsNaN()).Not()
+	}
+
+	// Smith's algorithm.
+	ratio1 := d.Div(cc)
+	denom1 := cc.Add(ratio1.Mul(d))
+	e1 := a.Add(b.Mul(ratio1)).Div(denom1)
+	f1 := b.Sub(a.Mul(ratio1)).Div(denom1)
+	ratio2 := cc.Div(d)
+	denom2 := d.Add(ratio2.Mul(cc))
+	e2 := a.Mul(ratio2).Add(b).Div(denom2)
+	f2 := b.Mul(ratio2).Sub(a).Div(denom2)
+	small := cc.Abs().GE(d.Abs())
+	e, f := ite(small, e1, e2), ite(small, f1, f2)
+
+	// Correct NaN results to infinities and zeros.
+	isZero := cc.IEEEEq(zero).And(d.IEEEEq(zero)).And(a.IsNaN().Not().Or(b.IsNaN().Not()))
+	e3, f3 := copysign(inf, cc).Mul(a), copysign(inf, cc).Mul(b)
+	isInfNum := a.IsInfinite().Or(b.IsInfinite()).And(isFinite(cc), isFinite(d))
+	a4, b4 := inf2one(a), inf2one(b)
+	e4 := inf.Mul(a4.Mul(cc).Add(b4.Mul(d)))
+	f4 := inf.Mul(b4.Mul(cc).Sub(a4.Mul(d)))
+	isInfDen := cc.IsInfinite().Or(d.IsInfinite()).And(isFinite(a), isFinite(b))
+	c5, d5 := inf2one(cc), inf2one(d)
+	e5 := zero.Mul(a.Mul(c5).Add(b.Mul(d5)))
+	f5 := zero.Mul(b.Mul(c5).Sub(a.Mul(d5)))
+	nan := e.IsNaN().And(f.IsNaN())
+	e = ite(nan, ite(isZero, e3, ite(isInfNum, e4, ite(isInfDen, e5, e))), e)
+	f = ite(nan, ite(isZero, f3, ite(isInfNum, f4, ite(isInfDen, f5, f))), f)
+
+	return SymComplex{e, f}.convert(s)
+}
+
+// Complex64 implements symbolic complex64 values.
+type Complex64 struct {
+	C complex64
+	S SymComplex
+}
+
+// AnyComplex64 returns an unconstrained symbolic Complex64.
+func AnyComplex64(ctx *z3.Context, name string) Complex64 {
+	cache := getCache(ctx)
+	re := cache.z3.FreshConst(name+".real", cache.sortFloat32).(z3.Float)
+	im := cache.z3.FreshConst(name+".imag", cache.sortFloat32).(z3.Float)
+	return Complex64{S: SymComplex{re, im}}
+}
+
+// MakeComplex64 returns complex(re, im).
+func MakeComplex64(re, im Float32) Complex64 {
+	if re.IsConcrete() && im.IsConcrete() {
+		return Complex64{C: complex(re.C, im.C)}
+	}
+	ctx := re.S.Context()
+	if ctx == nil {
+		ctx = im.S.Context()
+	}
+	cache := getCache(ctx)
+	return Complex64{S: SymComplex{re.sym(cache), im.sym(cache)}}
+}
+
+// String returns x as a string.
+func (x Complex64) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Complex64) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Complex64) Eval(m *z3.Model) complex64 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	return complex(x.Real().Eval(m), x.Imag().Eval(m))
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Complex64) sym(c *cache) SymComplex {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return SymComplex{Float32{C: real(x.C)}.sym(c), Float32{C: imag(x.C)}.sym(c)}
+}
+
+// Real returns real(x).
+func (x Complex64) Real() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: real(x.C)}
+	}
+	return Float32{S: x.S.Real}
+}
+
+// Imag returns imag(x).
+func (x Complex64) Imag() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: imag(x.C)}
+	}
+	return Float32{S: x.S.Imag}
+}
+
+// ite returns cond ? x : y for symbolic cond.
+func (x Complex64) ite(cond Bool, y Complex64) Complex64 {
+	return MakeComplex64(Ite(cond, x.Real(), y.Real()), Ite(cond, x.Imag(), y.Imag()))
+}
+
+func (x Complex64) Add(y Complex64) Complex64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Complex64{C: complex64(x.C + y.C)}
+	}
+	return MakeComplex64(x.Real().Add(y.Real()), x.Imag().Add(y.Imag()))
+}
+
+func (x Complex64) Sub(y Complex64) Complex64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Complex64{C: complex64(x.C - y.C)}
+	}
+	return MakeComplex64(x.Real().Sub(y.Real()), x.Imag().Sub(y.Imag()))
+}
+
+func (x Complex64) Mul(y Complex64) Complex64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Complex64{C: complex64(x.C * y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Complex64{S: cache.complexMul(x.sym(cache), y.sym(cache), cache.sortFloat32)}
+}
+
+func (x Complex64) Quo(y Complex64) Complex64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Complex64{C: x.C / y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Complex64{S: cache.complexDiv(x.sym(cache), y.sym(cache), cache.sortFloat32)}
+}
+
+func (x Complex64) Eq(y Complex64) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C == y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).eq(y.sym(cache))}
+}
+
+func (x Complex64) NE(y Complex64) Bool {
+	return x.Eq(y).Not()
+}
+
+func (x Complex64) Neg() Complex64 {
+	if x.IsConcrete() {
+		return Complex64{C: -x.C}
+	}
+	return Complex64{S: SymComplex{x.S.Real.Neg(), x.S.Imag.Neg()}}
+}
+
+func (x Complex64) ToComplex64() Complex64 {
+	if x.IsConcrete() {
+		return Complex64{C: complex64(x.C)}
+	}
+	return MakeComplex64(x.Real().ToFloat32(), x.Imag().ToFloat32())
+}
+
+func (x Complex64) ToComplex128() Complex128 {
+	if x.IsConcrete() {
+		return Complex128{C: complex128(x.C)}
+	}
+	return MakeComplex128(x.Real().ToFloat64(), x.Imag().ToFloat64())
+}
+
+// Complex128 implements symbolic complex128 values.
+type Complex128 struct {
+	C complex128
+	S SymComplex
+}
+
+// AnyComplex128 returns an unconstrained symbolic Complex128.
+func AnyComplex128(ctx *z3.Context, name string) Complex128 {
+	cache := getCache(ctx)
+	re := cache.z3.FreshConst(name+".real", cache.sortFloat64).(z3.Float)
+	im := cache.z3.FreshConst(name+".imag", cache.sortFloat64).(z3.Float)
+	return Complex128{S: SymComplex{re, im}}
+}
+
+// MakeComplex128 returns complex(re, im).
+func MakeComplex128(re, im Float64) Complex128 {
+	if re.IsConcrete() && im.IsConcrete() {
+		return Complex128{C: complex(re.C, im.C)}
+	}
+	ctx := re.S.Context()
+	if ctx == nil {
+		ctx = im.S.Context()
+	}
+	cache := getCache(ctx)
+	return Complex128{S: SymComplex{re.sym(cache), im.sym(cache)}}
+}
+
+// String returns x as a string.
+func (x Complex128) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Complex128) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Complex128) Eval(m *z3.Model) complex128 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	return complex(x.Real().Eval(m), x.Imag().Eval(m))
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Complex128) sym(c *cache) SymComplex {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return SymComplex{Float64{C: real(x.C)}.sym(c), Float64{C: imag(x.C)}.sym(c)}
+}
+
+// Real returns real(x).
+func (x Complex128) Real() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: real(x.C)}
+	}
+	return Float64{S: x.S.Real}
+}
+
+// Imag returns imag(x).
+func (x Complex128) Imag() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: imag(x.C)}
+	}
+	return Float64{S: x.S.Imag}
+}
+
+// ite returns cond ? x : y for symbolic cond.
+func (x Complex128) ite(cond Bool, y Complex128) Complex128 {
+	return MakeComplex128(Ite(cond, x.Real(), y.Real()), Ite(cond, x.Imag(), y.Imag()))
+}
+
+func (x Complex128) Add(y Complex128) Complex128 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Complex128{C: complex128(x.C + y.C)}
+	}
+	return MakeComplex128(x.Real().Add(y.Real()), x.Imag().Add(y.Imag()))
+}
+
+func (x Complex128) Sub(y Complex128) Complex128 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Complex128{C: complex128(x.C - y.C)}
+	}
+	return MakeComplex128(x.Real().Sub(y.Real()), x.Imag().Sub(y.Imag()))
+}
+
+func (x Complex128) Mul(y Complex128) Complex128 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Complex128{C: complex128(x.C * y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Complex128{S: cache.complexMul(x.sym(cache), y.sym(cache), cache.sortFloat64)}
+}
+
+func (x Complex128) Quo(y Complex128) Complex128 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Complex128{C: x.C / y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Complex128{S: cache.complexDiv(x.sym(cache), y.sym(cache), cache.sortFloat64)}
+}
+
+func (x Complex128) Eq(y Complex128) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C == y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).eq(y.sym(cache))}
+}
+
+func (x Complex128) NE(y Complex128) Bool {
+	return x.Eq(y).Not()
+}
+
+func (x Complex128) Neg() Complex128 {
+	if x.IsConcrete() {
+		return Complex128{C: -x.C}
+	}
+	return Complex128{S: SymComplex{x.S.Real.Neg(), x.S.Imag.Neg()}}
+}
+
+func (x Complex128) ToComplex64() Complex64 {
+	if x.IsConcrete() {
+		return Complex64{C: complex64(x.C)}
+	}
+	return MakeComplex64(x.Real().ToFloat32(), x.Imag().ToFloat32())
+}
+
+func (x Complex128) ToComplex128() Complex128 {
+	if x.IsConcrete() {
+		return Complex128{C: complex128(x.C)}
+	}
+	return MakeComplex128(x.Real().ToFloat64(), x.Imag().ToFloat64())
+}
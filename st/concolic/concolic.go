@@ -0,0 +1,353 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package concolic generates test inputs for Go functions written
+// against the symbolic types in package st.
+//
+// Concolic ("concrete and symbolic") testing runs a function on
+// concrete inputs while recording the symbolic conditions of the
+// branches it takes. Negating one of these conditions and solving for
+// inputs that satisfy it produces inputs that take a different path
+// through the function. Repeating this process explores the
+// function's paths starting from a set of seed inputs.
+//
+// The function under test takes st values as arguments, such as
+// func(x st.Int32, y st.Uint8) st.Bool. It may also take an *st.Path
+// as its first argument and branch on symbolic conditions using
+// Path.If. If the function returns an st.Bool, its result is treated
+// as a final branch, so the generated inputs cover both results where
+// possible.
+package concolic
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/aclements/go-z3/st"
+	"github.com/aclements/go-z3/z3"
+)
+
+// A Case is a set of concrete inputs and the path they take through
+// the function under test.
+type Case struct {
+	// Inputs are the concrete values of the function's
+	// arguments, not including any *st.Path argument. Each input
+	// has the concrete Go type of the corresponding st type.
+	Inputs []interface{}
+
+	// Path is the path taken by Inputs.
+	Path *st.Path
+}
+
+// A Generator generates test cases for a function.
+type Generator struct {
+	ctx     *z3.Context
+	fn      reflect.Value
+	hasPath bool
+	inputs  []st.Value
+
+	// MaxCases, if non-zero, bounds the number of cases Run
+	// generates.
+	MaxCases int
+}
+
+var pathType = reflect.TypeOf((*st.Path)(nil))
+
+// NewGenerator returns a Generator for fn, which must be a function
+// whose arguments are st types, optionally preceded by an *st.Path.
+func NewGenerator(ctx *z3.Context, fn interface{}) *Generator {
+	g := &Generator{ctx: ctx, fn: reflect.ValueOf(fn)}
+	ft := g.fn.Type()
+	if ft.Kind() != reflect.Func {
+		panic(fmt.Sprintf("%s is not a function", ft))
+	}
+	for i := 0; i < ft.NumIn(); i++ {
+		pt := ft.In(i)
+		if i == 0 && pt == pathType {
+			g.hasPath = true
+			continue
+		}
+		in, ok := st.AnyValue(ctx, fmt.Sprintf("arg%d", len(g.inputs)), pt).(st.Value)
+		if !ok {
+			panic(fmt.Sprintf("unsupported argument type %s", pt))
+		}
+		g.inputs = append(g.inputs, in)
+	}
+	return g
+}
+
+// Run explores the function starting from each of the seed input
+// sets and returns a case for each distinct path found. Each seed
+// must have one value for each of the function's st arguments, which
+// must be convertible to the concrete type of that argument. If there
+// are no seeds, Run starts from zero inputs.
+func (g *Generator) Run(seeds ...[]interface{}) []Case {
+	var work [][]interface{}
+	for _, seed := range seeds {
+		if len(seed) != len(g.inputs) {
+			panic(fmt.Sprintf("seed has %d inputs; want %d", len(seed), len(g.inputs)))
+		}
+		inputs := make([]interface{}, len(seed))
+		for i, v := range seed {
+			ct := reflect.TypeOf(g.inputs[i]).Field(0).Type
+			inputs[i] = reflect.ValueOf(v).Convert(ct).Interface()
+		}
+		work = append(work, inputs)
+	}
+	if len(seeds) == 0 {
+		inputs := make([]interface{}, len(g.inputs))
+		for i, in := range g.inputs {
+			inputs[i] = reflect.Zero(reflect.TypeOf(in).Field(0).Type).Interface()
+		}
+		work = append(work, inputs)
+	}
+
+	var cases []Case
+	seen := make(map[string]bool)  // Paths already found.
+	tried := make(map[string]bool) // Path prefixes already solved for.
+	for len(work) > 0 {
+		if g.MaxCases != 0 && len(cases) >= g.MaxCases {
+			break
+		}
+		inputs := work[0]
+		work = work[1:]
+
+		m := g.model(inputs)
+		if m == nil {
+			continue
+		}
+		p := st.Follow(g.ctx, m, g.call)
+		if p == nil {
+			continue
+		}
+		key := pathKey(p.Decisions())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		tried[key] = true
+		cases = append(cases, Case{inputs, p})
+
+		// Negate each branch in turn, keeping the assumptions
+		// that precede it.
+		branches, decisions := p.Branches(), p.Decisions()
+		for i := range branches {
+			prefix := append(append([]bool(nil), decisions[:i]...), !decisions[i])
+			if tried[pathKey(prefix)] {
+				continue
+			}
+			tried[pathKey(prefix)] = true
+			solver := z3.NewSolver(g.ctx)
+			solver.Assert(p.CondBefore(i).And(branches[i].Not()).S)
+			if sat, _ := solver.Check(); sat {
+				work = append(work, g.eval(solver.Model()))
+			}
+		}
+	}
+	return cases
+}
+
+// call calls the function under test along path p.
+func (g *Generator) call(p *st.Path) {
+	args := make([]reflect.Value, 0, len(g.inputs)+1)
+	if g.hasPath {
+		args = append(args, reflect.ValueOf(p))
+	}
+	for _, in := range g.inputs {
+		args = append(args, reflect.ValueOf(in))
+	}
+	for _, res := range g.fn.Call(args) {
+		if b, ok := res.Interface().(st.Bool); ok {
+			p.If(b)
+		}
+	}
+}
+
+// model returns a model that assigns inputs to the symbolic inputs,
+// or nil if the inputs could not be represented.
+func (g *Generator) model(inputs []interface{}) *z3.Model {
+	solver := z3.NewSolver(g.ctx)
+	for i, in := range g.inputs {
+		solver.Assert(st.Identical(g.ctx, in, st.ValueOf(g.ctx, inputs[i])).S)
+	}
+	if sat, _ := solver.Check(); !sat {
+		return nil
+	}
+	return solver.Model()
+}
+
+// eval returns the concrete values of the symbolic inputs in m.
+func (g *Generator) eval(m *z3.Model) []interface{} {
+	inputs := make([]interface{}, len(g.inputs))
+	for i, in := range g.inputs {
+		eval := reflect.ValueOf(in).MethodByName("Eval")
+		inputs[i] = eval.Call([]reflect.Value{reflect.ValueOf(m)})[0].Interface()
+	}
+	return inputs
+}
+
+func pathKey(decisions []bool) string {
+	key := make([]byte, len(decisions))
+	for i, d := range decisions {
+		key[i] = '0'
+		if d {
+			key[i] = '1'
+		}
+	}
+	return string(key)
+}
+
+// WriteTable writes cases to w as the body of a Go composite literal
+// of test cases, with one line per case. For example,
+//
+//	{int32(5), uint8(0)},
+func WriteTable(w io.Writer, cases []Case) error {
+	for _, c := range cases {
+		if _, err := fmt.Fprint(w, "{"); err != nil {
+			return err
+		}
+		for i, in := range c.Inputs {
+			sep := ", "
+			if i == len(c.Inputs)-1 {
+				sep = ""
+			}
+			lit, err := goLiteral(in)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "%s%s", lit, sep); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprint(w, "},\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// goLiteral returns a Go expression for v.
+func goLiteral(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case bool:
+		return fmt.Sprint(v), nil
+	case string:
+		return fmt.Sprintf("%q", v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return fmt.Sprintf("%T(%v)", v, v), nil
+	case float32:
+		switch {
+		case math.IsInf(float64(v), 0) || math.IsNaN(float64(v)):
+			return fmt.Sprintf("math.Float32frombits(%#x)", math.Float32bits(v)), nil
+		case v == 0 && math.Signbit(float64(v)):
+			// Go constants have no negative zero.
+			return "float32(math.Copysign(0, -1))", nil
+		}
+		return "float32(" + strconv.FormatFloat(float64(v), 'g', -1, 32) + ")", nil
+	case float64:
+		switch {
+		case math.IsInf(v, 0) || math.IsNaN(v):
+			return fmt.Sprintf("math.Float64frombits(%#x)", math.Float64bits(v)), nil
+		case v == 0 && math.Signbit(v):
+			return "math.Copysign(0, -1)", nil
+		}
+		return "float64(" + strconv.FormatFloat(v, 'g', -1, 64) + ")", nil
+	case complex64:
+		re, _ := goLiteral(real(v))
+		im, _ := goLiteral(imag(v))
+		return "complex(" + re + ", " + im + ")", nil
+	case complex128:
+		re, _ := goLiteral(real(v))
+		im, _ := goLiteral(imag(v))
+		return "complex(" + re + ", " + im + ")", nil
+	}
+	return "", fmt.Errorf("type %T cannot be written as a Go literal", v)
+}
+
+// Seed adds the inputs of each case to f's seed corpus. The fuzz
+// target's arguments after *testing.T must match the function's st
+// arguments. Seed skips cases with inputs of types that Go fuzzing
+// does not support, such as uintptr. It also skips []byte inputs,
+// which a Generator never produces and WriteCorpus does not support.
+func Seed(f *testing.F, cases []Case) {
+cases:
+	for _, c := range cases {
+		for _, in := range c.Inputs {
+			if !fuzzable(in) {
+				f.Logf("skipping seed %v: type %T is not supported by fuzzing", c.Inputs, in)
+				continue cases
+			}
+		}
+		f.Add(c.Inputs...)
+	}
+}
+
+// fuzzable returns whether v has a type supported by Go fuzzing and
+// by marshalCorpus.
+func fuzzable(v interface{}) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, bool, string:
+		return true
+	}
+	return false
+}
+
+// WriteCorpus writes the inputs of each case to a file in dir in the
+// format of Go's fuzzing corpus. To add the cases to the seed corpus
+// of fuzz test FuzzX, dir should be testdata/fuzz/FuzzX.
+func WriteCorpus(dir string, cases []Case) error {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	for _, c := range cases {
+		data, err := marshalCorpus(c.Inputs)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%x", sha256.Sum256(data))[:16]
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// marshalCorpus encodes vals in Go's fuzzing corpus file format.
+func marshalCorpus(vals []interface{}) ([]byte, error) {
+	b := []byte("go test fuzz v1\n")
+	for _, val := range vals {
+		var line string
+		switch v := val.(type) {
+		case int, int8, int16, int64, uint, uint16, uint32, uint64, bool:
+			line = fmt.Sprintf("%T(%v)", v, v)
+		case int32:
+			if utf8.ValidRune(v) {
+				line = fmt.Sprintf("rune(%q)", v)
+			} else {
+				line = fmt.Sprintf("int32(%v)", v)
+			}
+		case uint8:
+			line = fmt.Sprintf("byte(%q)", v)
+		case float32:
+			line = fmt.Sprintf("math.Float32frombits(%#x)", math.Float32bits(v))
+		case float64:
+			line = fmt.Sprintf("math.Float64frombits(%#x)", math.Float64bits(v))
+		case string:
+			line = fmt.Sprintf("string(%q)", v)
+		default:
+			return nil, fmt.Errorf("type %T is not supported by the fuzzing corpus", v)
+		}
+		b = append(b, line+"\n"...)
+	}
+	return b, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package concolic
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aclements/go-z3/st"
+	"github.com/aclements/go-z3/z3"
+)
+
+func classify(p *st.Path, x st.Int32, y st.Uint8) st.Bool {
+	if p.If(x.GT(st.Int32{C: 100})) {
+		return y.Eq(st.Uint8{C: 'q'})
+	}
+	return x.Eq(st.Int32{C: -7})
+}
+
+func generate(t testing.TB) []Case {
+	ctx := z3.NewContext(nil)
+	return NewGenerator(ctx, classify).Run()
+}
+
+func TestGenerator(t *testing.T) {
+	cases := generate(t)
+	if len(cases) != 4 {
+		t.Fatalf("want 4 cases, got %d", len(cases))
+	}
+	type outcome struct{ big, res bool }
+	seen := make(map[outcome]bool)
+	for _, c := range cases {
+		x, y := c.Inputs[0].(int32), c.Inputs[1].(uint8)
+		// Replay concretely.
+		res := classify(nil, st.Int32{C: x}, st.Uint8{C: y})
+		seen[outcome{x > 100, res.C}] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("cases do not cover all paths: %v", seen)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, cases[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "{int32(0), uint8(0)},\n"; got != want {
+		t.Errorf("WriteTable = %q, want %q", got, want)
+	}
+}
+
+func TestGeneratorAssume(t *testing.T) {
+	// Inputs that negate the branch must also satisfy the
+	// assumption made before it.
+	ctx := z3.NewContext(nil)
+	f := func(p *st.Path, x st.Int32) st.Bool {
+		p.Assume(x.Eq(st.Int32{C: 7}).Or(x.LT(st.Int32{C: 0})))
+		return x.GT(st.Int32{C: 0})
+	}
+	cases := NewGenerator(ctx, f).Run([]interface{}{-1})
+	if len(cases) != 2 {
+		t.Fatalf("want 2 cases, got %v", cases)
+	}
+	if x := cases[1].Inputs[0].(int32); x != 7 {
+		t.Errorf("want x = 7, got %d", x)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	cases := []Case{{Inputs: []interface{}{math.Copysign(0, -1), float32(math.Copysign(0, -1)), 0.1, float32(0.1), complex(1.5, math.Copysign(0, -1)), math.NaN()}}}
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, cases); err != nil {
+		t.Fatal(err)
+	}
+	want := "{math.Copysign(0, -1), float32(math.Copysign(0, -1)), float64(0.1), float32(0.1), complex(float64(1.5), math.Copysign(0, -1)), math.Float64frombits(0x7ff8000000000001)},\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteTable = %q, want %q", got, want)
+	}
+
+	type pair struct{ A, B int }
+	if err := WriteTable(&buf, []Case{{Inputs: []interface{}{pair{}}}}); err == nil {
+		t.Errorf("want error writing struct input")
+	}
+}
+
+func TestWriteCorpus(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "testdata", "fuzz", "FuzzClassify")
+	cases := []Case{{Inputs: []interface{}{int32(-1), uint8('q'), "a", 1.5}}}
+	if err := WriteCorpus(dir, cases); err != nil {
+		t.Fatal(err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("want 1 corpus file, got %v, %v", files, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "go test fuzz v1\nint32(-1)\nbyte('q')\nstring(\"a\")\nmath.Float64frombits(0x3ff8000000000000)\n"
+	if string(data) != want {
+		t.Errorf("corpus file:\n%s\nwant:\n%s", data, want)
+	}
+
+	if err := WriteCorpus(dir, []Case{{Inputs: []interface{}{uintptr(0)}}}); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("want unsupported type error, got %v", err)
+	}
+}
+
+func FuzzClassify(f *testing.F) {
+	Seed(f, generate(f))
+	f.Fuzz(func(t *testing.T, x int32, y uint8) {
+		classify(nil, st.Int32{C: x}, st.Uint8{C: y})
+	})
+}
+
+func FuzzSeedUnsupported(f *testing.F) {
+	// Seed skips cases that f.Add would reject.
+	Seed(f, []Case{{Inputs: []interface{}{uintptr(1)}}, {Inputs: []interface{}{[]byte("a")}}, {Inputs: []interface{}{uint64(2)}}})
+	f.Fuzz(func(t *testing.T, x uint64) {})
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"reflect"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// Elem is the constraint satisfied by types that can be stored in
+// composite symbolic types such as Slice, Array, and Map. Every type
+// in this package that is represented by a single Z3 value (that is,
+// every type except the complex types) implements Elem.
+type Elem[T any] interface {
+	IsConcrete() bool
+	String() string
+	Eq(y T) Bool
+
+	// zero returns the concrete zero value of T.
+	zero() T
+
+	// sortOf returns the Z3 sort of T's symbolic values.
+	sortOf(c *cache) z3.Sort
+
+	// symValue is like sym, but returns a z3.Value.
+	symValue(c *cache) z3.Value
+
+	// fromSym returns the T whose symbolic value is v.
+	fromSym(v z3.Value) T
+
+	// ite returns cond ? x : y. cond must be symbolic. Use Ite
+	// instead, which also handles concrete conditions.
+	ite(cond Bool, y T) T
+
+	// evalValue is like Eval, but returns the concrete value as a
+	// reflect.Value.
+	evalValue(m *z3.Model) reflect.Value
+}
+
+// concreteType returns the Go type of T's concrete values.
+func concreteType[T Elem[T]]() reflect.Type {
+	var zero T
+	return reflect.TypeOf(zero).Field(0).Type
+}
+
+// Ite returns cond ? x : y. If cond is concrete, Ite returns x or y
+// itself. Otherwise, the result is symbolic and equals x when cond
+// is true and y when cond is false.
+//
+// Ite is useful for merging the values of a variable from the two
+// branches of a symbolic condition. It supports every type in this
+// package except Heap.
+func Ite[T interface{ ite(cond Bool, y T) T }](cond Bool, x, y T) T {
+	if cond.IsConcrete() {
+		if cond.C {
+			return x
+		}
+		return y
+	}
+	return x.ite(cond, y)
+}
+
+// inRange returns lo <= x && x <= hi.
+func inRange(lo, x, hi Int) Bool {
+	return allOf(lo.LE(x), x.LE(hi))
+}
+
+// allOf returns the conjunction of conds. Unlike Bool.And, the result
+// is concretely false if any of conds is, so bounds checks that are
+// known to fail can panic immediately.
+func allOf(conds ...Bool) Bool {
+	res := Bool{C: true}
+	for _, c := range conds {
+		if c.IsConcrete() && !c.C {
+			return c
+		}
+		res = res.And(c)
+	}
+	return res
+}
@@ -34,7 +34,10 @@ package st
 
 import (
 	"fmt"
+	"math"
 	"math/big"
+	"math/bits"
+	"reflect"
 	"github.com/aclements/go-z3/z3"
 )
 
@@ -42,12 +45,27 @@ import (
 
 	fmt.Fprintf(w, "type sorts struct {\n")
 	for _, typ := range ops.Types {
+		if typ.SymType == "" {
+			continue
+		}
 		fmt.Fprintf(w, "sort%s z3.Sort\n", typ.StName)
 	}
 	fmt.Fprintf(w, "}\n\n")
 
 	fmt.Fprintf(w, "func initSorts(s *sorts, ctx *z3.Context) {\n")
 	for _, typ := range ops.Types {
+		if typ.SymType == "" {
+			continue
+		}
+		if typ.Flags&ops.IsFloat != 0 {
+			fmt.Fprintf(w, "s.sort%s = ctx.Float%dSort()\n", typ.StName, typ.Bits)
+			continue
+		}
+		if typ.Flags&ops.IsString != 0 {
+			// Go strings are sequences of bytes.
+			fmt.Fprintf(w, "s.sort%s = ctx.SeqSort(ctx.BVSort(8))\n", typ.StName)
+			continue
+		}
 		arg := ""
 		if typ.Bits != 0 {
 			arg = fmt.Sprintf("%d", typ.Bits)
@@ -57,7 +75,13 @@ import (
 	fmt.Fprintf(w, "}\n\n")
 
 	for _, typ := range ops.Types {
+		if typ.SymType == "" {
+			// Composite types are implemented by hand.
+			continue
+		}
 		genDecl(w, typ)
+		genElem(w, typ)
+		genMinMax(w, typ)
 
 		for _, binop := range ops.BinOps {
 			if binop.Flags&typ.Flags != 0 {
@@ -74,6 +98,13 @@ import (
 		for _, typ2 := range ops.Types {
 			genConv(w, typ, typ2)
 		}
+
+		genChecked(w, typ)
+		genBits(w, typ)
+		genCarry(w, typ)
+		for _, typ2 := range ops.Types {
+			genConvChecked(w, typ, typ2)
+		}
 	}
 
 	writeSource(*flagOut, w.Bytes())
@@ -143,6 +174,10 @@ func genDecl(w *bytes.Buffer, t ops.Type) {
 		fmt.Fprintf(w, "val, ok, _ := c.AsUint64()\n")
 	case t.Flags&ops.IsInteger != 0:
 		fmt.Fprintf(w, "val, ok, _ := c.AsInt64()\n")
+	case t.Flags&ops.IsFloat != 0:
+		fmt.Fprintf(w, "val, ok, _ := c.AsFloat%d()\n", t.Bits)
+	case t.Flags&ops.IsString != 0:
+		fmt.Fprintf(w, "val, ok := evalString(m, c)\n")
 	case t.Flags&ops.IsBigInt != 0:
 		fmt.Fprintf(w, "val, ok := c.AsBigInt()\n")
 	case t.Flags&ops.IsBigRat != 0:
@@ -162,6 +197,10 @@ func genDecl(w *bytes.Buffer, t ops.Type) {
 	case "z3.BV":
 		// TODO: Is this right for unsigned types?
 		fmt.Fprintf(w, "return c.z3.FromInt(int64(x.C), c.sort%s).(z3.BV)\n", t.StName)
+	case "z3.Float":
+		fmt.Fprintf(w, "return c.z3.FromFloat%d(x.C, c.sort%s)\n", t.Bits, t.StName)
+	case "z3.Seq":
+		fmt.Fprintf(w, "return c.fromString(x.C)\n")
 	case "z3.Int":
 		fmt.Fprintf(w, "return c.z3.FromBigInt(x.C, c.sort%s).(z3.Int)\n", t.StName)
 	case "z3.Real":
@@ -170,6 +209,98 @@ func genDecl(w *bytes.Buffer, t ops.Type) {
 	fmt.Fprintf(w, "}\n\n")
 }
 
+// genElem generates the methods t needs to implement Elem.
+func genElem(w *bytes.Buffer, t ops.Type) {
+	symtype := "z3." + t.SymType
+
+	fmt.Fprintf(w, "func (%s) zero() %s {\n", t.StName, t.StName)
+	if t.Flags&(ops.IsBigInt|ops.IsBigRat) != 0 {
+		fmt.Fprintf(w, "return %s{C: new(%s)}\n", t.StName, strings.TrimLeft(t.ConType, "*"))
+	} else {
+		fmt.Fprintf(w, "return %s{}\n", t.StName)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "func (%s) sortOf(c *cache) z3.Sort { return c.sort%s }\n\n", t.StName, t.StName)
+
+	fmt.Fprintf(w, "func (x %s) symValue(c *cache) z3.Value { return x.sym(c) }\n\n", t.StName)
+
+	fmt.Fprintf(w, "func (%s) fromSym(v z3.Value) %s { return %s{S: v.(%s)} }\n\n", t.StName, t.StName, t.StName, symtype)
+
+	fmt.Fprintf(w, "func (x %s) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }\n\n", t.StName)
+
+	fmt.Fprintf(w, "func (x %s) ite(cond Bool, y %s) %s {\n", t.StName, t.StName, t.StName)
+	fmt.Fprintf(w, "cache := getCache(cond.S.Context())\n")
+	fmt.Fprintf(w, "return %s{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(%s)}\n", t.StName, symtype)
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// genMinMax generates Min and Max for ordered types and Abs for
+// signed numeric types.
+func genMinMax(w *bytes.Buffer, t ops.Type) {
+	if t.Flags&ops.Ordered == 0 {
+		return
+	}
+	isFloat := t.Flags&ops.IsFloat != 0
+
+	for _, m := range []struct{ method, word, cmp, zero string }{
+		{"Min", "smaller", "LE", "xs.IsNegative()"},
+		{"Max", "larger", "GE", "ys.IsNegative()"},
+	} {
+		fmt.Fprintf(w, "// %s returns the %s of x and y, like Go's built-in %s.\n", m.method, m.word, strings.ToLower(m.method))
+		if isFloat {
+			fmt.Fprintf(w, "// If either is NaN, the result is NaN.\n")
+		}
+		fmt.Fprintf(w, "func (x %s) %s(y %s) %s {\n", t.StName, m.method, t.StName, t.StName)
+		if !isFloat {
+			fmt.Fprintf(w, "return Ite(x.%s(y), x, y)\n", m.cmp)
+			fmt.Fprintf(w, "}\n\n")
+			continue
+		}
+		fmt.Fprintf(w, "if x.IsConcrete() && y.IsConcrete() {\n")
+		// Unlike math.Min and math.Max, Go's built-ins
+		// return NaN even if the other argument is an
+		// infinity.
+		fmt.Fprintf(w, "if x.C != x.C { return x }\n")
+		fmt.Fprintf(w, "if y.C != y.C { return y }\n")
+		fmt.Fprintf(w, "return %s{C: %s(math.%s(float64(x.C), float64(y.C)))}\n", t.StName, t.ConType, m.method)
+		fmt.Fprintf(w, "}\n")
+		fmt.Fprintf(w, "ctx := x.S.Context()\n")
+		fmt.Fprintf(w, "if ctx == nil { ctx = y.S.Context() }\n")
+		fmt.Fprintf(w, "cache := getCache(ctx)\n")
+		fmt.Fprintf(w, "xs, ys := x.sym(cache), y.sym(cache)\n")
+		// Z3's fp.min and fp.max don't specify the sign of
+		// the result for -0 and +0, but Go does.
+		fmt.Fprintf(w, "pickX := xs.%s(ys).Or(xs.IsZero().And(ys.IsZero(), %s))\n", strings.TrimSuffix(m.cmp, "E")+"T", m.zero)
+		fmt.Fprintf(w, "res := pickX.IfThenElse(xs, ys)\n")
+		fmt.Fprintf(w, "res = ys.IsNaN().IfThenElse(ys, res)\n")
+		fmt.Fprintf(w, "return %s{S: xs.IsNaN().IfThenElse(xs, res).(z3.Float)}\n", t.StName)
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	if t.Flags&(ops.IsInteger|ops.IsFloat|ops.IsBigInt|ops.IsBigRat) == 0 || t.Flags&ops.IsUnsigned != 0 {
+		return
+	}
+	fmt.Fprintf(w, "// Abs returns the absolute value of x.\n")
+	switch {
+	case isFloat:
+		fmt.Fprintf(w, "// Like math.Abs, this clears the sign bit of x.\n")
+	case t.Flags&ops.IsInteger != 0:
+		fmt.Fprintf(w, "// As in Go, the negation of the most negative %s overflows, so\n", t.ConType)
+		fmt.Fprintf(w, "// its absolute value is itself.\n")
+	}
+	fmt.Fprintf(w, "func (x %s) Abs() %s {\n", t.StName, t.StName)
+	if isFloat {
+		fmt.Fprintf(w, "if x.IsConcrete() {\n")
+		fmt.Fprintf(w, "return %s{C: %s(math.Abs(float64(x.C)))}\n", t.StName, t.ConType)
+		fmt.Fprintf(w, "}\n")
+		fmt.Fprintf(w, "return %s{S: x.S.Abs()}\n", t.StName)
+	} else {
+		fmt.Fprintf(w, "return Ite(x.LT(x.zero()), x.Neg(), x)\n")
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
+
 func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 	resType := t.StName
 	if op.Flags&ops.OpCompare != 0 {
@@ -186,7 +317,11 @@ func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 
 	// If x and y are concrete, do concrete operation.
 	fmt.Fprintf(w, "if x.IsConcrete() && y.IsConcrete() {\n")
-	if t.Flags&(ops.IsBigInt|ops.IsBigRat) == 0 {
+	if t.Flags&ops.IsFloat != 0 && op.Flags&ops.OpCompare == 0 {
+		// The explicit conversion forces rounding and
+		// prevents the compiler from fusing operations.
+		fmt.Fprintf(w, "return %s{C: %s(x.C %s y.C)}\n", resType, t.ConType, op.Op)
+	} else if t.Flags&(ops.IsBigInt|ops.IsBigRat) == 0 {
 		fmt.Fprintf(w, "return %s{C: x.C %s y.C}\n", resType, op.Op)
 	} else if op.Flags&ops.OpCompare == 0 {
 		fmt.Fprintf(w, "z := %s{C: new(%s)}\n", resType, strings.TrimLeft(t.ConType, "*"))
@@ -201,10 +336,18 @@ func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 	fmt.Fprintf(w, "ctx := x.S.Context()\n")
 	fmt.Fprintf(w, "if ctx == nil { ctx = y.S.Context() }\n")
 	fmt.Fprintf(w, "cache := getCache(ctx)\n")
+	if op.Method == "Quo" || op.Method == "Rem" {
+		switch {
+		case t.Flags&ops.IsInteger != 0:
+			fmt.Fprintf(w, "cache.checkPanic(y.Eq(y.zero()), %q)\n", "integer divide by zero")
+		case t.Flags&(ops.IsBigInt|ops.IsBigRat) != 0:
+			fmt.Fprintf(w, "cache.checkPanic(y.Eq(y.zero()), %q)\n", "division by zero")
+		}
+	}
 	symop := op.Method
-	if symop == "Quo" && t.Flags&(ops.IsInteger|ops.IsBigRat) != 0 {
-		// On bit-vectors and reals, Go's / operator is
-		// equivalent to Z3's [SU]Div.
+	if symop == "Quo" && t.Flags&(ops.IsInteger|ops.IsFloat|ops.IsBigRat) != 0 {
+		// On bit-vectors, floats, and reals, Go's / operator
+		// is equivalent to Z3's [SU]Div.
 		symop = "Div"
 	}
 	if op.Flags&ops.Z3SignedPrefix != 0 {
@@ -213,7 +356,7 @@ func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 			symop = "U" + symop
 		case t.Flags&ops.IsInteger != 0:
 			symop = "S" + symop
-		case t.Flags&(ops.IsFloat|ops.IsBigInt|ops.IsBigRat) != 0:
+		case t.Flags&(ops.IsFloat|ops.IsString|ops.IsBigInt|ops.IsBigRat) != 0:
 			symop = symop
 		default:
 			panic("bad symop " + symop)
@@ -252,6 +395,26 @@ func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 		}
 	}
 	expr := fmt.Sprintf("x.sym(cache).%s(%s)", symop, rs)
+	if t.Flags&ops.IsFloat != 0 {
+		// Go's == and != follow IEEE 754 equality, where
+		// NaN != NaN and -0 == +0. Z3's Eq is identity.
+		switch symop {
+		case "Eq":
+			expr = "x.sym(cache).IEEEEq(y.sym(cache))"
+		case "NE":
+			expr = "x.sym(cache).IEEEEq(y.sym(cache)).Not()"
+		}
+	}
+	if t.Flags&ops.IsString != 0 {
+		switch symop {
+		case "Add":
+			expr = "x.sym(cache).Concat(y.sym(cache))"
+		case "LT", "LE", "GT", "GE":
+			// Z3 has no lexicographic ordering on
+			// sequences, so we define our own.
+			expr = fmt.Sprintf("cache.string%s(x.sym(cache), y.sym(cache))", symop)
+		}
+	}
 	switch symop {
 	case "AndNot":
 		// There's no Z3 method for this one, but it's easy to
@@ -300,11 +463,29 @@ func genUnOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 }
 
 func genConv(w io.Writer, from, to ops.Type) {
-	if from.Flags&to.Flags&ops.IsInteger == 0 {
+	const numeric = ops.IsInteger | ops.IsFloat
+	if from.Flags&numeric == 0 || to.Flags&numeric == 0 {
 		return
 	}
 	op := ""
 	switch {
+	case from.Flags&ops.IsFloat != 0 && to.Flags&ops.IsFloat != 0:
+		if from.Bits != to.Bits {
+			op = fmt.Sprintf("ToFloat(getCache(x.S.Context()).sort%s)", to.StName)
+		}
+	case to.Flags&ops.IsFloat != 0 && from.Flags&ops.IsUnsigned != 0:
+		op = fmt.Sprintf("UToFloat(getCache(x.S.Context()).sort%s)", to.StName)
+	case to.Flags&ops.IsFloat != 0:
+		op = fmt.Sprintf("SToFloat(getCache(x.S.Context()).sort%s)", to.StName)
+	case from.Flags&ops.IsFloat != 0:
+		// Go truncates toward zero when converting a float
+		// to an integer. Once rounded, the conversion to a
+		// bit-vector is exact.
+		bv := "ToSBV"
+		if to.Flags&ops.IsUnsigned != 0 {
+			bv = "ToUBV"
+		}
+		op = fmt.Sprintf("Round(z3.RoundToZero).%s(%d)", bv, to.Bits)
 	case to.Bits < from.Bits:
 		op = fmt.Sprintf("Extract(%d, 0)", to.Bits-1)
 	case from.Flags&ops.IsUnsigned != 0:
@@ -316,6 +497,269 @@ func genConv(w io.Writer, from, to ops.Type) {
 	fmt.Fprintf(w, "	if x.IsConcrete() {\n")
 	fmt.Fprintf(w, "		return %s{C: %s(x.C)}\n", to.StName, to.ConType)
 	fmt.Fprintf(w, "	}\n")
-	fmt.Fprintf(w, "	return %s{S: x.S.%s}\n", to.StName, op)
+	if op == "" {
+		fmt.Fprintf(w, "	return %s{S: x.S}\n", to.StName)
+	} else {
+		fmt.Fprintf(w, "	return %s{S: x.S.%s}\n", to.StName, op)
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// genChecked generates the overflow-checking variants of the
+// arithmetic operations on fixed-size integer types.
+func genChecked(w io.Writer, t ops.Type) {
+	if t.Flags&ops.IsInteger == 0 {
+		return
+	}
+	signed := t.Flags&ops.IsUnsigned == 0
+
+	// Each check has a concrete Go expression in terms of x.C,
+	// y.C, and the wrapped result z.C, and a symbolic Z3
+	// expression in terms of xs and ys. Both are true if the
+	// operation overflows.
+	type check struct {
+		method, doc string
+		con, sym    string
+	}
+	var checks []check
+	if signed {
+		checks = []check{
+			{"Add", "x + y", "(x.C >= 0) == (y.C >= 0) && (z.C >= 0) != (x.C >= 0)", "xs.SAddNoOverflow(ys).And(xs.SAddNoUnderflow(ys)).Not()"},
+			{"Sub", "x - y", "(x.C >= 0) != (y.C >= 0) && (z.C >= 0) != (x.C >= 0)", "xs.SSubNoOverflow(ys).And(xs.SSubNoUnderflow(ys)).Not()"},
+			// Z3's SMulNoOverflow is wrong for negative
+			// operands in some versions, so check that the
+			// full-width product fits instead.
+			{"Mul", "x * y", "x.C != 0 && (z.C/x.C != y.C || x.C == -1 && y.C != 0 && y.C == -y.C)",
+				fmt.Sprintf("xs.SignExtend(%d).Mul(ys.SignExtend(%d)).Eq(xs.Mul(ys).SignExtend(%d)).Not()", t.Bits, t.Bits, t.Bits)},
+			{"Quo", "x / y", "y.C == -1 && x.C != 0 && x.C == -x.C", "xs.SDivNoOverflow(ys).Not()"},
+		}
+	} else {
+		checks = []check{
+			{"Add", "x + y", "z.C < x.C", "xs.UAddNoOverflow(ys).Not()"},
+			{"Sub", "x - y", "x.C < y.C", "xs.USubNoUnderflow(ys).Not()"},
+			{"Mul", "x * y", "x.C != 0 && z.C/x.C != y.C", "xs.UMulNoOverflow(ys).Not()"},
+			{"Quo", "x / y", "false", ""},
+		}
+	}
+
+	for _, c := range checks {
+		fmt.Fprintf(w, "// %sChecked returns %s and whether the result overflowed\n", c.method, c.doc)
+		fmt.Fprintf(w, "// %s. If it did, z is the wrapped result that %s returns.\n", t.ConType, c.method)
+		if c.method == "Quo" && !signed {
+			fmt.Fprintf(w, "// Unsigned division never overflows.\n")
+		}
+		fmt.Fprintf(w, "func (x %s) %sChecked(y %s) (z %s, overflow Bool) {\n", t.StName, c.method, t.StName, t.StName)
+		fmt.Fprintf(w, "z = x.%s(y)\n", c.method)
+		if c.sym == "" {
+			fmt.Fprintf(w, "return z, Bool{C: false}\n")
+			fmt.Fprintf(w, "}\n\n")
+			continue
+		}
+		fmt.Fprintf(w, "if x.IsConcrete() && y.IsConcrete() {\n")
+		fmt.Fprintf(w, "return z, Bool{C: %s}\n", c.con)
+		fmt.Fprintf(w, "}\n")
+		fmt.Fprintf(w, "ctx := x.S.Context()\n")
+		fmt.Fprintf(w, "if ctx == nil { ctx = y.S.Context() }\n")
+		fmt.Fprintf(w, "cache := getCache(ctx)\n")
+		fmt.Fprintf(w, "xs, ys := x.sym(cache), y.sym(cache)\n")
+		fmt.Fprintf(w, "return z, Bool{S: %s}\n", c.sym)
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	fmt.Fprintf(w, "// NegChecked returns -x and whether the result overflowed %s.\n", t.ConType)
+	if !signed {
+		fmt.Fprintf(w, "// The negation of any non-zero unsigned value overflows.\n")
+	}
+	fmt.Fprintf(w, "func (x %s) NegChecked() (z %s, overflow Bool) {\n", t.StName, t.StName)
+	fmt.Fprintf(w, "z = x.Neg()\n")
+	if !signed {
+		fmt.Fprintf(w, "return z, x.NE(x.zero())\n")
+	} else {
+		fmt.Fprintf(w, "if x.IsConcrete() {\n")
+		fmt.Fprintf(w, "return z, Bool{C: x.C != 0 && x.C == -x.C}\n")
+		fmt.Fprintf(w, "}\n")
+		fmt.Fprintf(w, "return z, Bool{S: x.S.SNegNoOverflow().Not()}\n")
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// genConvChecked generates an overflow-checking variant of the
+// conversion from integer type from to integer type to.
+func genConvChecked(w io.Writer, from, to ops.Type) {
+	if from.Flags&ops.IsInteger == 0 || to.Flags&ops.IsInteger == 0 {
+		return
+	}
+	fmt.Fprintf(w, "// To%sChecked returns %s(x) and whether x is out of the range\n", to.StName, to.ConType)
+	fmt.Fprintf(w, "// of %s. If it is, z is the truncated or wrapped result that\n", to.ConType)
+	fmt.Fprintf(w, "// To%s returns.\n", to.StName)
+	fmt.Fprintf(w, "func (x %s) To%sChecked() (z %s, overflow Bool) {\n", from.StName, to.StName, to.StName)
+	fmt.Fprintf(w, "z = x.To%s()\n", to.StName)
+	// The conversion is exact if it round trips and doesn't
+	// change the sign.
+	fmt.Fprintf(w, "overflow = z.To%s().NE(x)\n", from.StName)
+	switch {
+	case from.Flags&ops.IsUnsigned == 0 && to.Flags&ops.IsUnsigned != 0:
+		fmt.Fprintf(w, "overflow = overflow.Or(x.LT(x.zero()))\n")
+	case from.Flags&ops.IsUnsigned != 0 && to.Flags&ops.IsUnsigned == 0:
+		fmt.Fprintf(w, "overflow = overflow.Or(z.LT(z.zero()))\n")
+	}
+	fmt.Fprintf(w, "return z, overflow\n")
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// genBits generates the equivalents of the math/bits functions for
+// unsigned integer types. The symbolic encodings are in bits.go.
+func genBits(w io.Writer, t ops.Type) {
+	if t.Flags&ops.IsUnsigned == 0 || t.StName == "Uintptr" {
+		// math/bits has no uintptr functions.
+		return
+	}
+	suffix := fmt.Sprint(t.Bits)
+	if t.StName == "Uint" {
+		suffix = ""
+	}
+
+	for _, f := range []struct{ name, doc string }{
+		{"LeadingZeros", "the number of leading zero bits in x"},
+		{"TrailingZeros", "the number of trailing zero bits in x"},
+		{"OnesCount", "the number of one bits in x"},
+		{"Len", "the minimum number of bits required to represent x"},
+	} {
+		fmt.Fprintf(w, "// %s returns %s, like bits.%s%s.\n", f.name, f.doc, f.name, suffix)
+		fmt.Fprintf(w, "func (x %s) %s() Int {\n", t.StName, f.name)
+		fmt.Fprintf(w, "if x.IsConcrete() { return Int{C: bits.%s%s(x.C)} }\n", f.name, suffix)
+		if f.name == "Len" {
+			fmt.Fprintf(w, "return Int{C: %d}.Sub(x.LeadingZeros())\n", t.Bits)
+		} else {
+			fmt.Fprintf(w, "return %s{S: bv%s(x.S)}.ToInt()\n", t.StName, f.name)
+		}
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	for _, f := range []struct{ name, doc string }{
+		{"Reverse", "x with its bits in reversed order"},
+		{"ReverseBytes", "x with its bytes in reversed order"},
+	} {
+		if f.name == "ReverseBytes" && t.Bits == 8 {
+			continue
+		}
+		fmt.Fprintf(w, "// %s returns %s, like bits.%s%s.\n", f.name, f.doc, f.name, suffix)
+		fmt.Fprintf(w, "func (x %s) %s() %s {\n", t.StName, f.name, t.StName)
+		fmt.Fprintf(w, "if x.IsConcrete() { return %s{C: bits.%s%s(x.C)} }\n", t.StName, f.name, suffix)
+		fmt.Fprintf(w, "return %s{S: bv%s(x.S)}\n", t.StName, f.name)
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	fmt.Fprintf(w, "// RotateLeft returns x rotated left by (k mod %d) bits, like\n", t.Bits)
+	fmt.Fprintf(w, "// bits.RotateLeft%s. To rotate right by k bits, pass -k.\n", suffix)
+	fmt.Fprintf(w, "func (x %s) RotateLeft(k Int) %s {\n", t.StName, t.StName)
+	fmt.Fprintf(w, "if x.IsConcrete() && k.IsConcrete() { return %s{C: bits.RotateLeft%s(x.C, k.C)} }\n", t.StName, suffix)
+	fmt.Fprintf(w, "ctx := x.S.Context()\n")
+	fmt.Fprintf(w, "if ctx == nil { ctx = k.S.Context() }\n")
+	fmt.Fprintf(w, "cache := getCache(ctx)\n")
+	// Truncating k preserves k mod the width, since the width
+	// is a power of two.
+	fmt.Fprintf(w, "return %s{S: bvRotateLeft(x.sym(cache), k.To%s().sym(cache))}\n", t.StName, t.StName)
 	fmt.Fprintf(w, "}\n\n")
 }
+
+// genCarry generates the equivalents of the math/bits functions Add,
+// Sub, and Mul for unsigned integer types. math/bits has these only
+// for uint, uint32, and uint64. For uintptr, the concrete results use
+// the uint functions, since uint and uintptr have the same size. For
+// smaller types, they are computed in 32 bits.
+func genCarry(w io.Writer, t ops.Type) {
+	if t.Flags&ops.IsUnsigned == 0 {
+		return
+	}
+	// suffix is the suffix of the function names, and like
+	// describes the math/bits function each one follows.
+	suffix, like := fmt.Sprint(t.Bits), "bits.%s"+fmt.Sprint(t.Bits)
+	switch t.StName {
+	case "Uint":
+		suffix, like = "", "bits.%s"
+	case "Uintptr":
+		suffix, like = "Uintptr", "bits.%s, but for uintptr"
+	case "Uint8", "Uint16":
+		like = "bits.%s, but for " + t.ConType
+	}
+	hi := t.Bits - 1
+
+	// Each function has two operands x and y and an optional
+	// carry or borrow in operand c. op is the Go operator for
+	// computing the results in 32 bits.
+	type carryFunc struct {
+		name, doc, op string
+		c, res, vars  string
+		sym           []string
+	}
+	for _, f := range []carryFunc{
+		{"Add", "the sum with carry of x, y, and carry", "+", "carry", "sum, carryOut", "s, c", []string{
+			"sum = x.Add(y).Add(carry)",
+			fmt.Sprintf("carryOut = x.And(y).Or(x.Or(y).AndNot(sum)).Rsh(Uint64{C: %d})", hi),
+		}},
+		{"Sub", "the difference of x, y, and borrow", "-", "borrow", "diff, borrowOut", "d, b", []string{
+			"diff = x.Sub(y).Sub(borrow)",
+			fmt.Sprintf("borrowOut = x.Not().And(y).Or(x.Xor(y).Not().And(diff)).Rsh(Uint64{C: %d})", hi),
+		}},
+		{"Mul", fmt.Sprintf("the %d-bit product of x and y", 2*t.Bits), "*", "", "hi, lo", "h, l", []string{
+			"ctx := x.S.Context()",
+			"if ctx == nil { ctx = y.S.Context() }",
+			"cache := getCache(ctx)",
+			fmt.Sprintf("p := x.sym(cache).ZeroExtend(%d).Mul(y.sym(cache).ZeroExtend(%d))", t.Bits, t.Bits),
+			fmt.Sprintf("hi, lo = %s{S: p.Extract(%d, %d)}, %s{S: p.Extract(%d, 0)}", t.StName, 2*t.Bits-1, t.Bits, t.StName, hi),
+			"return hi, lo",
+		}},
+	} {
+		params, concrete := "x, y", "x.IsConcrete() && y.IsConcrete()"
+		if f.c == "" {
+			fmt.Fprintf(w, "// %s%s returns %s, like %s.", f.name, suffix, f.doc, fmt.Sprintf(like, f.name))
+		} else {
+			fmt.Fprintf(w, "// %s%s returns %s, like\n", f.name, suffix, f.doc)
+			fmt.Fprintf(w, "// %s. %s must be 0 or 1, and %sOut is 0 or 1.", fmt.Sprintf(like, f.name), f.c, f.c)
+			params += ", " + f.c
+			concrete += " && " + f.c + ".IsConcrete()"
+		}
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "func %s%s(%s %s) (%s %s) {\n", f.name, suffix, params, t.StName, f.res, t.StName)
+		fmt.Fprintf(w, "if %s {\n", concrete)
+		args := []string{"x.C", "y.C"}
+		if f.c != "" {
+			args = append(args, f.c+".C")
+		}
+		switch t.StName {
+		case "Uint8", "Uint16":
+			for i := range args {
+				args[i] = "uint32(" + args[i] + ")"
+			}
+			fmt.Fprintf(w, "p := %s\n", strings.Join(args, " "+f.op+" "))
+			// Add's carry is just above the result. Sub
+			// borrows from the top of the 32-bit result.
+			shift := map[string]int{"Add": t.Bits, "Sub": 31, "Mul": t.Bits}[f.name]
+			r1, r2 := "p", fmt.Sprintf("p >> %d", shift)
+			if f.name == "Mul" {
+				r1, r2 = r2, r1
+			}
+			fmt.Fprintf(w, "return %s{C: %s(%s)}, %s{C: %s(%s)}\n", t.StName, t.ConType, r1, t.StName, t.ConType, r2)
+		case "Uintptr":
+			for i := range args {
+				args[i] = "uint(" + args[i] + ")"
+			}
+			r1, r2, _ := strings.Cut(f.vars, ", ")
+			fmt.Fprintf(w, "%s := bits.%s(%s)\n", f.vars, f.name, strings.Join(args, ", "))
+			fmt.Fprintf(w, "return %s{C: uintptr(%s)}, %s{C: uintptr(%s)}\n", t.StName, r1, t.StName, r2)
+		default:
+			r1, r2, _ := strings.Cut(f.vars, ", ")
+			fmt.Fprintf(w, "%s := bits.%s%s(%s)\n", f.vars, f.name, suffix, strings.Join(args, ", "))
+			fmt.Fprintf(w, "return %s{C: %s}, %s{C: %s}\n", t.StName, r1, t.StName, r2)
+		}
+		fmt.Fprintf(w, "}\n")
+		for _, line := range f.sym {
+			fmt.Fprintf(w, "%s\n", line)
+		}
+		if f.name != "Mul" {
+			fmt.Fprintf(w, "return %s\n", f.res)
+		}
+		fmt.Fprintf(w, "}\n\n")
+	}
+}
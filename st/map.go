@@ -0,0 +1,167 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// Map implements symbolic map[K]V values.
+//
+// A Map is represented by a Z3 array from keys to values, a Z3 array
+// from keys to whether that key is present, and a length. Every key
+// that is not present maps to the zero V, so two Maps with the same
+// keys and values are represented by equal arrays.
+//
+// Keys are compared by identity rather than by Go's == operator. In
+// particular, a floating-point NaN key can be found again, and -0 and
+// +0 are different keys. K's concrete type must be comparable, so K
+// must not be Integer or Real.
+//
+// Like Slices, Maps are values: SetIndex and Delete return a new Map.
+// The zero Map is not associated with a Context and must not be
+// used. Use MakeMap or AnyMap to construct a Map.
+type Map[K Elem[K], V Elem[V]] struct {
+	vals, present z3.Array
+	len           Int
+}
+
+// MakeMap returns an empty Map.
+func MakeMap[K Elem[K], V Elem[V]](ctx *z3.Context) Map[K, V] {
+	cache := getCache(ctx)
+	var k K
+	var v V
+	return Map[K, V]{
+		vals:    cache.z3.ConstArray(k.sortOf(cache), v.zero().symValue(cache)),
+		present: cache.z3.ConstArray(k.sortOf(cache), cache.z3.FromBool(false)),
+	}
+}
+
+// AnyMap returns an unconstrained symbolic Map with at most maxLen
+// keys.
+func AnyMap[K Elem[K], V Elem[V]](ctx *z3.Context, name string, maxLen int) Map[K, V] {
+	cache := getCache(ctx)
+	var k K
+	var v V
+	x := MakeMap[K, V](ctx)
+	// Insert the first n of maxLen unconstrained keys. Inserting a
+	// key more than once has no effect, so any set of at most
+	// maxLen keys is possible without asserting that the keys are
+	// distinct.
+	n := AnyInt(ctx, name+".len")
+	for i := 0; i < maxLen; i++ {
+		key := k.fromSym(cache.z3.FreshConst(fmt.Sprintf("%s.key%d", name, i), k.sortOf(cache)))
+		val := v.fromSym(cache.z3.FreshConst(fmt.Sprintf("%s.val%d", name, i), v.sortOf(cache)))
+		y := x.SetIndex(key, val)
+		x = Ite(Int{C: i}.LT(n), y, x)
+	}
+	return x
+}
+
+func (Map[K, V]) anyValue(ctx *z3.Context, name string) interface{} {
+	return AnyMap[K, V](ctx, name, anyLen)
+}
+
+// ite returns cond ? x : y for symbolic cond.
+func (x Map[K, V]) ite(cond Bool, y Map[K, V]) Map[K, V] {
+	return Map[K, V]{
+		vals:    cond.S.IfThenElse(x.vals, y.vals).(z3.Array),
+		present: cond.S.IfThenElse(x.present, y.present).(z3.Array),
+		len:     Ite(cond, x.len, y.len),
+	}
+}
+
+// cache returns the cache for x's Context.
+func (x Map[K, V]) cache() *cache {
+	ctx := x.vals.Context()
+	if ctx == nil {
+		panic("use of zero Map")
+	}
+	return getCache(ctx)
+}
+
+// String returns x as a string.
+func (x Map[K, V]) String() string {
+	return fmt.Sprintf("(map %s %s %s)", x.vals, x.present, x.len)
+}
+
+// Eval returns x's concrete value in model m as a Go map whose key
+// and value types are K's and V's concrete types. For example, Eval
+// on a Map[String, Int] returns a map[string]int.
+func (x Map[K, V]) Eval(m *z3.Model) interface{} {
+	var k K
+	var v V
+	res := reflect.MakeMap(reflect.MapOf(concreteType[K](), concreteType[V]()))
+	present := m.Eval(x.present, true).(z3.Array)
+	def, keys, isPresent, ok := present.AsStores()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + present.String())
+	}
+	if val, _ := def.(z3.Bool).AsBool(); val {
+		panic("model evaluation produced infinite map " + present.String())
+	}
+	for i, key := range keys {
+		kv := k.fromSym(key).evalValue(m)
+		if val, _ := isPresent[i].(z3.Bool).AsBool(); !val {
+			res.SetMapIndex(kv, reflect.Value{})
+			continue
+		}
+		res.SetMapIndex(kv, v.fromSym(x.vals.Select(key)).evalValue(m))
+	}
+	return res.Interface()
+}
+
+// Len returns len(x).
+func (x Map[K, V]) Len() Int {
+	return x.len
+}
+
+// Index returns v, ok := x[k].
+func (x Map[K, V]) Index(k K) (v V, ok Bool) {
+	cache := x.cache()
+	ks := k.symValue(cache)
+	v = v.fromSym(x.vals.Select(ks))
+	return v, Bool{S: x.present.Select(ks).(z3.Bool)}
+}
+
+// SetIndex returns a copy of x with x[k] = v.
+func (x Map[K, V]) SetIndex(k K, v V) Map[K, V] {
+	cache := x.cache()
+	ks := k.symValue(cache)
+	_, had := x.Index(k)
+	return Map[K, V]{
+		vals:    x.vals.Store(ks, v.symValue(cache)),
+		present: x.present.Store(ks, cache.z3.FromBool(true)),
+		len:     Ite(had, x.len, x.len.Add(Int{C: 1})),
+	}
+}
+
+// Delete returns a copy of x with k deleted, like delete(x, k).
+func (x Map[K, V]) Delete(k K) Map[K, V] {
+	cache := x.cache()
+	ks := k.symValue(cache)
+	_, had := x.Index(k)
+	var v V
+	return Map[K, V]{
+		vals:    x.vals.Store(ks, v.zero().symValue(cache)),
+		present: x.present.Store(ks, cache.z3.FromBool(false)),
+		len:     Ite(had, x.len.Sub(Int{C: 1}), x.len),
+	}
+}
+
+// Eq returns whether x and y have the same keys and each key maps to
+// the same value in both. Go does not permit comparing maps, so this
+// has no Go equivalent. Like keys, values are compared by identity.
+func (x Map[K, V]) Eq(y Map[K, V]) Bool {
+	return Bool{S: x.present.Eq(y.present).And(x.vals.Eq(y.vals))}
+}
+
+// NE returns x.Eq(y).Not().
+func (x Map[K, V]) NE(y Map[K, V]) Bool {
+	return x.Eq(y).Not()
+}
@@ -25,7 +25,7 @@
 //	x + y	x.Add(y)
 //	x - y	x.Sub(y)
 //	x * y	x.Mul(y)
-//	x / y	x.Div(y)	(does not panic on symbolic divide by 0)
+//	x / y	x.Quo(y)	(see PanicLog for symbolic divide by 0)
 //	x % y	x.Rem(y)
 //
 //	x & y	x.And(y)
@@ -49,10 +49,107 @@
 //	^x	x.Not()
 //	!x	x.Not() 	(Bool only)
 //
+//	min(x, y)	x.Min(y)
+//	max(x, y)	x.Max(y)
+//
+// Signed numeric types also have an Abs method. The function Ite
+// returns one of two values of any type depending on a Bool. It is
+// the symbolic equivalent of an if statement that assigns to a
+// variable on both branches.
+//
 // For any pair of types T and U that support conversion in Go, T has
 // a method ToU() that returns a U value.
 //
-// TODO: Float, complex, and string types.
+// Like Go, fixed-size integer arithmetic wraps on overflow. The
+// integer types also have checked variants of Add, Sub, Mul, Quo,
+// Neg, and conversions to other integer types, such as AddChecked and
+// ToInt8Checked. These return the wrapped result and a Bool that is
+// true if the operation overflowed, so a solver can find inputs that
+// overflow or prove that none do.
+//
+// The unsigned integer types also have equivalents of the math/bits
+// functions, such as x.LeadingZeros() for bits.LeadingZeros32(x) and
+// x.RotateLeft(k) for bits.RotateLeft32(x, k). The functions Add,
+// Add32, Add64, Sub, Sub32, Sub64, Mul, Mul32, and Mul64 are
+// equivalent to the corresponding math/bits functions. Add8, Add16,
+// and AddUintptr, and the corresponding Sub and Mul functions, extend
+// these to the other unsigned types.
+//
+// Float32 and Float64 follow Go's IEEE 754 semantics: x.Eq(y) is
+// false if either is NaN and -0 equals +0, and converting a float to
+// an integer truncates toward zero. Symbolic float operations use the
+// Context's rounding mode, which must be z3.RoundToNearestEven (the
+// default) to match Go. As in Go, converting a float to an integer
+// type that cannot represent its value produces an unspecified
+// result.
+//
+// String represents Go strings as sequences of bytes, so indexing,
+// slicing, and length follow Go's byte semantics. String also
+// provides equivalents of several operations and functions from the
+// strings package:
+//
+//	len(s)	s.Len()
+//	s[i]	s.Index(i)
+//	s[i:j]	s.Slice(i, j)
+//
+//	strings.HasPrefix(s, t)	s.HasPrefix(t)
+//	strings.HasSuffix(s, t)	s.HasSuffix(t)
+//	strings.Contains(s, t)	s.Contains(t)
+//	strings.Index(s, t)	s.IndexOf(t)
+//
+// A []byte can be converted to a String with BytesToString. Int32
+// (rune) and Uint8 (byte) values can be converted to a String with
+// ToString.
+//
+// Complex64 and Complex128 are represented as a pair of floats.
+// Symbolic complex values have type SymComplex. MakeComplex64 and
+// MakeComplex128 correspond to Go's complex built-in, and the Real and
+// Imag methods correspond to real and imag. Multiplication and
+// division follow the algorithms used by the Go runtime, except that
+// the result of an operation that produces a NaN has no sign.
+//
+// Slice[T] and Array[T] represent []T and [n]T, where T is any type in
+// this package that satisfies Elem. Their operations correspond to Go
+// operations as follows:
+//
+//	len(s)		s.Len()
+//	cap(s)		s.Cap()
+//	s[i]		s.Index(i)
+//	s[i] = v	s.SetIndex(i, v)
+//	s[i:j:k]	s.Slice(i, j, k)
+//	append(s, v...)	s.Append(v...)
+//
+// Map[K, V] represents map[K]V:
+//
+//	len(m)		m.Len()
+//	v, ok := m[k]	v, ok := m.Index(k)
+//	m[k] = v	m.SetIndex(k, v)
+//	delete(m, k)	m.Delete(k)
+//
+// Struct represents values of an arbitrary Go struct type and
+// Pointer represents values of a Go pointer type. Any and ValueOf use
+// reflection to construct symbolic values mirroring Go types,
+// including structs and pointers. Pointers refer to values in a
+// Heap, which models memory:
+//
+//	*p		h.Load(p)
+//	*p = v		h.Store(p, v)
+//	new(T)		h.New(T)
+//
+// An Explorer symbolically executes Go code that branches on symbolic
+// conditions using Path.If, exploring each feasible path and
+// producing its path condition and a model of its inputs.
+//
+// Operations that can panic in Go, such as Index, return an
+// additional Bool that is true if the Go operation would panic. A
+// PanicLog collects these conditions, as well as those of integer
+// division, from all operations on symbolic values.
+//
+// Prove checks that a property, written as a Go function from
+// symbolic values to Bool, holds for all inputs, and returns a
+// counterexample as its error if it does not. FindCounterexample
+// returns the counterexample directly. Package sttest reports these
+// failures in tests.
 package st
 
 // RealApproxDigits is the number of decimal digits an irrational real
@@ -0,0 +1,101 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import "github.com/aclements/go-z3/z3"
+
+// A PanicLog records the conditions under which symbolic operations
+// would panic in Go.
+//
+// Operations on concrete values panic just like the corresponding Go
+// operations, and so do operations on symbolic values that would
+// panic for every input, such as dividing by a concrete zero.
+// Otherwise, operations on symbolic values don't panic because they
+// compute results for every possible input at once. Instead, while a
+// PanicLog is active for a Context, these operations record an
+// Obligation in the log giving the condition under which the Go
+// operation would have panicked. Asserting PanicLog.MayPanic
+// in a solver then finds inputs that cause a runtime panic, or
+// proves that there are none.
+//
+// The operations that record obligations are integer division and
+// remainder by zero, out-of-bounds indexing and slicing of Strings,
+// Slices, and Arrays, invalid MakeSlice arguments, and nil pointer
+// dereferences in a Heap. Shifts cannot panic because shift counts
+// are always unsigned, and conversions never panic in Go.
+//
+// If an operation is executed on a Path of an Explorer, its
+// obligation is conditioned on the Path's condition at the time of
+// the operation.
+type PanicLog struct {
+	cache       *cache
+	obligations []Obligation
+
+	// path is the Path currently being explored, or nil.
+	path *Path
+}
+
+// An Obligation is a condition under which an operation would panic.
+type Obligation struct {
+	// Cond is true for inputs that cause the operation to panic.
+	Cond Bool
+
+	// Msg is the panic message, such as "integer divide by zero".
+	Msg string
+}
+
+// RecordPanics starts recording panic obligations for operations on
+// symbolic values in ctx and returns the log they will be recorded
+// in. This replaces any existing PanicLog for ctx.
+func RecordPanics(ctx *z3.Context) *PanicLog {
+	c := getCache(ctx)
+	l := &PanicLog{cache: c}
+	c.panics = l
+	return l
+}
+
+// Stop stops recording obligations in l.
+func (l *PanicLog) Stop() {
+	if l.cache.panics == l {
+		l.cache.panics = nil
+	}
+}
+
+// Obligations returns the obligations recorded in l.
+func (l *PanicLog) Obligations() []Obligation {
+	return l.obligations
+}
+
+// MayPanic returns a Bool that is true for inputs that cause any
+// recorded operation to panic.
+func (l *PanicLog) MayPanic() Bool {
+	res := Bool{C: false}
+	for _, o := range l.obligations {
+		res = res.Or(o.Cond)
+	}
+	return res
+}
+
+// checkPanic is like recordPanic, but if cond is concretely true it
+// panics with msg immediately, just as the Go operation would.
+func (c *cache) checkPanic(cond Bool, msg string) {
+	if cond.IsConcrete() && cond.C {
+		panic(msg)
+	}
+	c.recordPanic(cond, msg)
+}
+
+// recordPanic records that a Go operation would panic with message
+// msg if cond is true.
+func (c *cache) recordPanic(cond Bool, msg string) {
+	l := c.panics
+	if l == nil || (cond.IsConcrete() && !cond.C) {
+		return
+	}
+	if l.path != nil {
+		cond = l.path.Cond().And(cond)
+	}
+	l.obligations = append(l.obligations, Obligation{cond, msg})
+}
@@ -0,0 +1,325 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"math/rand"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// A Strategy determines the order in which an Explorer explores
+// paths.
+type Strategy int
+
+const (
+	// DFS explores the most recently discovered path first.
+	DFS Strategy = iota
+
+	// BFS explores the least recently discovered path first. This
+	// tends to explore paths with fewer branches first.
+	BFS
+
+	// Random explores a randomly chosen path next, and takes a
+	// random side of each new branch.
+	Random
+)
+
+// An Explorer symbolically executes a function along every feasible
+// path through it.
+//
+// The function being explored branches on symbolic conditions by
+// calling Path.If. Since Go can't fork a running function, the
+// Explorer discovers paths by re-running the function: each run
+// follows a recorded sequence of earlier decisions and then explores
+// new branches, recording the untaken side of each feasible branch
+// to explore in a later run. Hence, the function must be
+// deterministic apart from its calls to Path.If.
+type Explorer struct {
+	ctx *z3.Context
+
+	// Strategy is the order in which to explore paths.
+	Strategy Strategy
+
+	// MaxDepth, if non-zero, bounds the number of symbolic
+	// branches on each path. Paths that reach this bound are
+	// stopped and reported with Truncated set.
+	MaxDepth int
+
+	// MaxPaths, if non-zero, bounds the number of paths to
+	// explore.
+	MaxPaths int
+
+	// Rand is the source of randomness for the Random strategy. If
+	// nil, Explore uses a source with a fixed seed.
+	Rand *rand.Rand
+}
+
+// NewExplorer returns a new Explorer that uses the DFS strategy and
+// does not bound exploration.
+func NewExplorer(ctx *z3.Context) *Explorer {
+	return &Explorer{ctx: ctx}
+}
+
+// Follow runs f along the path taken by the concrete inputs given by
+// model m, without checking the feasibility of branches or exploring
+// other paths. The returned Path's model is m. This is the basis of
+// concolic execution, where new inputs are derived by negating the
+// branches of a concrete run. If f stops the path because of a
+// failed Path.Assume, Follow returns nil.
+func Follow(ctx *z3.Context, m *z3.Model, f func(p *Path)) *Path {
+	p := &Path{ctx: ctx, follow: m}
+	if !p.run(f) {
+		return nil
+	}
+	return p
+}
+
+// A Path is a single path through a function being explored.
+type Path struct {
+	ctx    *z3.Context
+	solver *z3.Solver
+	rand   *rand.Rand
+
+	// prefix is the sequence of decisions this path must follow
+	// before exploring new branches.
+	prefix []bool
+	// decisions is the sequence of decisions taken so far at
+	// symbolic branches.
+	decisions []bool
+	// forks is the decision sequences of untaken feasible branches
+	// discovered by this path.
+	forks [][]bool
+
+	// branches is the sequence of conditions taken at symbolic
+	// branches. conds additionally includes assumptions.
+	// branchConds[i] is the length of conds before branch i.
+	branches    []Bool
+	conds       []Bool
+	branchConds []int
+	maxDepth    int
+
+	// follow, if non-nil, is the model whose values determine the
+	// direction of each branch.
+	follow *z3.Model
+
+	model *z3.Model
+
+	// Truncated indicates that this path was stopped because it
+	// reached the Explorer's MaxDepth.
+	Truncated bool
+}
+
+// stopPath is panicked to stop executing the current path.
+type stopPath struct{}
+
+// Explore calls f once for each feasible path through f and returns
+// the explored paths. Infeasible paths, such as those stopped by
+// Path.Assume, are not returned.
+func (e *Explorer) Explore(f func(p *Path)) []*Path {
+	rnd := e.Rand
+	if rnd == nil && e.Strategy == Random {
+		rnd = rand.New(rand.NewSource(1))
+	}
+
+	var paths []*Path
+	work := [][]bool{nil}
+	for len(work) > 0 {
+		if e.MaxPaths != 0 && len(paths) >= e.MaxPaths {
+			break
+		}
+
+		var prefix []bool
+		switch e.Strategy {
+		case DFS:
+			prefix, work = work[len(work)-1], work[:len(work)-1]
+		case BFS:
+			prefix, work = work[0], work[1:]
+		case Random:
+			i := rnd.Intn(len(work))
+			prefix = work[i]
+			work[i] = work[len(work)-1]
+			work = work[:len(work)-1]
+		}
+
+		p := &Path{
+			ctx:      e.ctx,
+			solver:   z3.NewSolver(e.ctx),
+			prefix:   prefix,
+			maxDepth: e.MaxDepth,
+		}
+		if e.Strategy == Random {
+			p.rand = rnd
+		}
+		if p.run(f) {
+			paths = append(paths, p)
+		}
+		// p.forks is ordered from the root, so DFS will
+		// explore the deepest fork next.
+		work = append(work, p.forks...)
+	}
+	return paths
+}
+
+// run runs f along p and reports whether p is feasible.
+func (p *Path) run(f func(p *Path)) (feasible bool) {
+	// Condition panic obligations on this path.
+	if l := getCache(p.ctx).panics; l != nil {
+		l.path = p
+		defer func() { l.path = nil }()
+	}
+	defer func() {
+		if err := recover(); err != nil {
+			if _, ok := err.(stopPath); !ok {
+				panic(err)
+			}
+			feasible = p.Truncated
+		}
+		if feasible && p.follow != nil {
+			p.model = p.follow
+		} else if feasible {
+			if sat, _ := p.solver.Check(); sat {
+				p.model = p.solver.Model()
+			}
+		}
+	}()
+	f(p)
+	return true
+}
+
+// If returns the value of cond on this path. If cond is symbolic
+// and both outcomes are feasible, the Explorer will also explore the
+// other outcome on another path.
+func (p *Path) If(cond Bool) bool {
+	if cond.IsConcrete() {
+		return cond.C
+	}
+
+	var dir bool
+	if p.follow != nil {
+		dir = p.eval(cond)
+	} else if i := len(p.decisions); i < len(p.prefix) {
+		dir = p.prefix[i]
+	} else {
+		if p.maxDepth != 0 && len(p.decisions) >= p.maxDepth {
+			p.Truncated = true
+			panic(stopPath{})
+		}
+		canTrue, canFalse := p.feasible(cond), p.feasible(cond.Not())
+		switch {
+		case canTrue && canFalse:
+			dir = true
+			if p.rand != nil {
+				dir = p.rand.Intn(2) == 0
+			}
+			fork := append(append([]bool(nil), p.decisions...), !dir)
+			p.forks = append(p.forks, fork)
+		case canTrue:
+			dir = true
+		case canFalse:
+			dir = false
+		default:
+			// The path condition itself is infeasible.
+			panic(stopPath{})
+		}
+	}
+
+	p.decisions = append(p.decisions, dir)
+	if !dir {
+		cond = cond.Not()
+	}
+	p.branches = append(p.branches, cond)
+	p.branchConds = append(p.branchConds, len(p.conds))
+	p.conds = append(p.conds, cond)
+	if p.follow == nil {
+		p.solver.Assert(cond.S)
+	}
+	return dir
+}
+
+// eval returns the value of cond in p.follow.
+func (p *Path) eval(cond Bool) bool {
+	val, ok := p.follow.Eval(cond.S, true).(z3.Bool).AsBool()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + cond.String())
+	}
+	return val
+}
+
+// feasible returns whether cond may be true on this path.
+func (p *Path) feasible(cond Bool) bool {
+	p.solver.Push()
+	defer p.solver.Pop()
+	p.solver.Assert(cond.S)
+	sat, err := p.solver.Check()
+	// Conservatively consider unknown conditions feasible.
+	return sat || err != nil
+}
+
+// Assume adds cond to the path condition. If cond cannot be true on
+// this path, Assume stops the path and it will not be reported by
+// Explore.
+func (p *Path) Assume(cond Bool) {
+	if cond.IsConcrete() {
+		if !cond.C {
+			panic(stopPath{})
+		}
+		return
+	}
+	if p.follow != nil {
+		if !p.eval(cond) {
+			panic(stopPath{})
+		}
+	} else if !p.feasible(cond) {
+		panic(stopPath{})
+	} else {
+		p.solver.Assert(cond.S)
+	}
+	p.conds = append(p.conds, cond)
+}
+
+// Branches returns the conditions taken at each symbolic branch on
+// this path, in order. Unlike Cond, this does not include
+// assumptions.
+func (p *Path) Branches() []Bool {
+	return p.branches
+}
+
+// Decisions returns the outcome of each symbolic branch on this path,
+// in order.
+func (p *Path) Decisions() []bool {
+	return p.decisions
+}
+
+// Cond returns the path condition: the conjunction of the symbolic
+// branch conditions taken by this path and its assumptions.
+func (p *Path) Cond() Bool {
+	res := Bool{C: true}
+	for _, cond := range p.conds {
+		res = res.And(cond)
+	}
+	return res
+}
+
+// CondBefore returns the path condition just before symbolic branch
+// i: the conjunction of the first i branch conditions and the
+// assumptions made before branch i. Conjoining this with the negation
+// of branch i gives the condition for inputs that follow this path
+// up to branch i and then diverge.
+func (p *Path) CondBefore(i int) Bool {
+	res := Bool{C: true}
+	for _, cond := range p.conds[:p.branchConds[i]] {
+		res = res.And(cond)
+	}
+	return res
+}
+
+// Model returns a model of the path condition, which gives concrete
+// values for the symbolic inputs that follow this path. It returns
+// nil if the solver could not find a model, or if it is called
+// before f returns.
+func (p *Path) Model() *z3.Model {
+	return p.model
+}
@@ -0,0 +1,241 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// Pointer implements symbolic values of a Go pointer type.
+//
+// A Pointer is an address in a Heap. The nil pointer has address 0.
+// Pointers returned by AnyPointer have non-negative addresses and
+// pointers allocated by Heap.New have negative addresses, so newly
+// allocated objects never alias pointers that were passed in.
+//
+// The zero Pointer has no type and must not be used. Use AnyPointer,
+// NilPointer, or Heap.New to construct a Pointer.
+type Pointer struct {
+	typ  reflect.Type
+	addr Int
+}
+
+// AnyPointer returns an unconstrained symbolic pointer of Go pointer
+// type typ. The result may be nil and may alias any other pointer of
+// the same type returned by AnyPointer.
+func AnyPointer(ctx *z3.Context, name string, typ reflect.Type) Pointer {
+	if typ.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("%s is not a pointer type", typ))
+	}
+	addr := AnyInt(ctx, name).And(Int{C: math.MaxInt})
+	return Pointer{typ, addr}
+}
+
+// NilPointer returns the nil pointer of Go pointer type typ.
+func NilPointer(typ reflect.Type) Pointer {
+	if typ.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("%s is not a pointer type", typ))
+	}
+	return Pointer{typ: typ}
+}
+
+func (Pointer) goTyped() {}
+
+// Type returns the Go pointer type of p.
+func (p Pointer) Type() reflect.Type {
+	return p.typ
+}
+
+// String returns p as a string.
+func (p Pointer) String() string {
+	return fmt.Sprintf("(%s)(%s)", p.typ, p.addr)
+}
+
+// IsConcrete returns true if p's address is concrete.
+func (p Pointer) IsConcrete() bool {
+	return p.addr.IsConcrete()
+}
+
+// Eval returns a Go pointer of type p.Type() that is nil if p is nil
+// in model m. Otherwise, it points to a new zero value. Use Heap.Eval
+// to evaluate the value p points to.
+func (p Pointer) Eval(m *z3.Model) interface{} {
+	return p.evalValue(m).Interface()
+}
+
+func (p Pointer) symValue(c *cache) z3.Value {
+	return p.addr.sym(c)
+}
+
+func (p Pointer) evalValue(m *z3.Model) reflect.Value {
+	if p.addr.Eval(m) == 0 {
+		return reflect.Zero(p.typ)
+	}
+	return reflect.New(p.typ.Elem())
+}
+
+// ite returns cond ? p : q for symbolic cond.
+func (p Pointer) ite(cond Bool, q Pointer) Pointer {
+	if p.typ != q.typ {
+		panic(fmt.Sprintf("mismatched types %s and %s", p.typ, q.typ))
+	}
+	return Pointer{p.typ, Ite(cond, p.addr, q.addr)}
+}
+
+// IsNil returns p == nil.
+func (p Pointer) IsNil() Bool {
+	return p.addr.Eq(Int{})
+}
+
+// Eq returns p == q.
+func (p Pointer) Eq(q Pointer) Bool {
+	if p.typ != q.typ {
+		panic(fmt.Sprintf("cannot compare %s and %s", p.typ, q.typ))
+	}
+	return p.addr.Eq(q.addr)
+}
+
+// NE returns p != q.
+func (p Pointer) NE(q Pointer) Bool {
+	return p.Eq(q).Not()
+}
+
+// Heap is a symbolic memory that maps Pointers to the values they
+// point to.
+//
+// A Heap has a separate memory for each pointed-to type, so Pointers
+// of different types never alias.
+//
+// Like other values in this package, Heaps are immutable: Store and
+// New return a new Heap.
+type Heap struct {
+	base *heapBase
+	// mem maps pointed-to types to memories that differ from
+	// base. It is copied on write.
+	mem  map[reflect.Type]z3.Array
+	next int
+}
+
+// heapBase is the initial, unconstrained memory of a Heap. It is
+// shared by all Heaps derived from the same AnyHeap.
+type heapBase struct {
+	cache *cache
+	name  string
+	mem   map[reflect.Type]z3.Array
+}
+
+// AnyHeap returns a Heap in which every address holds an
+// unconstrained value.
+func AnyHeap(ctx *z3.Context, name string) Heap {
+	return Heap{base: &heapBase{getCache(ctx), name, make(map[reflect.Type]z3.Array)}}
+}
+
+// memory returns the memory of h for values of type typ.
+func (h Heap) memory(typ reflect.Type) z3.Array {
+	if mem, ok := h.mem[typ]; ok {
+		return mem
+	}
+	b := h.base
+	if mem, ok := b.mem[typ]; ok {
+		return mem
+	}
+	c := b.cache
+	sort := c.z3.ArraySort(c.sortInt, c.sortOfType(typ))
+	mem := c.z3.FreshConst(fmt.Sprintf("%s[%s]", b.name, typ), sort).(z3.Array)
+	b.mem[typ] = mem
+	return mem
+}
+
+// withMemory returns a copy of h with the memory for typ set to mem.
+func (h Heap) withMemory(typ reflect.Type, mem z3.Array) Heap {
+	nmem := make(map[reflect.Type]z3.Array, len(h.mem)+1)
+	for k, v := range h.mem {
+		nmem[k] = v
+	}
+	nmem[typ] = mem
+	return Heap{h.base, nmem, h.next}
+}
+
+// Load returns *p. nilDeref is true if p is nil, in which case the
+// returned value is unspecified. Like *p, Load panics if p is
+// concretely nil.
+func (h Heap) Load(p Pointer) (v Value, nilDeref Bool) {
+	h.base.cache.checkPanic(p.IsNil(), "invalid memory address or nil pointer dereference")
+	return h.load(p), p.IsNil()
+}
+
+// load is like Load, but does not check for nil.
+func (h Heap) load(p Pointer) Value {
+	elem := p.typ.Elem()
+	return fromSymType(elem, h.memory(elem).Select(p.addr.sym(h.base.cache)))
+}
+
+// Store returns a copy of h in which *p = v. v must have the st type
+// corresponding to p's element type. nilDeref is true if p is nil, in
+// which case the returned Heap is unspecified. Like Load, Store
+// panics if p is concretely nil.
+func (h Heap) Store(p Pointer, v Value) (h2 Heap, nilDeref Bool) {
+	c := h.base.cache
+	c.checkPanic(p.IsNil(), "invalid memory address or nil pointer dereference")
+	elem := p.typ.Elem()
+	mem := h.memory(elem).Store(p.addr.sym(c), v.symValue(c))
+	return h.withMemory(elem, mem), p.IsNil()
+}
+
+// New returns a copy of h with a newly allocated zero value of Go
+// type typ, and a pointer to that value, like new(typ).
+func (h Heap) New(typ reflect.Type) (Heap, Pointer) {
+	c := h.base.cache
+	p := Pointer{reflect.PtrTo(typ), Int{C: -(h.next + 1)}}
+	zero := valueOf(c, reflect.Zero(typ))
+	mem := h.memory(typ).Store(p.addr.sym(c), zero.symValue(c))
+	h2 := h.withMemory(typ, mem)
+	h2.next++
+	return h2, p
+}
+
+// Eval returns v's concrete value in model m, following pointers in
+// h. Unlike v's Eval method, this evaluates the values that pointers
+// in v point to, recursively. Pointers to the same address evaluate
+// to the same Go pointer.
+func (h Heap) Eval(m *z3.Model, v Value) interface{} {
+	ptrs := make(map[heapAddr]reflect.Value)
+	return h.eval(m, v, ptrs).Interface()
+}
+
+type heapAddr struct {
+	typ  reflect.Type
+	addr int
+}
+
+func (h Heap) eval(m *z3.Model, v Value, ptrs map[heapAddr]reflect.Value) reflect.Value {
+	switch v := v.(type) {
+	case Struct:
+		res := reflect.New(v.typ).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			f := field(res, i)
+			f.Set(h.eval(m, v.Field(i), ptrs).Convert(f.Type()))
+		}
+		return res
+	case Pointer:
+		addr := heapAddr{v.typ, v.addr.Eval(m)}
+		if addr.addr == 0 {
+			return reflect.Zero(v.typ)
+		}
+		if p, ok := ptrs[addr]; ok {
+			return p
+		}
+		p := reflect.New(v.typ.Elem())
+		ptrs[addr] = p
+		elem := h.load(v)
+		p.Elem().Set(h.eval(m, elem, ptrs).Convert(v.typ.Elem()))
+		return p
+	}
+	return v.evalValue(m)
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// A Counterexample is a set of arguments for which a property does
+// not hold. It implements error.
+type Counterexample struct {
+	// Args are the concrete Go values of the property's
+	// arguments, as returned by each argument's Eval method. For
+	// example, an Int32 argument gives an int32. A Heap has no
+	// single concrete value, so Heap arguments give nil.
+	Args []interface{}
+
+	// Model is the model the arguments were derived from.
+	Model *z3.Model
+}
+
+// String returns the arguments of c, like "arg0 = 1, arg1 = "x"".
+func (c *Counterexample) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = fmt.Sprintf("arg%d = %#v", i, arg)
+	}
+	return strings.Join(args, ", ")
+}
+
+func (c *Counterexample) Error() string {
+	return "property does not hold for " + c.String()
+}
+
+// UnknownError is returned by Prove and FindCounterexample when the
+// solver cannot determine whether a property holds.
+type UnknownError struct {
+	// Reason is the solver's reason for failing.
+	Reason string
+}
+
+func (e *UnknownError) Error() string {
+	return "cannot prove property: " + e.Reason
+}
+
+// Timeout returns whether the solver failed because it ran out of
+// time or was interrupted. A solver's time limit can be set with the
+// "timeout" parameter of its Context's Config.
+func (e *UnknownError) Timeout() bool {
+	return e.Reason == "timeout" || e.Reason == "canceled"
+}
+
+// Prove attempts to prove that prop returns true for all arguments.
+// prop and goTypes are as for FindCounterexample.
+//
+// If prop holds for all arguments, Prove returns nil. If it does not,
+// Prove returns a *Counterexample. If the solver cannot determine
+// whether prop holds, Prove returns an *UnknownError.
+func Prove(ctx *z3.Context, prop interface{}, goTypes ...reflect.Type) error {
+	cex, err := FindCounterexample(ctx, prop, goTypes...)
+	if err != nil {
+		return err
+	}
+	if cex != nil {
+		return cex
+	}
+	return nil
+}
+
+// FindCounterexample searches for arguments for which prop returns
+// false. prop must be a function that returns a Bool and whose
+// arguments have types from this package, such as Int32, String, or
+// Slice[Uint8]. FindCounterexample calls prop once with an
+// unconstrained symbolic value for each argument, as constructed by
+// AnyValue.
+//
+// Struct, Pointer, and Array arguments do not record the Go type they
+// represent, so goTypes must give the Go type of each of these
+// arguments in order, as accepted by AnyValue. Like AnyValue,
+// FindCounterexample only considers Slices and Maps of up to 4
+// elements.
+//
+// If prop holds for all arguments, FindCounterexample returns nil,
+// nil. If the solver cannot determine whether prop holds, it returns
+// an *UnknownError. It returns any other error from the solver
+// unchanged.
+func FindCounterexample(ctx *z3.Context, prop interface{}, goTypes ...reflect.Type) (*Counterexample, error) {
+	fn := reflect.ValueOf(prop)
+	ft := fn.Type()
+	if ft.Kind() != reflect.Func || ft.NumOut() != 1 || ft.Out(0) != reflect.TypeOf(Bool{}) {
+		panic(fmt.Sprintf("property must be a function returning Bool; got %s", ft))
+	}
+
+	args := make([]reflect.Value, ft.NumIn())
+	for i := range args {
+		typ := ft.In(i)
+		if needsGoType(typ) {
+			if len(goTypes) == 0 {
+				panic(fmt.Sprintf("missing Go type for argument %d of type %s", i, typ))
+			}
+			typ, goTypes = goTypes[0], goTypes[1:]
+		}
+		args[i] = reflect.ValueOf(AnyValue(ctx, fmt.Sprintf("arg%d", i), typ))
+		if args[i].Type() != ft.In(i) {
+			panic(fmt.Sprintf("Go type %s of argument %d is represented by %s, not %s", typ, i, args[i].Type(), ft.In(i)))
+		}
+	}
+	if len(goTypes) != 0 {
+		panic(fmt.Sprintf("%d unused Go types", len(goTypes)))
+	}
+	res := fn.Call(args)[0].Interface().(Bool)
+
+	solver := z3.NewSolver(ctx)
+	solver.Assert(res.Not().sym(getCache(ctx)))
+	sat, err := solver.Check()
+	if err != nil {
+		if unknown, ok := err.(*z3.ErrSatUnknown); ok {
+			return nil, &UnknownError{unknown.Reason}
+		}
+		return nil, err
+	}
+	if !sat {
+		return nil, nil
+	}
+	m := solver.Model()
+	cex := &Counterexample{Model: m}
+	for _, arg := range args {
+		var val interface{}
+		if eval := arg.MethodByName("Eval"); eval.Type().NumIn() == 1 {
+			val = eval.Call([]reflect.Value{reflect.ValueOf(m)})[0].Interface()
+		}
+		cex.Args = append(cex.Args, val)
+	}
+	return cex, nil
+}
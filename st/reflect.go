@@ -0,0 +1,244 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// Value is a value of any type in this package that is represented
+// by a single Z3 value. This includes every Elem type, Struct, and
+// Pointer.
+type Value interface {
+	IsConcrete() bool
+	String() string
+
+	// symValue returns the value's symbolic value, creating it if
+	// necessary.
+	symValue(c *cache) z3.Value
+
+	// evalValue returns the value's concrete Go value in model m.
+	evalValue(m *z3.Model) reflect.Value
+}
+
+// scalarType describes how to represent values of a Go scalar kind.
+type scalarType struct {
+	zero     Value
+	sortOf   func(c *cache) z3.Sort
+	fromSym  func(v z3.Value) Value
+	any      func(ctx *z3.Context, name string) Value
+	anyArray func(ctx *z3.Context, name string, n int) interface{}
+}
+
+func scalarTypeOf[T Elem[T]](any func(ctx *z3.Context, name string) T) scalarType {
+	var zero T
+	return scalarType{
+		zero:    zero.zero(),
+		sortOf:  zero.sortOf,
+		fromSym: func(v z3.Value) Value { return zero.fromSym(v) },
+		any:     func(ctx *z3.Context, name string) Value { return any(ctx, name) },
+		anyArray: func(ctx *z3.Context, name string, n int) interface{} {
+			return AnyArray[T](ctx, name, n)
+		},
+	}
+}
+
+var scalarTypes = map[reflect.Kind]scalarType{
+	reflect.Bool:    scalarTypeOf(AnyBool),
+	reflect.Int:     scalarTypeOf(AnyInt),
+	reflect.Int8:    scalarTypeOf(AnyInt8),
+	reflect.Int16:   scalarTypeOf(AnyInt16),
+	reflect.Int32:   scalarTypeOf(AnyInt32),
+	reflect.Int64:   scalarTypeOf(AnyInt64),
+	reflect.Uint:    scalarTypeOf(AnyUint),
+	reflect.Uint8:   scalarTypeOf(AnyUint8),
+	reflect.Uint16:  scalarTypeOf(AnyUint16),
+	reflect.Uint32:  scalarTypeOf(AnyUint32),
+	reflect.Uint64:  scalarTypeOf(AnyUint64),
+	reflect.Uintptr: scalarTypeOf(AnyUintptr),
+	reflect.Float32: scalarTypeOf(AnyFloat32),
+	reflect.Float64: scalarTypeOf(AnyFloat64),
+	reflect.String:  scalarTypeOf(AnyString),
+}
+
+// stTypes maps the types in this package that implement Elem to how
+// to construct them.
+var stTypes = map[reflect.Type]scalarType{
+	reflect.TypeOf(Integer{}): scalarTypeOf(AnyInteger),
+	reflect.TypeOf(Real{}):    scalarTypeOf(AnyReal),
+}
+
+func init() {
+	for _, st := range scalarTypes {
+		stTypes[reflect.TypeOf(st.zero)] = st
+	}
+}
+
+// anyTypes maps the other non-generic types in this package that
+// AnyValue supports to how to construct them.
+var anyTypes = map[reflect.Type]func(ctx *z3.Context, name string) interface{}{
+	reflect.TypeOf(Complex64{}):  func(ctx *z3.Context, name string) interface{} { return AnyComplex64(ctx, name) },
+	reflect.TypeOf(Complex128{}): func(ctx *z3.Context, name string) interface{} { return AnyComplex128(ctx, name) },
+	reflect.TypeOf(Heap{}):       func(ctx *z3.Context, name string) interface{} { return AnyHeap(ctx, name) },
+}
+
+// anyGeneric is implemented by the generic types in this package so
+// that AnyValue can construct them without knowing their type
+// arguments.
+type anyGeneric interface {
+	anyValue(ctx *z3.Context, name string) interface{}
+}
+
+// anyLen is the maximum length of a Slice or Map constructed by
+// AnyValue. It is small so Eval can construct the Go value of any
+// model.
+const anyLen = 4
+
+// goTyped is implemented by the types in this package whose values
+// represent a Go type that is not determined by the value's type:
+// Struct, Pointer, and Array. AnyValue requires that Go type instead.
+type goTyped interface {
+	goTyped()
+}
+
+// AnyValue returns an unconstrained symbolic value of typ. typ may be
+// any type from this package other than Struct, Pointer, and Array,
+// in which case the result has type typ. For example,
+// AnyValue(ctx, name, reflect.TypeOf(Int32{})) is equivalent to
+// AnyInt32(ctx, name). A Slice constructed this way has a length and
+// capacity of at most 4, and a Map has at most 4 keys.
+//
+// Struct, Pointer, and Array values do not record the Go type they
+// represent, so for these typ must instead be that Go type. If typ is
+// a Go struct or pointer type, AnyValue is equivalent to Any. If typ
+// is a Go array type [n]E, where E is a boolean, integer,
+// floating-point, or string type, the result is an Array of length n
+// of the corresponding type from this package.
+func AnyValue(ctx *z3.Context, name string, typ reflect.Type) interface{} {
+	if st, ok := stTypes[typ]; ok {
+		return st.any(ctx, name)
+	}
+	if any, ok := anyTypes[typ]; ok {
+		return any(ctx, name)
+	}
+	switch v := reflect.Zero(typ).Interface().(type) {
+	case anyGeneric:
+		return v.anyValue(ctx, name)
+	case goTyped:
+		panic(fmt.Sprintf("%s requires the Go type it represents", typ))
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Ptr:
+		return Any(ctx, name, typ)
+	case reflect.Array:
+		if st, ok := scalarTypes[typ.Elem().Kind()]; ok {
+			return st.anyArray(ctx, name, typ.Len())
+		}
+	}
+	panic(fmt.Sprintf("unsupported type %s", typ))
+}
+
+// needsGoType returns whether AnyValue requires the Go type
+// represented by values of typ, rather than typ itself.
+func needsGoType(typ reflect.Type) bool {
+	return typ.Implements(reflect.TypeOf((*goTyped)(nil)).Elem())
+}
+
+// Any returns an unconstrained symbolic value of Go type typ.
+//
+// typ may be a boolean, integer, floating-point, or string type, in
+// which case the result has the corresponding type from this package;
+// a struct type whose fields are all supported types, in which case
+// the result is a Struct; or a pointer type, in which case the result
+// is a Pointer.
+func Any(ctx *z3.Context, name string, typ reflect.Type) Value {
+	switch typ.Kind() {
+	case reflect.Struct:
+		return AnyStruct(ctx, name, typ)
+	case reflect.Ptr:
+		return AnyPointer(ctx, name, typ)
+	}
+	if st, ok := scalarTypes[typ.Kind()]; ok {
+		return st.any(ctx, name)
+	}
+	panic(fmt.Sprintf("unsupported type %s", typ))
+}
+
+// ValueOf returns the st value representing Go value v. v must have
+// a type supported by Any. If v is a struct, the result is a
+// symbolic Struct whose fields are literals. If v is a pointer, it
+// must be nil.
+func ValueOf(ctx *z3.Context, v interface{}) Value {
+	return valueOf(getCache(ctx), reflect.ValueOf(v))
+}
+
+func valueOf(c *cache, v reflect.Value) Value {
+	typ := v.Type()
+	switch typ.Kind() {
+	case reflect.Struct:
+		// Copy v so its fields are addressable.
+		v2 := reflect.New(typ).Elem()
+		v2.Set(v)
+		fields := make([]z3.Value, typ.NumField())
+		for i := range fields {
+			fields[i] = valueOf(c, field(v2, i)).symValue(c)
+		}
+		return Struct{typ, c.z3.Tuple(c.sortOfType(typ), fields...)}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			panic("ValueOf of non-nil pointer")
+		}
+		return Pointer{typ: typ}
+	}
+	st, ok := scalarTypes[typ.Kind()]
+	if !ok {
+		panic(fmt.Sprintf("unsupported type %s", typ))
+	}
+	// Construct the st type's concrete value.
+	res := reflect.New(reflect.TypeOf(st.zero)).Elem()
+	cv := res.Field(0)
+	cv.Set(v.Convert(cv.Type()))
+	return res.Interface().(Value)
+}
+
+// sortOfType returns the Z3 sort representing Go type typ.
+func (c *cache) sortOfType(typ reflect.Type) z3.Sort {
+	switch typ.Kind() {
+	case reflect.Struct:
+		return c.structSort(typ)
+	case reflect.Ptr:
+		return c.sortInt
+	}
+	if st, ok := scalarTypes[typ.Kind()]; ok {
+		return st.sortOf(c)
+	}
+	panic(fmt.Sprintf("unsupported type %s", typ))
+}
+
+// fromSymType returns the st value of Go type typ whose symbolic
+// value is v.
+func fromSymType(typ reflect.Type, v z3.Value) Value {
+	switch typ.Kind() {
+	case reflect.Struct:
+		return Struct{typ, v.(z3.Tuple)}
+	case reflect.Ptr:
+		return Pointer{typ, Int{S: v.(z3.BV)}}
+	}
+	if st, ok := scalarTypes[typ.Kind()]; ok {
+		return st.fromSym(v)
+	}
+	panic(fmt.Sprintf("unsupported type %s", typ))
+}
+
+// Identical returns a Bool that is true if x and y are the same
+// value. Unlike Eq, this treats floating-point NaNs as identical and
+// distinguishes -0 from +0. x and y must have the same st type.
+func Identical(ctx *z3.Context, x, y Value) Bool {
+	c := getCache(ctx)
+	return Bool{S: c.z3.Distinct(x.symValue(c), y.symValue(c)).Not()}
+}
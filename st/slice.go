@@ -0,0 +1,302 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// Slice implements symbolic []T values.
+//
+// A Slice is a window onto a backing array, which is represented as
+// a Z3 array indexed by Int. The Slice's offset into the backing
+// array, its length, and its capacity may each be symbolic.
+//
+// Unlike Go slices, Slices are values: SetIndex and Append return a
+// new Slice and do not affect other Slices that share the same
+// backing array. Slices also do not distinguish nil slices from empty
+// slices.
+//
+// The zero Slice is not associated with a Context and must not be
+// used. Use NilSlice, MakeSlice, SliceOf, or AnySlice to construct a
+// Slice.
+type Slice[T Elem[T]] struct {
+	arr           z3.Array
+	off, len, cap Int
+}
+
+// zeroArray returns a backing array whose elements are all the zero
+// T.
+func zeroArray[T Elem[T]](c *cache) z3.Array {
+	var zero T
+	return c.z3.ConstArray(c.sortInt, zero.zero().symValue(c))
+}
+
+// NilSlice returns a Slice with length and capacity 0.
+func NilSlice[T Elem[T]](ctx *z3.Context) Slice[T] {
+	return Slice[T]{arr: zeroArray[T](getCache(ctx))}
+}
+
+// MakeSlice returns make([]T, len, cap). oob is true if make would
+// panic because len or cap is out of range, in which case the
+// returned Slice is unspecified. Like make, MakeSlice panics if len
+// and cap are concrete and out of range.
+func MakeSlice[T Elem[T]](ctx *z3.Context, len, cap Int) (s Slice[T], oob Bool) {
+	cache := getCache(ctx)
+	oob = inRange(Int{}, len, cap).Not()
+	cache.checkPanic(oob, "makeslice: len out of range")
+	return Slice[T]{arr: zeroArray[T](cache), len: len, cap: cap}, oob
+}
+
+// SliceOf returns a Slice with elements elems. Its length and
+// capacity are both len(elems).
+func SliceOf[T Elem[T]](ctx *z3.Context, elems ...T) Slice[T] {
+	return NilSlice[T](ctx).Append(elems...)
+}
+
+// AnySlice returns an unconstrained symbolic Slice whose length and
+// capacity are at most maxLen.
+func AnySlice[T Elem[T]](ctx *z3.Context, name string, maxLen int) Slice[T] {
+	cache := getCache(ctx)
+	var zero T
+	sort := cache.z3.ArraySort(cache.sortInt, zero.sortOf(cache))
+	arr := cache.z3.FreshConst(name, sort).(z3.Array)
+	// Map unconstrained lengths into range, rather than requiring
+	// the caller to assert constraints on them.
+	max := Int{C: maxLen}
+	len := AnyInt(ctx, name+".len")
+	len = Ite(inRange(Int{}, len, max), len, Int{})
+	cap := AnyInt(ctx, name+".cap")
+	cap = Ite(inRange(len, cap, max), cap, len)
+	return Slice[T]{arr: arr, len: len, cap: cap}
+}
+
+func (Slice[T]) anyValue(ctx *z3.Context, name string) interface{} {
+	return AnySlice[T](ctx, name, anyLen)
+}
+
+// cache returns the cache for x's Context.
+func (x Slice[T]) cache() *cache {
+	ctx := x.arr.Context()
+	if ctx == nil {
+		panic("use of zero Slice")
+	}
+	return getCache(ctx)
+}
+
+// ite returns cond ? x : y for symbolic cond.
+func (x Slice[T]) ite(cond Bool, y Slice[T]) Slice[T] {
+	return Slice[T]{
+		arr: cond.S.IfThenElse(x.arr, y.arr).(z3.Array),
+		off: Ite(cond, x.off, y.off),
+		len: Ite(cond, x.len, y.len),
+		cap: Ite(cond, x.cap, y.cap),
+	}
+}
+
+// String returns x as a string.
+func (x Slice[T]) String() string {
+	if !x.len.IsConcrete() {
+		return fmt.Sprintf("(slice %s %s %s %s)", x.arr, x.off, x.len, x.cap)
+	}
+	elems := make([]string, x.len.C)
+	for i := range elems {
+		elems[i] = x.index(Int{C: i}).String()
+	}
+	return "[" + strings.Join(elems, " ") + "]"
+}
+
+// Eval returns x's concrete value in model m as a Go slice whose
+// element type is T's concrete type. For example, Eval on a
+// Slice[Uint8] returns a []byte.
+//
+// Elements between the length and capacity of the result are always
+// zero.
+func (x Slice[T]) Eval(m *z3.Model) interface{} {
+	len, cap := x.len.Eval(m), x.cap.Eval(m)
+	res := reflect.MakeSlice(reflect.SliceOf(concreteType[T]()), len, cap)
+	for i := 0; i < len; i++ {
+		res.Index(i).Set(x.index(Int{C: i}).evalValue(m))
+	}
+	return res.Interface()
+}
+
+// Len returns len(x).
+func (x Slice[T]) Len() Int {
+	return x.len
+}
+
+// Cap returns cap(x).
+func (x Slice[T]) Cap() Int {
+	return x.cap
+}
+
+// Index returns x[i]. oob is true if i is out of bounds, in which
+// case the returned value is unspecified. Like x[i], Index panics if
+// i and x's length are concrete and i is out of bounds.
+func (x Slice[T]) Index(i Int) (v T, oob Bool) {
+	oob = inRange(Int{}, i, x.len.Sub(Int{C: 1})).Not()
+	x.cache().checkPanic(oob, "index out of range")
+	return x.index(i), oob
+}
+
+// index is like Index, but does not check bounds.
+func (x Slice[T]) index(i Int) T {
+	var v T
+	return v.fromSym(x.arr.Select(x.off.Add(i).sym(x.cache())))
+}
+
+// SetIndex returns a copy of x with x[i] = v. oob is true if i is out
+// of bounds, in which case the returned Slice is unspecified. Like
+// Index, SetIndex panics if i is concretely out of bounds.
+func (x Slice[T]) SetIndex(i Int, v T) (y Slice[T], oob Bool) {
+	cache := x.cache()
+	y = x
+	y.arr = x.arr.Store(x.off.Add(i).sym(cache), v.symValue(cache))
+	oob = inRange(Int{}, i, x.len.Sub(Int{C: 1})).Not()
+	cache.checkPanic(oob, "index out of range")
+	return y, oob
+}
+
+// Append returns append(x, vals...).
+//
+// If the result exceeds x's capacity, its capacity is the larger of
+// its length and twice x's capacity. This is similar to, but not
+// the same as, the Go runtime's growth strategy. Unlike in Go, the
+// elements between the length and capacity of a grown Slice are not
+// necessarily zero.
+func (x Slice[T]) Append(vals ...T) Slice[T] {
+	cache := x.cache()
+	y := x
+	for _, v := range vals {
+		y.arr = y.arr.Store(y.off.Add(y.len).sym(cache), v.symValue(cache))
+		y.len = y.len.Add(Int{C: 1})
+	}
+	double := x.cap.Add(x.cap)
+	grown := Ite(y.len.GT(double), y.len, double)
+	y.cap = Ite(y.len.GT(x.cap), grown, x.cap)
+	return y
+}
+
+// Slice returns x[i:j:k]. For the two-index form x[i:j], pass
+// x.Cap() for k. oob is true if the indexes are out of range, in
+// which case the returned Slice is unspecified. Like x[i:j:k], Slice
+// panics if the indexes are concretely out of range.
+func (x Slice[T]) Slice(i, j, k Int) (y Slice[T], oob Bool) {
+	oob = allOf(inRange(Int{}, i, j), inRange(j, k, x.cap)).Not()
+	x.cache().checkPanic(oob, "slice bounds out of range")
+	return Slice[T]{x.arr, x.off.Add(i), j.Sub(i), k.Sub(i)}, oob
+}
+
+// Array implements symbolic [n]T values, where n is concrete.
+//
+// Like Slice, an Array is represented as a Z3 array indexed by Int.
+// Only the elements in [0, n) are meaningful.
+//
+// The zero Array is not associated with a Context and must not be
+// used. Use ArrayOf or AnyArray to construct an Array.
+type Array[T Elem[T]] struct {
+	arr z3.Array
+	n   int
+}
+
+// ArrayOf returns an Array with elements elems.
+func ArrayOf[T Elem[T]](ctx *z3.Context, elems ...T) Array[T] {
+	cache := getCache(ctx)
+	arr := zeroArray[T](cache)
+	for i, v := range elems {
+		arr = arr.Store(Int{C: i}.sym(cache), v.symValue(cache))
+	}
+	return Array[T]{arr, len(elems)}
+}
+
+// AnyArray returns an unconstrained symbolic Array of length n.
+func AnyArray[T Elem[T]](ctx *z3.Context, name string, n int) Array[T] {
+	cache := getCache(ctx)
+	var zero T
+	sort := cache.z3.ArraySort(cache.sortInt, zero.sortOf(cache))
+	return Array[T]{cache.z3.FreshConst(name, sort).(z3.Array), n}
+}
+
+func (Array[T]) goTyped() {}
+
+// slice returns x[:].
+func (x Array[T]) slice() Slice[T] {
+	if x.arr.Context() == nil {
+		panic("use of zero Array")
+	}
+	return Slice[T]{arr: x.arr, len: Int{C: x.n}, cap: Int{C: x.n}}
+}
+
+// ite returns cond ? x : y for symbolic cond.
+func (x Array[T]) ite(cond Bool, y Array[T]) Array[T] {
+	if x.n != y.n {
+		panic("arrays have different lengths")
+	}
+	return Array[T]{cond.S.IfThenElse(x.arr, y.arr).(z3.Array), x.n}
+}
+
+// String returns x as a string.
+func (x Array[T]) String() string {
+	return x.slice().String()
+}
+
+// Eval returns x's concrete value in model m as a Go array whose
+// element type is T's concrete type. For example, Eval on an
+// Array[Uint8] of length 4 returns a [4]byte.
+func (x Array[T]) Eval(m *z3.Model) interface{} {
+	res := reflect.New(reflect.ArrayOf(x.n, concreteType[T]())).Elem()
+	reflect.Copy(res, reflect.ValueOf(x.slice().Eval(m)))
+	return res.Interface()
+}
+
+// Len returns len(x).
+func (x Array[T]) Len() Int {
+	return Int{C: x.n}
+}
+
+// Index returns x[i]. oob is true if i is out of bounds, in which
+// case the returned value is unspecified. Index panics if i is
+// concretely out of bounds.
+func (x Array[T]) Index(i Int) (v T, oob Bool) {
+	return x.slice().Index(i)
+}
+
+// SetIndex returns a copy of x with x[i] = v. oob is true if i is out
+// of bounds, in which case the returned Array is unspecified.
+// SetIndex panics if i is concretely out of bounds.
+func (x Array[T]) SetIndex(i Int, v T) (y Array[T], oob Bool) {
+	s, oob := x.slice().SetIndex(i, v)
+	return Array[T]{s.arr, x.n}, oob
+}
+
+// Slice returns x[i:j:k]. oob is true if the indexes are out of
+// range, in which case the returned Slice is unspecified. Slice
+// panics if the indexes are concretely out of range.
+func (x Array[T]) Slice(i, j, k Int) (y Slice[T], oob Bool) {
+	return x.slice().Slice(i, j, k)
+}
+
+// Eq returns x == y. x and y must have the same length.
+func (x Array[T]) Eq(y Array[T]) Bool {
+	if x.n != y.n {
+		panic("arrays have different lengths")
+	}
+	res := Bool{C: true}
+	for i := 0; i < x.n; i++ {
+		xi, yi := x.slice().index(Int{C: i}), y.slice().index(Int{C: i})
+		res = res.And(xi.Eq(yi))
+	}
+	return res
+}
+
+// NE returns x != y. x and y must have the same length.
+func (x Array[T]) NE(y Array[T]) Bool {
+	return x.Eq(y).Not()
+}
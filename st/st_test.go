@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aclements/go-z3/z3"
@@ -31,6 +33,103 @@ func TestEquivUint32(t *testing.T) {
 		uint32(0), uint32(1), uint32(31), uint32(32), uint32(1<<32-1))
 }
 
+func TestEquivFloat32(t *testing.T) {
+	testEquiv(t, reflect.TypeOf(Float32{}), Float32.sym,
+		float32(0), float32(math.Copysign(0, -1)), float32(1), float32(-1.5),
+		float32(0.1), float32(100), float32(math.MaxFloat32),
+		float32(math.SmallestNonzeroFloat32),
+		float32(math.Inf(1)), float32(math.NaN()))
+}
+
+func TestEquivFloat64(t *testing.T) {
+	testEquiv(t, reflect.TypeOf(Float64{}), Float64.sym,
+		0.0, math.Copysign(0, -1), 1.0, -1.5, 0.1, 100.0, 1e20,
+		math.MaxFloat64, math.SmallestNonzeroFloat64,
+		math.Inf(1), math.Inf(-1), math.NaN())
+}
+
+func TestEquivComplex64(t *testing.T) {
+	testEquiv(t, reflect.TypeOf(Complex64{}), Complex64.sym,
+		complex64(0), complex64(1), complex64(-1i), complex64(1.5+2i),
+		complex64(complex(float32(math.Inf(1)), 1)),
+		complex64(complex(1, float32(math.NaN()))))
+}
+
+func TestEquivComplex128(t *testing.T) {
+	testEquiv(t, reflect.TypeOf(Complex128{}), Complex128.sym,
+		complex128(0), complex128(1), -1i, 1.5+2i, 1e300+1e-300i,
+		complex(math.Inf(1), 1), complex(1, math.NaN()))
+}
+
+func TestEquivString(t *testing.T) {
+	testEquiv(t, reflect.TypeOf(String{}), String.sym,
+		"", "a", "b", "ab", "abc", "ba", "\xff")
+}
+
+func TestString(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	cache := getCache(ctx)
+	for _, str := range []string{"", "a", "hello", "\x00\xff"} {
+		c, s := String{C: str}, String{S: cache.fromString(str)}
+		check := func(name string, cres, sres interface{}) {
+			t.Helper()
+			crv, srv := reflect.ValueOf(cres), reflect.ValueOf(sres)
+			if !identical(ctx, crv, srv) {
+				t.Errorf("%s on %q = %v, want %v", name, str, ctx.Simplify(srv.FieldByName("S").Interface().(z3.Value), nil), crv.FieldByName("C").Interface())
+			}
+		}
+		check("Len", c.Len(), s.Len())
+		for i := 0; i <= len(str); i++ {
+			if i < len(str) {
+				check("Index", c.Index(Int{C: i}), s.Index(Int{C: i}))
+			}
+			for j := i; j <= len(str); j++ {
+				check("Slice", c.Slice(Int{C: i}, Int{C: j}), s.Slice(Int{C: i}, Int{C: j}))
+			}
+		}
+		for _, sub := range []string{"", "l", "lo", "x", "\xff"} {
+			sc, ss := String{C: sub}, String{S: cache.fromString(sub)}
+			check("HasPrefix", c.HasPrefix(sc), s.HasPrefix(ss))
+			check("HasSuffix", c.HasSuffix(sc), s.HasSuffix(ss))
+			check("Contains", c.Contains(sc), s.Contains(ss))
+			check("IndexOf", c.IndexOf(sc), s.IndexOf(ss))
+		}
+	}
+
+	for _, r := range []int32{0, 'a', 0x7f, 0x80, 'é', 0x7ff, 0x800, '世', 0xD800, 0xFFFF, 0x10000, 0x10FFFF, 0x110000, -1} {
+		c, s := Int32{C: r}, Int32{S: Int32{C: r}.sym(cache)}
+		check := reflect.ValueOf(c.ToString())
+		if sres := reflect.ValueOf(s.ToString()); !identical(ctx, check, sres) {
+			t.Errorf("ToString(%#x) = %v, want %q", r, ctx.Simplify(s.ToString().S, nil), c.ToString().C)
+		}
+	}
+
+	// Evaluate a symbolic string in a model.
+	x := AnyString(ctx, "x")
+	solver := z3.NewSolver(ctx)
+	solver.Assert(x.Len().Eq(Int{C: 3}).S)
+	solver.Assert(x.HasPrefix(String{C: "ab"}).S)
+	solver.Assert(x.Index(Int{C: 2}).Eq(Uint8{C: 'c'}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", solver, err)
+	}
+	if got := x.Eval(solver.Model()); got != "abc" {
+		t.Errorf("want x = %q, got %q", "abc", got)
+	}
+
+	// Indexes that are concretely out of range panic even if the
+	// string is symbolic.
+	for name, f := range map[string]func(){
+		"x[-1]":   func() { x.Index(Int{C: -1}) },
+		"x[-1:0]": func() { x.Slice(Int{C: -1}, Int{}) },
+		"x[2:1]":  func() { x.Slice(Int{C: 2}, Int{C: 1}) },
+	} {
+		if !panics(f) {
+			t.Errorf("%s did not panic", name)
+		}
+	}
+}
+
 func TestEquivInteger(t *testing.T) {
 	var huge big.Int
 	huge.SetString("123456789012345678901234567890", 10)
@@ -61,11 +160,21 @@ func testEquiv(t *testing.T, typ reflect.Type, symMethod interface{}, vals ...in
 		case "Lsh", "Rsh":
 			// TODO: Test these
 			continue
+		case "Index", "Slice":
+			// Takes Int indexes. See TestString.
+			continue
+		case "RotateLeft":
+			// Takes an Int rotation. See TestBits.
+			continue
+		}
+		if !homogeneous(m.Type, typ) {
+			t.Errorf("%s.%s has mixed argument types; add a dedicated test and exclude it above", typ.Name(), m.Name)
+			continue
 		}
 		t.Run(m.Name, func(t *testing.T) {
 			inputs := genArgs(rvals, m.Type.NumIn())
 			for _, input := range inputs {
-				if m.Name == "Quo" || m.Name == "Rem" {
+				if m.Name == "Quo" || m.Name == "Rem" || m.Name == "QuoChecked" {
 					s := fmt.Sprint(input[1].Interface())
 					if s == "0" || s == "0/1" {
 						// Avoid divide by zero
@@ -73,24 +182,43 @@ func testEquiv(t *testing.T, typ reflect.Type, symMethod interface{}, vals ...in
 					}
 				}
 
+				if strings.HasPrefix(m.Name, "To") && !convertible(input[0], m.Type.Out(0)) {
+					// Out-of-range float conversions
+					// are implementation-defined.
+					continue
+				}
+
 				c, s := wrap(ctx, typ, symMethod, input)
 
 				// Do the operation concretely.
-				cres := m.Func.Call(c)[0]
+				cress := m.Func.Call(c)
 
 				// Do the operation symbolically.
-				sres := m.Func.Call(s)[0]
+				sress := m.Func.Call(s)
 
 				// Check that they're equal.
-				eq := cres.MethodByName("Eq").Call([]reflect.Value{sres})[0]
-				if !toBool(ctx, eq.Interface().(Bool)) {
-					t.Errorf("%s(%v) = %v, want %v", m.Name, sliceInterface(c), ctx.Simplify(sres.FieldByName("S").Interface().(z3.Value), nil), cres.FieldByName("C").Interface())
+				for i, cres := range cress {
+					sres := sress[i]
+					if !identical(ctx, cres, sres) {
+						t.Errorf("%s(%v) result %d = %v, want %v", m.Name, sliceInterface(c), i, ctx.Simplify(sres.FieldByName("S").Interface().(z3.Value), nil), cres.FieldByName("C").Interface())
+					}
 				}
 			}
 		})
 	}
 }
 
+// homogeneous returns whether all arguments to method type mt are of
+// type typ.
+func homogeneous(mt reflect.Type, typ reflect.Type) bool {
+	for i := 0; i < mt.NumIn(); i++ {
+		if mt.In(i) != typ {
+			return false
+		}
+	}
+	return true
+}
+
 // genArgs returns the Cartesian product vals^n.
 func genArgs(vals []reflect.Value, n int) [][]reflect.Value {
 	if n == 0 {
@@ -130,7 +258,58 @@ func sliceInterface(rs []reflect.Value) []interface{} {
 	return out
 }
 
+// convertible returns whether val can be converted to the concrete
+// type of st type typ without going out of range.
+func convertible(val reflect.Value, typ reflect.Type) bool {
+	if val.Kind() != reflect.Float32 && val.Kind() != reflect.Float64 {
+		return true
+	}
+	ctyp := typ.Field(0).Type
+	switch ctyp.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	}
+	f := val.Float()
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return false
+	}
+	return val.Convert(ctyp).Convert(val.Type()).Float() == math.Trunc(f)
+}
+
+// identical returns whether concrete st value c is identical to
+// symbolic st value s. Unlike Eq, this treats floating-point NaNs as
+// identical and distinguishes -0 from +0.
+func identical(ctx *z3.Context, c, s reflect.Value) bool {
+	switch sv := s.FieldByName("S").Interface().(type) {
+	case z3.Float:
+		return identicalFloat(ctx, c.FieldByName("C").Interface(), sv)
+	case SymComplex:
+		switch cc := c.FieldByName("C").Interface().(type) {
+		case complex64:
+			return identicalFloat(ctx, real(cc), sv.Real) && identicalFloat(ctx, imag(cc), sv.Imag)
+		case complex128:
+			return identicalFloat(ctx, real(cc), sv.Real) && identicalFloat(ctx, imag(cc), sv.Imag)
+		}
+	}
+	eq := c.MethodByName("Eq").Call([]reflect.Value{s})[0]
+	return toBool(ctx, eq.Interface().(Bool))
+}
+
+func identicalFloat(ctx *z3.Context, c interface{}, s z3.Float) bool {
+	var cf z3.Float
+	switch c := c.(type) {
+	case float32:
+		cf = ctx.FromFloat32(c, s.Sort())
+	case float64:
+		cf = ctx.FromFloat64(c, s.Sort())
+	}
+	return toBool(ctx, Bool{S: cf.Eq(s)})
+}
+
 func toBool(ctx *z3.Context, b Bool) bool {
+	if b.IsConcrete() {
+		return b.C
+	}
 	// Since everything is literals, the simplifier should have no
 	// trouble getting the answer and is dramatically faster than
 	// the solver.
@@ -140,3 +319,739 @@ func toBool(ctx *z3.Context, b Bool) bool {
 	}
 	return val
 }
+
+func TestChecked(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	// fits returns whether x is in [lo, hi].
+	fits := func(x Int64, lo, hi int64) Bool {
+		return x.GE(Int64{C: lo}).And(x.LE(Int64{C: hi}))
+	}
+	type op struct {
+		name string
+		int  func(x, y Int16) (Int16, Bool)
+		uint func(x, y Uint16) (Uint16, Bool)
+		wide func(x, y Int64) Int64
+	}
+	for _, op := range []op{
+		{"Add", Int16.AddChecked, Uint16.AddChecked, Int64.Add},
+		{"Sub", Int16.SubChecked, Uint16.SubChecked, Int64.Sub},
+		{"Mul", Int16.MulChecked, Uint16.MulChecked, Int64.Mul},
+	} {
+		t.Run(op.name, func(t *testing.T) {
+			proveT(t, ctx, func(x, y Int16) Bool {
+				z, overflow := op.int(x, y)
+				wide := op.wide(x.ToInt64(), y.ToInt64())
+				ok := fits(wide, math.MinInt16, math.MaxInt16)
+				return overflow.NE(ok).And(z.ToInt64().Eq(wide).Or(overflow))
+			})
+			proveT(t, ctx, func(x, y Uint16) Bool {
+				z, overflow := op.uint(x, y)
+				wide := op.wide(x.ToInt64(), y.ToInt64())
+				ok := fits(wide, 0, math.MaxUint16)
+				return overflow.NE(ok).And(z.ToInt64().Eq(wide).Or(overflow))
+			})
+		})
+	}
+	proveT(t, ctx, func(x, y Int16) Bool {
+		_, overflow := x.QuoChecked(y)
+		return overflow.Eq(x.Eq(Int16{C: math.MinInt16}).And(y.Eq(Int16{C: -1})))
+	})
+	proveT(t, ctx, func(x Int16) Bool {
+		_, overflow := x.NegChecked()
+		return overflow.Eq(x.Eq(Int16{C: math.MinInt16}))
+	})
+	proveT(t, ctx, func(x Int16) Bool {
+		_, overflow := x.ToUint8Checked()
+		return overflow.NE(fits(x.ToInt64(), 0, math.MaxUint8))
+	})
+	proveT(t, ctx, func(x Uint16) Bool {
+		_, overflow := x.ToInt8Checked()
+		return overflow.NE(fits(x.ToInt64(), math.MinInt8, math.MaxInt8))
+	})
+	proveT(t, ctx, func(x Int64) Bool {
+		_, overflow := x.ToUint64Checked()
+		return overflow.Eq(x.LT(Int64{}))
+	})
+}
+
+func TestBits(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	cache := getCache(ctx)
+	check := func(name string, arg interface{}, got, want Value) {
+		t.Helper()
+		if !toBool(ctx, Identical(ctx, got, want)) {
+			t.Errorf("%s(%v) = %v, want %v", name, arg, ctx.Simplify(got.symValue(cache), nil), want)
+		}
+	}
+
+	for i := 0; i < 256; i++ {
+		x := Uint8{C: uint8(i)}
+		xs := Uint8{S: x.sym(cache)}
+		check("LeadingZeros", x, xs.LeadingZeros(), x.LeadingZeros())
+		check("TrailingZeros", x, xs.TrailingZeros(), x.TrailingZeros())
+		check("OnesCount", x, xs.OnesCount(), x.OnesCount())
+		check("Len", x, xs.Len(), x.Len())
+		check("Reverse", x, xs.Reverse(), x.Reverse())
+		for _, k := range []int{0, 1, 7, 8, 9, -1, -9} {
+			check("RotateLeft", []interface{}{x, k}, xs.RotateLeft(Int{C: k}), x.RotateLeft(Int{C: k}))
+		}
+	}
+
+	for _, v := range []uint32{0, 1, 0x80000000, 0xdeadbeef} {
+		x := Uint32{C: v}
+		xs := Uint32{S: x.sym(cache)}
+		for _, k := range []int{0, 1, 31, 32, 33, -1, -33} {
+			check("RotateLeft", []interface{}{x, k}, xs.RotateLeft(Int{C: k}), x.RotateLeft(Int{C: k}))
+		}
+	}
+
+	vals := []uint64{0, 1, 2, 0x80, 0xff00, 0x12345678, 1 << 63, 0xdeadbeefcafebabe, math.MaxUint64}
+	for _, v := range vals {
+		x := Uint64{C: v}
+		xs := Uint64{S: x.sym(cache)}
+		check("LeadingZeros", x, xs.LeadingZeros(), x.LeadingZeros())
+		check("TrailingZeros", x, xs.TrailingZeros(), x.TrailingZeros())
+		check("OnesCount", x, xs.OnesCount(), x.OnesCount())
+		check("ReverseBytes", x, xs.ReverseBytes(), x.ReverseBytes())
+		x32 := Uint32{C: uint32(v)}
+		xs32 := Uint32{S: x32.sym(cache)}
+		check("OnesCount32", x32, xs32.OnesCount(), x32.OnesCount())
+		check("ReverseBytes32", x32, xs32.ReverseBytes(), x32.ReverseBytes())
+		check("RotateLeft32", x32, xs32.RotateLeft(Int{C: -3}), x32.RotateLeft(Int{C: -3}))
+
+		for _, w := range vals {
+			y := Uint64{C: w}
+			ys := Uint64{S: y.sym(cache)}
+			for _, c := range []uint64{0, 1} {
+				carry := Uint64{C: c}
+				s1, c1 := Add64(xs, ys, carry)
+				s2, c2 := Add64(x, y, carry)
+				check("Add64.sum", []uint64{v, w, c}, s1, s2)
+				check("Add64.carry", []uint64{v, w, c}, c1, c2)
+				d1, b1 := Sub64(xs, ys, carry)
+				d2, b2 := Sub64(x, y, carry)
+				check("Sub64.diff", []uint64{v, w, c}, d1, d2)
+				check("Sub64.borrow", []uint64{v, w, c}, b1, b2)
+			}
+			h1, l1 := Mul64(xs, ys)
+			h2, l2 := Mul64(x, y)
+			check("Mul64.hi", []uint64{v, w}, h1, h2)
+			check("Mul64.lo", []uint64{v, w}, l1, l2)
+		}
+	}
+}
+
+func TestCarry(t *testing.T) {
+	// Every unsigned type has Add, Sub, and Mul carry functions.
+	ctx := z3.NewContext(nil)
+	cache := getCache(ctx)
+	check := func(name string, arg interface{}, got, want Value) {
+		t.Helper()
+		if !toBool(ctx, Identical(ctx, got, want)) {
+			t.Errorf("%s(%v) = %v, want %v", name, arg, ctx.Simplify(got.symValue(cache), nil), want)
+		}
+	}
+
+	vals8 := []uint8{0, 1, 2, 0x7f, 0x80, 0xfe, 0xff}
+	for _, v := range vals8 {
+		for _, w := range vals8 {
+			x, y := Uint8{C: v}, Uint8{C: w}
+			xs, ys := Uint8{S: x.sym(cache)}, Uint8{S: y.sym(cache)}
+			args := []uint8{v, w}
+			for _, c := range []uint8{0, 1} {
+				carry := Uint8{C: c}
+				sum, carryOut := Add8(x, y, carry)
+				wide := uint(v) + uint(w) + uint(c)
+				check("Add8.sum", args, sum, Uint8{C: uint8(wide)})
+				check("Add8.carry", args, carryOut, Uint8{C: uint8(wide >> 8)})
+				s1, c1 := Add8(xs, ys, carry)
+				check("Add8.sum", args, s1, sum)
+				check("Add8.carry", args, c1, carryOut)
+
+				diff, borrowOut := Sub8(x, y, carry)
+				check("Sub8.diff", args, diff, Uint8{C: v - w - c})
+				b := uint8(0)
+				if uint(v) < uint(w)+uint(c) {
+					b = 1
+				}
+				check("Sub8.borrow", args, borrowOut, Uint8{C: b})
+				d1, b1 := Sub8(xs, ys, carry)
+				check("Sub8.diff", args, d1, diff)
+				check("Sub8.borrow", args, b1, borrowOut)
+			}
+			hi, lo := Mul8(x, y)
+			p := uint16(v) * uint16(w)
+			check("Mul8.hi", args, hi, Uint8{C: uint8(p >> 8)})
+			check("Mul8.lo", args, lo, Uint8{C: uint8(p)})
+			h1, l1 := Mul8(xs, ys)
+			check("Mul8.hi", args, h1, hi)
+			check("Mul8.lo", args, l1, lo)
+		}
+	}
+
+	vals := []uint{0, 1, 0x80, math.MaxUint >> 1, math.MaxUint}
+	for _, v := range vals {
+		for _, w := range vals {
+			args := []uint{v, w}
+			s, c := bits.Add(v, w, 1)
+			sum, carryOut := Add(Uint{S: Uint{C: v}.sym(cache)}, Uint{C: w}, Uint{C: 1})
+			check("Add.sum", args, sum, Uint{C: s})
+			check("Add.carry", args, carryOut, Uint{C: c})
+			psum, pcarry := AddUintptr(Uintptr{C: uintptr(v)}, Uintptr{S: Uintptr{C: uintptr(w)}.sym(cache)}, Uintptr{C: 1})
+			check("AddUintptr.sum", args, psum, Uintptr{C: uintptr(s)})
+			check("AddUintptr.carry", args, pcarry, Uintptr{C: uintptr(c)})
+
+			d, b := bits.Sub(v, w, 1)
+			diff, borrowOut := SubUintptr(Uintptr{C: uintptr(v)}, Uintptr{C: uintptr(w)}, Uintptr{C: 1})
+			check("SubUintptr.diff", args, diff, Uintptr{C: uintptr(d)})
+			check("SubUintptr.borrow", args, borrowOut, Uintptr{C: uintptr(b)})
+
+			h, l := bits.Mul(v, w)
+			hi, lo := MulUintptr(Uintptr{S: Uintptr{C: uintptr(v)}.sym(cache)}, Uintptr{C: uintptr(w)})
+			check("MulUintptr.hi", args, hi, Uintptr{C: uintptr(h)})
+			check("MulUintptr.lo", args, lo, Uintptr{C: uintptr(l)})
+			hi2, lo2 := Mul(Uint{C: v}, Uint{C: w})
+			check("Mul.hi", args, hi2, Uint{C: h})
+			check("Mul.lo", args, lo2, Uint{C: l})
+		}
+	}
+}
+
+// panics returns whether f panics.
+func panics(f func()) (panicked bool) {
+	defer func() {
+		if recover() != nil {
+			panicked = true
+		}
+	}()
+	f()
+	return false
+}
+
+func TestSlice(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	check := func(name string, b Bool, want bool) {
+		t.Helper()
+		if got := toBool(ctx, b); got != want {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+
+	s := SliceOf(ctx, Int{C: 1}, Int{C: 2}, Int{C: 3})
+	check("len", s.Len().Eq(Int{C: 3}), true)
+	for i := 0; i < 3; i++ {
+		v, oob := s.Index(Int{C: i})
+		check(fmt.Sprintf("s[%d] oob", i), oob, false)
+		check(fmt.Sprintf("s[%d]", i), v.Eq(Int{C: i + 1}), true)
+	}
+
+	// Slicing shifts indexes and respects capacity.
+	s2, oob := s.Slice(Int{C: 1}, Int{C: 2}, s.Cap())
+	check("s[1:2] oob", oob, false)
+	check("len(s[1:2])", s2.Len().Eq(Int{C: 1}), true)
+	check("cap(s[1:2])", s2.Cap().Eq(Int{C: 2}), true)
+	v, _ := s2.Index(Int{C: 0})
+	check("s[1:2][0]", v.Eq(Int{C: 2}), true)
+
+	// SetIndex and Append return new slices.
+	s3, _ := s.SetIndex(Int{C: 0}, Int{C: 10})
+	s3 = s3.Append(Int{C: 4})
+	check("len(s3)", s3.Len().Eq(Int{C: 4}), true)
+	check("cap(s3)", s3.Cap().Eq(Int{C: 6}), true)
+	v, _ = s.Index(Int{C: 0})
+	check("s[0]", v.Eq(Int{C: 1}), true)
+
+	// Concretely out-of-bounds operations panic like Go.
+	for name, f := range map[string]func(){
+		"s[-1]":          func() { s.Index(Int{C: -1}) },
+		"s[3]":           func() { s.Index(Int{C: 3}) },
+		"s[3] = 0":       func() { s.SetIndex(Int{C: 3}, Int{}) },
+		"s[2:1]":         func() { s.Slice(Int{C: 2}, Int{C: 1}, s.Cap()) },
+		"s[0:1:4]":       func() { s.Slice(Int{C: 0}, Int{C: 1}, Int{C: 4}) },
+		"a[2]":           func() { ArrayOf(ctx, Int{}, Int{}).Index(Int{C: 2}) },
+		"make len > cap": func() { MakeSlice[Int](ctx, Int{C: 2}, Int{C: 1}) },
+	} {
+		if !panics(f) {
+			t.Errorf("%s did not panic", name)
+		}
+	}
+
+	// A symbolic index may be out of bounds.
+	i := AnyInt(ctx, "i")
+	_, oob = s.Index(i)
+	solver := z3.NewSolver(ctx)
+	solver.Assert(oob.S)
+	solver.Assert(i.Eq(Int{C: 3}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Errorf("s[i] with i = 3 not out of bounds: %v", err)
+	}
+
+	// Solve for a symbolic slice.
+	x := AnySlice[Uint8](ctx, "x", 4)
+	x0, oob := x.Index(Int{C: 0})
+	x1, _ := x.Index(Int{C: 1})
+	solver = z3.NewSolver(ctx)
+	solver.Assert(oob.Not().S)
+	solver.Assert(x.Len().Eq(Int{C: 2}).S)
+	solver.Assert(x0.Eq(Uint8{C: 'h'}).S)
+	solver.Assert(x1.Eq(Uint8{C: 'i'}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", solver, err)
+	}
+	m := solver.Model()
+	if got := x.Eval(m).([]byte); string(got) != "hi" || cap(got) > 4 {
+		t.Errorf("want x = %q with cap <= 4, got %q with cap %d", "hi", got, cap(got))
+	}
+
+	// So do concretely out-of-range indexes into a symbolic slice.
+	if !panics(func() { x.Slice(Int{C: 2}, Int{C: 1}, x.Cap()) }) {
+		t.Errorf("x[2:1] did not panic")
+	}
+
+	// Unsatisfiable beyond maxLen.
+	solver = z3.NewSolver(ctx)
+	solver.Assert(x.Len().GT(Int{C: 4}).S)
+	if sat, _ := solver.Check(); sat {
+		t.Errorf("len(x) > 4 is satisfiable")
+	}
+}
+
+func TestArray(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	a := ArrayOf(ctx, Float64{C: 1}, Float64{C: 2})
+	b, oob := a.SetIndex(Int{C: 1}, Float64{C: math.NaN()})
+	if toBool(ctx, oob) {
+		t.Errorf("b[1] out of bounds")
+	}
+	if !toBool(ctx, a.Eq(a)) || toBool(ctx, b.Eq(b)) {
+		t.Errorf("array equality does not follow element equality")
+	}
+	if !panics(func() { a.Index(Int{C: 2}) }) {
+		t.Errorf("a[2] did not panic")
+	}
+
+	x := AnyArray[Int32](ctx, "x", 3)
+	solver := z3.NewSolver(ctx)
+	for i := 0; i < 3; i++ {
+		v, _ := x.Index(Int{C: i})
+		solver.Assert(v.Eq(Int32{C: int32(i * i)}).S)
+	}
+	if sat, err := solver.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", solver, err)
+	}
+	if got, want := x.Eval(solver.Model()), [3]int32{0, 1, 4}; got != want {
+		t.Errorf("want x = %v, got %v", want, got)
+	}
+}
+
+func TestMap(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	check := func(name string, b Bool, want bool) {
+		t.Helper()
+		// The simplifier can't always decide array equality,
+		// so check that b != want is unsatisfiable.
+		solver := z3.NewSolver(ctx)
+		solver.Assert(b.NE(Bool{C: want}).sym(getCache(ctx)))
+		if sat, err := solver.Check(); sat || err != nil {
+			t.Errorf("%s = %v, want %v", name, !want, want)
+		}
+	}
+
+	x := MakeMap[String, Int](ctx)
+	x = x.SetIndex(String{C: "a"}, Int{C: 1})
+	x = x.SetIndex(String{C: "b"}, Int{C: 2})
+	x = x.SetIndex(String{C: "a"}, Int{C: 3})
+	check("len", x.Len().Eq(Int{C: 2}), true)
+	v, ok := x.Index(String{C: "a"})
+	check("x[a] ok", ok, true)
+	check("x[a]", v.Eq(Int{C: 3}), true)
+	v, ok = x.Index(String{C: "c"})
+	check("x[c] ok", ok, false)
+	check("x[c]", v.Eq(Int{C: 0}), true)
+
+	y := x.Delete(String{C: "a"}).Delete(String{C: "c"})
+	check("len after delete", y.Len().Eq(Int{C: 1}), true)
+	_, ok = y.Index(String{C: "a"})
+	check("deleted ok", ok, false)
+
+	// Equality ignores history.
+	z := MakeMap[String, Int](ctx).SetIndex(String{C: "b"}, Int{C: 2})
+	check("y == z", y.Eq(z), true)
+	check("x == z", x.Eq(z), false)
+
+	// Solve for a symbolic map.
+	a := AnyMap[Int, Bool](ctx, "a", 3)
+	av, aok := a.Index(Int{C: 7})
+	solver := z3.NewSolver(ctx)
+	solver.Assert(a.Len().Eq(Int{C: 2}).S)
+	solver.Assert(aok.S)
+	solver.Assert(av.S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", solver, err)
+	}
+	got := a.Eval(solver.Model()).(map[int]bool)
+	if len(got) != 2 || !got[7] {
+		t.Errorf("want len(a) = 2 and a[7] = true, got %v", got)
+	}
+
+	solver = z3.NewSolver(ctx)
+	solver.Assert(a.Len().GT(Int{C: 3}).S)
+	if sat, _ := solver.Check(); sat {
+		t.Errorf("len(a) > 3 is satisfiable")
+	}
+}
+
+type testNode struct {
+	Val   int32
+	Name  string
+	ok    bool
+	Next  *testNode
+	Inner struct{ X, Y float64 }
+}
+
+func TestStructUnexported(t *testing.T) {
+	// Unexported fields are deliberately included in the symbolic
+	// representation and round trip through ValueOf and Eval.
+	ctx := z3.NewContext(nil)
+	type pair struct {
+		a int32
+		b string
+	}
+	for _, want := range []pair{{}, {1, "x"}, {-5, "hello"}} {
+		x := ValueOf(ctx, want).(Struct)
+		if got := x.FieldByName("a").(Int32); !toBool(ctx, got.Eq(Int32{C: want.a})) {
+			t.Errorf("ValueOf(%+v).a = %v, want %d", want, got, want.a)
+		}
+		solver := z3.NewSolver(ctx)
+		if sat, err := solver.Check(); !sat {
+			t.Fatalf("not satisfiable: %v", err)
+		}
+		if got := x.Eval(solver.Model()).(pair); got != want {
+			t.Errorf("Eval(ValueOf(%+v)) = %+v", want, got)
+		}
+	}
+	x, y := ValueOf(ctx, pair{1, "x"}).(Struct), ValueOf(ctx, pair{1, "y"}).(Struct)
+	if toBool(ctx, x.Eq(y)) {
+		t.Errorf("structs differing only in unexported fields compare equal")
+	}
+}
+
+func TestStruct(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	typ := reflect.TypeOf(testNode{})
+
+	// Concrete round trip.
+	x := ValueOf(ctx, testNode{Val: 1, Name: "a", ok: true}).(Struct)
+	if got := x.FieldByName("Val").(Int32); !toBool(ctx, got.Eq(Int32{C: 1})) {
+		t.Errorf("x.Val = %v, want 1", got)
+	}
+	if !toBool(ctx, x.FieldByName("Next").(Pointer).IsNil()) {
+		t.Errorf("x.Next != nil")
+	}
+	y := x.SetField(0, Int32{C: 2})
+	if toBool(ctx, x.Eq(y)) || !toBool(ctx, x.Eq(x)) {
+		t.Errorf("struct equality is wrong")
+	}
+
+	// Solve for a linked list of two nodes.
+	n := Any(ctx, "n", typ).(Struct)
+	heap := AnyHeap(ctx, "heap")
+	next := n.FieldByName("Next").(Pointer)
+	n2, nilDeref := heap.Load(next)
+	solver := z3.NewSolver(ctx)
+	solver.Assert(nilDeref.Not().S)
+	solver.Assert(n.FieldByName("Val").(Int32).Eq(Int32{C: 1}).S)
+	solver.Assert(n.FieldByName("ok").(Bool).S)
+	solver.Assert(n2.(Struct).FieldByName("Val").(Int32).Eq(Int32{C: 2}).S)
+	solver.Assert(n2.(Struct).FieldByName("Next").(Pointer).IsNil().S)
+	inner := n.FieldByName("Inner").(Struct)
+	solver.Assert(inner.FieldByName("X").(Float64).Eq(Float64{C: 0.5}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", solver, err)
+	}
+	m := solver.Model()
+	got := heap.Eval(m, n).(testNode)
+	if got.Val != 1 || !got.ok || got.Inner.X != 0.5 || got.Next == nil || got.Next.Val != 2 || got.Next.Next != nil {
+		t.Errorf("unexpected model %+v", got)
+	}
+	if got := n.Eval(m).(testNode); got.Next == nil || got.Next.Val != 0 {
+		t.Errorf("Struct.Eval should not follow pointers, got %+v", got)
+	}
+
+	// New allocations don't alias inputs.
+	heap2, p := heap.New(typ)
+	heap2, _ = heap2.Store(p, y)
+	v, _ := heap2.Load(p)
+	if !toBool(ctx, v.(Struct).Eq(y)) {
+		t.Errorf("*p = %v, want %v", v, y)
+	}
+	solver = z3.NewSolver(ctx)
+	solver.Assert(p.Eq(next).S)
+	if sat, _ := solver.Check(); sat {
+		t.Errorf("new pointer may alias input")
+	}
+
+	// Dereferencing a concrete nil pointer panics.
+	nilp := NilPointer(reflect.PtrTo(typ))
+	if !panics(func() { heap.Load(nilp) }) {
+		t.Errorf("*nil did not panic")
+	}
+	if !panics(func() { heap.Store(nilp, y) }) {
+		t.Errorf("*nil = y did not panic")
+	}
+}
+
+func TestIte(t *testing.T) {
+	ctx := z3.NewContext(nil)
+
+	// Concrete conditions return the operand itself.
+	x, y := AnyInt32(ctx, "x"), AnyInt32(ctx, "y")
+	if got := Ite(Bool{C: true}, x, y); got.String() != x.String() {
+		t.Errorf("Ite(true, x, y) = %v, want x", got)
+	}
+
+	a, b := String{C: "a"}, AnyString(ctx, "b")
+	ca, cb := Complex128{C: 1i}, AnyComplex128(ctx, "c")
+	sa, sb := SliceOf(ctx, Int{C: 1}, Int{C: 2}), SliceOf(ctx, Int{C: 3})
+	typ := reflect.TypeOf(testNode{})
+	na, nb := AnyStruct(ctx, "na", typ), AnyStruct(ctx, "nb", typ)
+	for _, c := range []bool{true, false} {
+		cond := Bool{S: ctx.FromBool(c)}
+		check := func(name string, got, x, y Value) {
+			t.Helper()
+			want := y
+			if c {
+				want = x
+			}
+			solver := z3.NewSolver(ctx)
+			solver.Assert(Identical(ctx, got, want).Not().S)
+			if sat, _ := solver.Check(); sat {
+				t.Errorf("Ite(%v, ...) on %s is wrong", c, name)
+			}
+		}
+
+		check("Int32", Ite(cond, x, y), x, y)
+		check("String", Ite(cond, a, b), a, b)
+		cc := Ite(cond, ca, cb)
+		check("Complex128.Real", cc.Real(), ca.Real(), cb.Real())
+		check("Complex128.Imag", cc.Imag(), ca.Imag(), cb.Imag())
+		s := Ite(cond, sa, sb)
+		check("Slice.Len", s.Len(), sa.Len(), sb.Len())
+		check("Slice.Index", s.index(Int{C: 0}), sa.index(Int{C: 0}), sb.index(Int{C: 0}))
+		check("Struct", Ite(cond, na, nb), na, nb)
+	}
+}
+
+func TestExplorer(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x, y := AnyInt(ctx, "x"), AnyInt(ctx, "y")
+	classify := func(p *Path) {
+		if p.If(x.LT(Int{C: 0})) {
+			return
+		}
+		if p.If(x.GT(Int{C: 10})) {
+			// Infeasible when x > 10.
+			p.If(x.LT(Int{C: 5}))
+			return
+		}
+		if p.If(x.Eq(y)) {
+			p.Assume(y.Eq(Int{C: 20}))
+		}
+	}
+
+	for _, strategy := range []Strategy{DFS, BFS, Random} {
+		e := NewExplorer(ctx)
+		e.Strategy = strategy
+		paths := e.Explore(classify)
+		if len(paths) != 3 {
+			t.Errorf("strategy %d: want 3 paths, got %d", strategy, len(paths))
+		}
+		for _, p := range paths {
+			m := p.Model()
+			if m == nil {
+				t.Fatalf("strategy %d: path %v has no model", strategy, p.Cond())
+			}
+			if ok, _ := m.Eval(p.Cond().S, true).(z3.Bool).AsBool(); !ok {
+				t.Errorf("strategy %d: model does not satisfy %v", strategy, p.Cond())
+			}
+			// Replay the path concretely.
+			xv, yv := x.Eval(m), y.Eval(m)
+			if xv < 0 != p.decisions[0] {
+				t.Errorf("strategy %d: model x=%d does not follow path %v", strategy, xv, p.decisions)
+			}
+			if len(p.decisions) == 3 && (xv == yv) != p.decisions[2] {
+				t.Errorf("strategy %d: model x=%d, y=%d does not follow path %v", strategy, xv, yv, p.decisions)
+			}
+		}
+	}
+
+	e := NewExplorer(ctx)
+	e.MaxDepth = 1
+	paths := e.Explore(classify)
+	if len(paths) != 2 || paths[0].Truncated == paths[1].Truncated {
+		t.Errorf("want one complete and one truncated path, got %d paths", len(paths))
+	}
+	e = NewExplorer(ctx)
+	e.MaxPaths = 1
+	if paths := e.Explore(classify); len(paths) != 1 {
+		t.Errorf("want 1 path, got %d", len(paths))
+	}
+}
+
+func TestPanicLog(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	mayPanic := func(l *PanicLog) bool {
+		t.Helper()
+		solver := z3.NewSolver(ctx)
+		solver.Assert(l.MayPanic().sym(getCache(ctx)))
+		sat, err := solver.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sat
+	}
+
+	x, y := AnyInt32(ctx, "x"), AnyInt32(ctx, "y")
+	l := RecordPanics(ctx)
+	x.Quo(Int32{C: 2})
+	if len(l.Obligations()) != 0 {
+		t.Errorf("division by non-zero constant recorded obligations %v", l.Obligations())
+	}
+	x.Rem(y)
+	if !mayPanic(l) {
+		t.Errorf("x %% y cannot panic")
+	}
+	if msg := l.Obligations()[0].Msg; msg != "integer divide by zero" {
+		t.Errorf("want divide by zero obligation, got %q", msg)
+	}
+	l.Stop()
+
+	// Dividing by a concrete zero panics, even if the dividend is
+	// symbolic.
+	if !panics(func() { x.Quo(Int32{}) }) {
+		t.Errorf("x / 0 did not panic")
+	}
+	if !panics(func() { AnyInteger(ctx, "z").Rem(Integer{C: new(big.Int)}) }) {
+		t.Errorf("z %% 0 did not panic")
+	}
+
+	// Guarded operations can't panic.
+	l = RecordPanics(ctx)
+	s := SliceOf(ctx, Uint8{C: 1}, Uint8{C: 2})
+	NewExplorer(ctx).Explore(func(p *Path) {
+		if p.If(y.NE(Int32{})) {
+			x.Quo(y)
+		}
+		if i := x.ToInt(); p.If(i.GE(Int{}).And(i.LT(s.Len()))) {
+			s.Index(i)
+		}
+	})
+	// Each operation is executed on two paths.
+	if len(l.Obligations()) != 4 {
+		t.Errorf("want 4 obligations, got %v", l.Obligations())
+	}
+	if mayPanic(l) {
+		t.Errorf("guarded operations may panic")
+	}
+
+	// Unguarded indexing can.
+	s.Index(x.ToInt())
+	if !mayPanic(l) {
+		t.Errorf("s[x] cannot panic")
+	}
+	l.Stop()
+	y.Quo(x)
+	if len(l.Obligations()) != 5 {
+		t.Errorf("stopped log recorded obligation")
+	}
+}
+
+// proveT reports a failure in t if prop does not hold. It is like
+// sttest.Prove, which this package's tests cannot import.
+func proveT(t *testing.T, ctx *z3.Context, prop interface{}, goTypes ...reflect.Type) {
+	t.Helper()
+	if err := Prove(ctx, prop, goTypes...); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProve(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	proveT(t, ctx, func(x, y Int32) Bool {
+		return x.Add(y).Eq(y.Add(x))
+	})
+
+	cex, err := FindCounterexample(ctx, func(x Int32, s String) Bool {
+		return x.Add(x).GE(x).Or(s.HasPrefix(String{C: "a"}))
+	})
+	if err != nil || cex == nil {
+		t.Fatalf("want counterexample, got %v, %v", cex, err)
+	}
+	x, s := cex.Args[0].(int32), cex.Args[1].(string)
+	if x+x >= x || strings.HasPrefix(s, "a") {
+		t.Errorf("%s is not a counterexample", cex)
+	}
+
+	if err := Prove(ctx, func(x Int32) Bool { return x.NE(Int32{C: 5}) }); err == nil || err.Error() != "property does not hold for arg0 = 5" {
+		t.Errorf("want counterexample arg0 = 5, got %v", err)
+	}
+
+	// A nonlinear property Z3 can't decide quickly.
+	ctx = z3.NewContext(z3.NewContextConfig().SetUint("timeout", 50))
+	err = Prove(ctx, func(x, y, z Integer) Bool {
+		zero := Integer{C: big.NewInt(0)}
+		cube := func(x Integer) Integer { return x.Mul(x).Mul(x) }
+		pos := x.GT(zero).And(y.GT(zero)).And(z.GT(zero))
+		return pos.Not().Or(cube(x).Add(cube(y)).NE(cube(z)))
+	})
+	if err, ok := err.(*UnknownError); !ok || !err.Timeout() {
+		t.Errorf("want timeout, got %v", err)
+	}
+}
+
+func TestProveTypes(t *testing.T) {
+	// Prove supports every type in this package.
+	ctx := z3.NewContext(nil)
+	proveT(t, ctx, func(x Complex128) Bool {
+		return x.Neg().Neg().Eq(x).Or(x.Eq(x).Not())
+	})
+	proveT(t, ctx, func(s Slice[Uint8], m Map[String, Int]) Bool {
+		return s.Len().LE(s.Cap()).And(s.Cap().LE(Int{C: 4})).And(m.Len().LE(Int{C: 4}))
+	})
+	// Slice lengths are bounded so counterexamples can be evaluated.
+	proveT(t, ctx, func(s Slice[Uint8]) Bool {
+		return s.Len().LT(Int{C: 1 << 40})
+	})
+	type pair struct {
+		A int32
+		B string
+	}
+	proveT(t, ctx, func(x Struct, h Heap, p Pointer, a Array[Int32]) Bool {
+		v, _ := a.Index(Int{C: 2})
+		h2, nilDeref := h.Store(p, x)
+		y, _ := h2.Load(p)
+		return x.Eq(x).And(v.Eq(v)).And(nilDeref.Or(y.(Struct).Eq(x)))
+	}, reflect.TypeOf(pair{}), reflect.TypeOf(&pair{}), reflect.TypeOf([3]int32{}))
+
+	cex, err := FindCounterexample(ctx, func(x Complex64, s Slice[Int8], m Map[Int8, Bool]) Bool {
+		return x.Eq(x).And(s.Len().LT(Int{C: 3})).And(m.Len().LT(Int{C: 2}))
+	})
+	if err != nil || cex == nil {
+		t.Fatalf("want counterexample, got %v, %v", cex, err)
+	}
+	x, s, m := cex.Args[0].(complex64), cex.Args[1].([]int8), cex.Args[2].(map[int8]bool)
+	if x == x && len(s) < 3 && len(m) < 2 {
+		t.Errorf("%s is not a counterexample", cex)
+	}
+
+	cex, err = FindCounterexample(ctx, func(h Heap, x Struct, a Array[Uint8]) Bool {
+		v, _ := a.Index(Int{C: 1})
+		return x.FieldByName("A").(Int32).NE(Int32{C: 1}).Or(v.NE(Uint8{C: 7}))
+	}, reflect.TypeOf(pair{}), reflect.TypeOf([2]uint8{}))
+	if err != nil || cex == nil {
+		t.Fatalf("want counterexample, got %v, %v", cex, err)
+	}
+	if cex.Args[0] != nil || cex.Args[1].(pair).A != 1 || cex.Args[2].([2]uint8)[1] != 7 {
+		t.Errorf("%s is not a counterexample", cex)
+	}
+}
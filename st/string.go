@@ -0,0 +1,264 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// Strings are represented symbolically as sequences of 8-bit
+// bit-vectors, so indexing and slicing have Go's byte semantics.
+
+// fromString returns the symbolic sequence for s.
+func (c *cache) fromString(s string) z3.Seq {
+	res := c.z3.SeqEmpty(c.sortString)
+	if len(s) == 0 {
+		return res
+	}
+	units := make([]z3.Seq, len(s))
+	for i := 0; i < len(s); i++ {
+		units[i] = c.z3.SeqUnit(Uint8{C: s[i]}.sym(c))
+	}
+	return res.Concat(units...)
+}
+
+// evalString returns the concrete value of sequence x in model m. x
+// must already be a model value.
+func evalString(m *z3.Model, x z3.Seq) (string, bool) {
+	ctx := x.Context()
+	n, ok, _ := m.Eval(x.Length(), true).(z3.Int).AsInt64()
+	if !ok {
+		return "", false
+	}
+	buf := make([]byte, n)
+	for i := range buf {
+		elem := m.Eval(x.Nth(ctx.FromInt(int64(i), ctx.IntSort()).(z3.Int)), true).(z3.BV)
+		b, ok, _ := elem.AsUint64()
+		if !ok {
+			return "", false
+		}
+		buf[i] = byte(b)
+	}
+	return string(buf), true
+}
+
+// stringLTFunc returns the recursive function that implements x < y for
+// strings.
+func (c *cache) stringLTFunc() z3.FuncDecl {
+	if c.stringLTDecl.Context() != nil {
+		return c.stringLTDecl
+	}
+	ints := c.z3.IntSort()
+	f := c.z3.RecFuncDecl("st.stringLT", []z3.Sort{c.sortString, c.sortString}, c.z3.BoolSort())
+	x := c.z3.FreshConst("x", c.sortString).(z3.Seq)
+	y := c.z3.FreshConst("y", c.sortString).(z3.Seq)
+	zero := c.z3.FromInt(0, ints).(z3.Int)
+	one := c.z3.FromInt(1, ints).(z3.Int)
+	xlen, ylen := x.Length(), y.Length()
+	x0, y0 := x.Nth(zero).(z3.BV), y.Nth(zero).(z3.BV)
+	rest := f.Apply(x.Extract(one, xlen.Sub(one)), y.Extract(one, ylen.Sub(one))).(z3.Bool)
+	// x < y if y is non-empty and either x is empty, the first
+	// byte of x is less than the first byte of y, or the first
+	// bytes are equal and the rest of x < the rest of y.
+	body := ylen.Eq(zero).IfThenElse(c.z3.FromBool(false),
+		xlen.Eq(zero).IfThenElse(c.z3.FromBool(true),
+			x0.Eq(y0).IfThenElse(rest, x0.ULT(y0))))
+	f.DefineRec([]z3.Value{x, y}, body)
+	c.stringLTDecl = f
+	return f
+}
+
+func (c *cache) stringLT(x, y z3.Seq) z3.Bool {
+	return c.stringLTFunc().Apply(x, y).(z3.Bool)
+}
+
+func (c *cache) stringLE(x, y z3.Seq) z3.Bool {
+	return c.stringLT(y, x).Not()
+}
+
+func (c *cache) stringGT(x, y z3.Seq) z3.Bool {
+	return c.stringLT(y, x)
+}
+
+func (c *cache) stringGE(x, y z3.Seq) z3.Bool {
+	return c.stringLT(x, y).Not()
+}
+
+// Len returns len(x).
+func (x String) Len() Int {
+	if x.IsConcrete() {
+		return Int{C: len(x.C)}
+	}
+	return Int{S: x.S.Length().ToBV(getCache(x.S.Context()).sortInt.BVSize())}
+}
+
+// Index returns the byte x[i].
+//
+// Like x[i], Index panics if i is concretely out of bounds.
+// Otherwise, if i may be out of bounds, the result is unconstrained
+// and this records a panic obligation.
+func (x String) Index(i Int) Uint8 {
+	if x.IsConcrete() && i.IsConcrete() {
+		return Uint8{C: x.C[i.C]}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = i.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(inRange(Int{}, i, x.Len().Sub(Int{C: 1})).Not(), "index out of range")
+	return Uint8{S: x.sym(cache).Nth(i.sym(cache).SToInt()).(z3.BV)}
+}
+
+// Slice returns x[i:j].
+//
+// Like x[i:j], Slice panics if i and j are concretely not in the
+// range 0 <= i <= j <= len(x). Otherwise, if they may not be, the
+// result is unspecified and this records a panic obligation.
+func (x String) Slice(i, j Int) String {
+	if x.IsConcrete() && i.IsConcrete() && j.IsConcrete() {
+		return String{C: x.C[i.C:j.C]}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = i.S.Context()
+	}
+	if ctx == nil {
+		ctx = j.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(allOf(inRange(Int{}, i, j), j.LE(x.Len())).Not(), "slice bounds out of range")
+	is, js := i.sym(cache).SToInt(), j.sym(cache).SToInt()
+	return String{S: x.sym(cache).Extract(is, js.Sub(is))}
+}
+
+// HasPrefix returns strings.HasPrefix(x, prefix).
+func (x String) HasPrefix(prefix String) Bool {
+	if x.IsConcrete() && prefix.IsConcrete() {
+		return Bool{C: strings.HasPrefix(x.C, prefix.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = prefix.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).HasPrefix(prefix.sym(cache))}
+}
+
+// HasSuffix returns strings.HasSuffix(x, suffix).
+func (x String) HasSuffix(suffix String) Bool {
+	if x.IsConcrete() && suffix.IsConcrete() {
+		return Bool{C: strings.HasSuffix(x.C, suffix.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = suffix.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).HasSuffix(suffix.sym(cache))}
+}
+
+// Contains returns strings.Contains(x, substr).
+func (x String) Contains(substr String) Bool {
+	if x.IsConcrete() && substr.IsConcrete() {
+		return Bool{C: strings.Contains(x.C, substr.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = substr.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).Contains(substr.sym(cache))}
+}
+
+// IndexOf returns strings.Index(x, substr).
+func (x String) IndexOf(substr String) Int {
+	if x.IsConcrete() && substr.IsConcrete() {
+		return Int{C: strings.Index(x.C, substr.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = substr.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, subs := x.sym(cache), substr.sym(cache)
+	zero := cache.z3.FromInt(0, cache.z3.IntSort()).(z3.Int)
+	idx := xs.IndexOf(subs, zero).ToBV(cache.sortInt.BVSize())
+	// This is redundant, but Z3's simplifier can't figure out
+	// on its own when the index of a literal is -1.
+	notFound := Int{C: -1}.sym(cache)
+	return Int{S: xs.Contains(subs).IfThenElse(idx, notFound).(z3.BV)}
+}
+
+// BytesToString returns string(b).
+func BytesToString(b []Uint8) String {
+	var ctx *z3.Context
+	buf := make([]byte, len(b))
+	for i, x := range b {
+		if !x.IsConcrete() {
+			ctx = x.S.Context()
+			break
+		}
+		buf[i] = x.C
+	}
+	if ctx == nil {
+		return String{C: string(buf)}
+	}
+	cache := getCache(ctx)
+	res := cache.z3.SeqEmpty(cache.sortString)
+	units := make([]z3.Seq, len(b))
+	for i, x := range b {
+		units[i] = cache.z3.SeqUnit(x.sym(cache))
+	}
+	return String{S: res.Concat(units...)}
+}
+
+// ToString returns string(x), the UTF-8 encoding of rune x.
+func (x Int32) ToString() String {
+	if x.IsConcrete() {
+		return String{C: string(x.C)}
+	}
+	return String{S: getCache(x.S.Context()).encodeRune(x.S.SignExtend(32))}
+}
+
+// ToString returns string(x), the UTF-8 encoding of x as a rune.
+func (x Uint8) ToString() String {
+	if x.IsConcrete() {
+		return String{C: string(x.C)}
+	}
+	return String{S: getCache(x.S.Context()).encodeRune(x.S.ZeroExtend(56))}
+}
+
+// encodeRune returns the UTF-8 encoding of rune r, which must be a
+// 64-bit bit-vector. Like Go, if r is not a valid Unicode code point,
+// this returns the encoding of utf8.RuneError.
+func (c *cache) encodeRune(r z3.BV) z3.Seq {
+	lit := func(v int64) z3.BV {
+		return c.z3.FromInt(v, r.Sort()).(z3.BV)
+	}
+	valid := r.ULT(lit(0xD800)).Or(r.UGE(lit(0xE000)).And(r.ULE(lit(utf8.MaxRune))))
+	r = valid.IfThenElse(r, lit(utf8.RuneError)).(z3.BV)
+
+	// bits returns bits [hi, lo] of r, or'd with prefix as a
+	// single byte.
+	bits := func(prefix int64, hi, lo int) z3.Seq {
+		b := r.Extract(hi, lo)
+		if n := 8 - (hi - lo + 1); n > 0 {
+			b = b.ZeroExtend(n)
+		}
+		b = b.Or(c.z3.FromInt(prefix, c.sortUint8).(z3.BV))
+		return c.z3.SeqUnit(b)
+	}
+	enc1 := bits(0, 6, 0)
+	enc2 := bits(0xC0, 10, 6).Concat(bits(0x80, 5, 0))
+	enc3 := bits(0xE0, 15, 12).Concat(bits(0x80, 11, 6), bits(0x80, 5, 0))
+	enc4 := bits(0xF0, 20, 18).Concat(bits(0x80, 17, 12), bits(0x80, 11, 6), bits(0x80, 5, 0))
+	return r.ULT(lit(0x80)).IfThenElse(enc1,
+		r.ULT(lit(0x800)).IfThenElse(enc2,
+			r.ULT(lit(0x10000)).IfThenElse(enc3, enc4))).(z3.Seq)
+}
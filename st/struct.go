@@ -0,0 +1,173 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// Struct implements symbolic values of a Go struct type.
+//
+// A Struct is represented as a Z3 tuple with one field for each field
+// of the Go struct type. Each field may have any type supported by
+// Any, including nested structs and pointers.
+//
+// Unlike the scalar types in this package, Structs are always
+// symbolic, though their fields may be literals. Use ValueOf to
+// construct a Struct from a concrete Go struct.
+//
+// Unexported fields are represented like exported fields, so that Eq
+// agrees with Go's == operator. To do this, ValueOf and Eval read and
+// write unexported fields of the Go value using package unsafe.
+type Struct struct {
+	typ reflect.Type
+	S   z3.Tuple
+}
+
+// AnyStruct returns a Struct of Go struct type typ in which each
+// field is an unconstrained symbolic value. Field f of the result is
+// named name.f.
+func AnyStruct(ctx *z3.Context, name string, typ reflect.Type) Struct {
+	cache := getCache(ctx)
+	fields := make([]z3.Value, typ.NumField())
+	for i := range fields {
+		f := typ.Field(i)
+		fields[i] = Any(ctx, name+"."+f.Name, f.Type).symValue(cache)
+	}
+	return Struct{typ, ctx.Tuple(cache.structSort(typ), fields...)}
+}
+
+// structSort returns the tuple sort representing Go struct type typ.
+func (c *cache) structSort(typ reflect.Type) z3.Sort {
+	if sort, ok := c.structSorts[typ]; ok {
+		return sort
+	}
+	if c.structSorts == nil {
+		c.structSorts = make(map[reflect.Type]z3.Sort)
+	}
+	// Distinct types may have the same string, so make the tuple
+	// name unique.
+	name := fmt.Sprintf("%s#%d", typ, len(c.structSorts))
+	names := make([]string, typ.NumField())
+	sorts := make([]z3.Sort, typ.NumField())
+	for i := range names {
+		f := typ.Field(i)
+		names[i] = name + "." + f.Name
+		sorts[i] = c.sortOfType(f.Type)
+	}
+	sort := c.z3.TupleSort(name, names, sorts)
+	c.structSorts[typ] = sort
+	return sort
+}
+
+// field returns field i of addressable struct v. Unlike v.Field(i),
+// the result can be read and set even if the field is unexported.
+// This bypasses reflect's export checks, so it must only be used to
+// copy values between Go structs and their symbolic representation.
+func field(v reflect.Value, i int) reflect.Value {
+	f := v.Field(i)
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+func (Struct) goTyped() {}
+
+// Type returns the Go struct type of x.
+func (x Struct) Type() reflect.Type {
+	return x.typ
+}
+
+// String returns x as a string.
+func (x Struct) String() string {
+	return x.S.String()
+}
+
+// IsConcrete returns false.
+func (x Struct) IsConcrete() bool {
+	return false
+}
+
+// Eval returns x's concrete value in model m as a Go value of type
+// x.Type(). Pointer fields are evaluated as by Pointer.Eval. Use
+// Heap.Eval to also evaluate the values they point to.
+func (x Struct) Eval(m *z3.Model) interface{} {
+	return x.evalValue(m).Interface()
+}
+
+func (x Struct) symValue(c *cache) z3.Value {
+	return x.S
+}
+
+func (x Struct) evalValue(m *z3.Model) reflect.Value {
+	res := reflect.New(x.typ).Elem()
+	for i := 0; i < x.typ.NumField(); i++ {
+		f := field(res, i)
+		f.Set(x.Field(i).evalValue(m).Convert(f.Type()))
+	}
+	return res
+}
+
+// NumField returns the number of fields in x.
+func (x Struct) NumField() int {
+	return x.typ.NumField()
+}
+
+// Field returns field i of x. The result has the st type
+// corresponding to the field's Go type, as described by Any.
+func (x Struct) Field(i int) Value {
+	return fromSymType(x.typ.Field(i).Type, x.S.Field(i))
+}
+
+// FieldByName returns the field of x named name. It panics if there
+// is no such field.
+func (x Struct) FieldByName(name string) Value {
+	f, ok := x.typ.FieldByName(name)
+	if !ok || len(f.Index) != 1 {
+		panic(fmt.Sprintf("%s has no field %s", x.typ, name))
+	}
+	return x.Field(f.Index[0])
+}
+
+// SetField returns a copy of x with field i set to v. v must have the
+// st type corresponding to the field's Go type.
+func (x Struct) SetField(i int, v Value) Struct {
+	cache := getCache(x.S.Context())
+	return Struct{x.typ, x.S.SetField(i, v.symValue(cache))}
+}
+
+// ite returns cond ? x : y for symbolic cond.
+func (x Struct) ite(cond Bool, y Struct) Struct {
+	if x.typ != y.typ {
+		panic(fmt.Sprintf("mismatched types %s and %s", x.typ, y.typ))
+	}
+	return Struct{x.typ, cond.S.IfThenElse(x.S, y.S).(z3.Tuple)}
+}
+
+// Eq returns x == y. Like Go's == operator, this compares each field
+// using == for that field's type.
+func (x Struct) Eq(y Struct) Bool {
+	if x.typ != y.typ {
+		panic(fmt.Sprintf("cannot compare %s and %s", x.typ, y.typ))
+	}
+	res := Bool{C: true}
+	for i := 0; i < x.NumField(); i++ {
+		res = res.And(eqValue(x.Field(i), y.Field(i)))
+	}
+	return res
+}
+
+// NE returns x != y.
+func (x Struct) NE(y Struct) Bool {
+	return x.Eq(y).Not()
+}
+
+// eqValue returns x == y, where x and y must be the same st type.
+func eqValue(x, y Value) Bool {
+	eq := reflect.ValueOf(x).MethodByName("Eq")
+	return eq.Call([]reflect.Value{reflect.ValueOf(y)})[0].Interface().(Bool)
+}
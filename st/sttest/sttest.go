@@ -0,0 +1,25 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sttest provides helpers for testing properties written
+// against the symbolic types in package st.
+package sttest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aclements/go-z3/st"
+	"github.com/aclements/go-z3/z3"
+)
+
+// Prove is like st.Prove, but reports a failure in t if prop does not
+// hold or cannot be proved. A failure report includes the
+// counterexample's arguments.
+func Prove(t testing.TB, ctx *z3.Context, prop interface{}, goTypes ...reflect.Type) {
+	t.Helper()
+	if err := st.Prove(ctx, prop, goTypes...); err != nil {
+		t.Error(err)
+	}
+}
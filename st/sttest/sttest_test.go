@@ -0,0 +1,40 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sttest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aclements/go-z3/st"
+	"github.com/aclements/go-z3/z3"
+)
+
+// recorder is a testing.TB that records errors.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func TestProve(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	Prove(t, ctx, func(x st.Uint8) st.Bool {
+		return x.Xor(x).Eq(st.Uint8{})
+	})
+
+	r := &recorder{TB: t}
+	Prove(r, ctx, func(x st.Uint8) st.Bool {
+		return x.LT(st.Uint8{C: 255})
+	})
+	if want := "property does not hold for arg0 = 0xff"; len(r.errors) != 1 || r.errors[0] != want {
+		t.Errorf("got errors %q, want %q", r.errors, want)
+	}
+}
@@ -5,7 +5,10 @@ package st
 import (
 	"fmt"
 	"github.com/aclements/go-z3/z3"
+	"math"
 	"math/big"
+	"math/bits"
+	"reflect"
 )
 
 type sorts struct {
@@ -21,6 +24,9 @@ type sorts struct {
 	sortUint32  z3.Sort
 	sortUint64  z3.Sort
 	sortUintptr z3.Sort
+	sortFloat32 z3.Sort
+	sortFloat64 z3.Sort
+	sortString  z3.Sort
 	sortInteger z3.Sort
 	sortReal    z3.Sort
 }
@@ -38,6 +44,9 @@ func initSorts(s *sorts, ctx *z3.Context) {
 	s.sortUint32 = ctx.BVSort(32)
 	s.sortUint64 = ctx.BVSort(64)
 	s.sortUintptr = ctx.BVSort(64)
+	s.sortFloat32 = ctx.Float32Sort()
+	s.sortFloat64 = ctx.Float64Sort()
+	s.sortString = ctx.SeqSort(ctx.BVSort(8))
 	s.sortInteger = ctx.IntSort()
 	s.sortReal = ctx.RealSort()
 }
@@ -90,6 +99,23 @@ func (x Bool) sym(c *cache) z3.Bool {
 	return c.z3.FromBool(x.C)
 }
 
+func (Bool) zero() Bool {
+	return Bool{}
+}
+
+func (Bool) sortOf(c *cache) z3.Sort { return c.sortBool }
+
+func (x Bool) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Bool) fromSym(v z3.Value) Bool { return Bool{S: v.(z3.Bool)} }
+
+func (x Bool) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Bool) ite(cond Bool, y Bool) Bool {
+	cache := getCache(cond.S.Context())
+	return Bool{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.Bool)}
+}
+
 func (x Bool) And(y Bool) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C && y.C}
@@ -193,6 +219,40 @@ func (x Int) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt).(z3.BV)
 }
 
+func (Int) zero() Int {
+	return Int{}
+}
+
+func (Int) sortOf(c *cache) z3.Sort { return c.sortInt }
+
+func (x Int) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int) fromSym(v z3.Value) Int { return Int{S: v.(z3.BV)} }
+
+func (x Int) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Int) ite(cond Bool, y Int) Int {
+	cache := getCache(cond.S.Context())
+	return Int{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Int) Min(y Int) Int {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Int) Max(y Int) Int {
+	return Ite(x.GE(y), x, y)
+}
+
+// Abs returns the absolute value of x.
+// As in Go, the negation of the most negative int overflows, so
+// its absolute value is itself.
+func (x Int) Abs() Int {
+	return Ite(x.LT(x.zero()), x.Neg(), x)
+}
+
 func (x Int) Add(y Int) Int {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int{C: x.C + y.C}
@@ -238,6 +298,7 @@ func (x Int) Quo(y Int) Int {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
@@ -250,6 +311,7 @@ func (x Int) Rem(y Int) Int {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
@@ -502,6 +564,198 @@ func (x Int) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(0)}
 }
 
+func (x Int) ToFloat32() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: float32(x.C)}
+	}
+	return Float32{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat32)}
+}
+
+func (x Int) ToFloat64() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: float64(x.C)}
+	}
+	return Float64{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat64)}
+}
+
+// AddChecked returns x + y and whether the result overflowed
+// int. If it did, z is the wrapped result that Add returns.
+func (x Int) AddChecked(y Int) (z Int, overflow Bool) {
+	z = x.Add(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) == (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SAddNoOverflow(ys).And(xs.SAddNoUnderflow(ys)).Not()}
+}
+
+// SubChecked returns x - y and whether the result overflowed
+// int. If it did, z is the wrapped result that Sub returns.
+func (x Int) SubChecked(y Int) (z Int, overflow Bool) {
+	z = x.Sub(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) != (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SSubNoOverflow(ys).And(xs.SSubNoUnderflow(ys)).Not()}
+}
+
+// MulChecked returns x * y and whether the result overflowed
+// int. If it did, z is the wrapped result that Mul returns.
+func (x Int) MulChecked(y Int) (z Int, overflow Bool) {
+	z = x.Mul(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: x.C != 0 && (z.C/x.C != y.C || x.C == -1 && y.C != 0 && y.C == -y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SignExtend(64).Mul(ys.SignExtend(64)).Eq(xs.Mul(ys).SignExtend(64)).Not()}
+}
+
+// QuoChecked returns x / y and whether the result overflowed
+// int. If it did, z is the wrapped result that Quo returns.
+func (x Int) QuoChecked(y Int) (z Int, overflow Bool) {
+	z = x.Quo(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: y.C == -1 && x.C != 0 && x.C == -x.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SDivNoOverflow(ys).Not()}
+}
+
+// NegChecked returns -x and whether the result overflowed int.
+func (x Int) NegChecked() (z Int, overflow Bool) {
+	z = x.Neg()
+	if x.IsConcrete() {
+		return z, Bool{C: x.C != 0 && x.C == -x.C}
+	}
+	return z, Bool{S: x.S.SNegNoOverflow().Not()}
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Int) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToInt().NE(x)
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Int) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToInt().NE(x)
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Int) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToInt().NE(x)
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Int) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToInt().NE(x)
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Int) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToInt().NE(x)
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Int) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToInt().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Int) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToInt().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Int) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToInt().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Int) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToInt().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Int) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToInt().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Int) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToInt().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
 // Int8 implements symbolic int8 values.
 type Int8 struct {
 	C int8
@@ -550,6 +804,40 @@ func (x Int8) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt8).(z3.BV)
 }
 
+func (Int8) zero() Int8 {
+	return Int8{}
+}
+
+func (Int8) sortOf(c *cache) z3.Sort { return c.sortInt8 }
+
+func (x Int8) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int8) fromSym(v z3.Value) Int8 { return Int8{S: v.(z3.BV)} }
+
+func (x Int8) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Int8) ite(cond Bool, y Int8) Int8 {
+	cache := getCache(cond.S.Context())
+	return Int8{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Int8) Min(y Int8) Int8 {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Int8) Max(y Int8) Int8 {
+	return Ite(x.GE(y), x, y)
+}
+
+// Abs returns the absolute value of x.
+// As in Go, the negation of the most negative int8 overflows, so
+// its absolute value is itself.
+func (x Int8) Abs() Int8 {
+	return Ite(x.LT(x.zero()), x.Neg(), x)
+}
+
 func (x Int8) Add(y Int8) Int8 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int8{C: x.C + y.C}
@@ -595,6 +883,7 @@ func (x Int8) Quo(y Int8) Int8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int8{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
@@ -607,6 +896,7 @@ func (x Int8) Rem(y Int8) Int8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int8{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
@@ -865,6 +1155,198 @@ func (x Int8) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(56)}
 }
 
+func (x Int8) ToFloat32() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: float32(x.C)}
+	}
+	return Float32{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat32)}
+}
+
+func (x Int8) ToFloat64() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: float64(x.C)}
+	}
+	return Float64{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat64)}
+}
+
+// AddChecked returns x + y and whether the result overflowed
+// int8. If it did, z is the wrapped result that Add returns.
+func (x Int8) AddChecked(y Int8) (z Int8, overflow Bool) {
+	z = x.Add(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) == (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SAddNoOverflow(ys).And(xs.SAddNoUnderflow(ys)).Not()}
+}
+
+// SubChecked returns x - y and whether the result overflowed
+// int8. If it did, z is the wrapped result that Sub returns.
+func (x Int8) SubChecked(y Int8) (z Int8, overflow Bool) {
+	z = x.Sub(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) != (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SSubNoOverflow(ys).And(xs.SSubNoUnderflow(ys)).Not()}
+}
+
+// MulChecked returns x * y and whether the result overflowed
+// int8. If it did, z is the wrapped result that Mul returns.
+func (x Int8) MulChecked(y Int8) (z Int8, overflow Bool) {
+	z = x.Mul(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: x.C != 0 && (z.C/x.C != y.C || x.C == -1 && y.C != 0 && y.C == -y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SignExtend(8).Mul(ys.SignExtend(8)).Eq(xs.Mul(ys).SignExtend(8)).Not()}
+}
+
+// QuoChecked returns x / y and whether the result overflowed
+// int8. If it did, z is the wrapped result that Quo returns.
+func (x Int8) QuoChecked(y Int8) (z Int8, overflow Bool) {
+	z = x.Quo(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: y.C == -1 && x.C != 0 && x.C == -x.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SDivNoOverflow(ys).Not()}
+}
+
+// NegChecked returns -x and whether the result overflowed int8.
+func (x Int8) NegChecked() (z Int8, overflow Bool) {
+	z = x.Neg()
+	if x.IsConcrete() {
+		return z, Bool{C: x.C != 0 && x.C == -x.C}
+	}
+	return z, Bool{S: x.S.SNegNoOverflow().Not()}
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Int8) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToInt8().NE(x)
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Int8) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToInt8().NE(x)
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Int8) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToInt8().NE(x)
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Int8) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToInt8().NE(x)
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Int8) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToInt8().NE(x)
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Int8) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToInt8().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Int8) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToInt8().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Int8) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToInt8().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Int8) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToInt8().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Int8) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToInt8().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Int8) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToInt8().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
 // Int16 implements symbolic int16 values.
 type Int16 struct {
 	C int16
@@ -913,6 +1395,40 @@ func (x Int16) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt16).(z3.BV)
 }
 
+func (Int16) zero() Int16 {
+	return Int16{}
+}
+
+func (Int16) sortOf(c *cache) z3.Sort { return c.sortInt16 }
+
+func (x Int16) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int16) fromSym(v z3.Value) Int16 { return Int16{S: v.(z3.BV)} }
+
+func (x Int16) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Int16) ite(cond Bool, y Int16) Int16 {
+	cache := getCache(cond.S.Context())
+	return Int16{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Int16) Min(y Int16) Int16 {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Int16) Max(y Int16) Int16 {
+	return Ite(x.GE(y), x, y)
+}
+
+// Abs returns the absolute value of x.
+// As in Go, the negation of the most negative int16 overflows, so
+// its absolute value is itself.
+func (x Int16) Abs() Int16 {
+	return Ite(x.LT(x.zero()), x.Neg(), x)
+}
+
 func (x Int16) Add(y Int16) Int16 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int16{C: x.C + y.C}
@@ -958,6 +1474,7 @@ func (x Int16) Quo(y Int16) Int16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int16{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
@@ -970,6 +1487,7 @@ func (x Int16) Rem(y Int16) Int16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int16{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
@@ -1228,6 +1746,198 @@ func (x Int16) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(48)}
 }
 
+func (x Int16) ToFloat32() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: float32(x.C)}
+	}
+	return Float32{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat32)}
+}
+
+func (x Int16) ToFloat64() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: float64(x.C)}
+	}
+	return Float64{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat64)}
+}
+
+// AddChecked returns x + y and whether the result overflowed
+// int16. If it did, z is the wrapped result that Add returns.
+func (x Int16) AddChecked(y Int16) (z Int16, overflow Bool) {
+	z = x.Add(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) == (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SAddNoOverflow(ys).And(xs.SAddNoUnderflow(ys)).Not()}
+}
+
+// SubChecked returns x - y and whether the result overflowed
+// int16. If it did, z is the wrapped result that Sub returns.
+func (x Int16) SubChecked(y Int16) (z Int16, overflow Bool) {
+	z = x.Sub(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) != (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SSubNoOverflow(ys).And(xs.SSubNoUnderflow(ys)).Not()}
+}
+
+// MulChecked returns x * y and whether the result overflowed
+// int16. If it did, z is the wrapped result that Mul returns.
+func (x Int16) MulChecked(y Int16) (z Int16, overflow Bool) {
+	z = x.Mul(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: x.C != 0 && (z.C/x.C != y.C || x.C == -1 && y.C != 0 && y.C == -y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SignExtend(16).Mul(ys.SignExtend(16)).Eq(xs.Mul(ys).SignExtend(16)).Not()}
+}
+
+// QuoChecked returns x / y and whether the result overflowed
+// int16. If it did, z is the wrapped result that Quo returns.
+func (x Int16) QuoChecked(y Int16) (z Int16, overflow Bool) {
+	z = x.Quo(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: y.C == -1 && x.C != 0 && x.C == -x.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SDivNoOverflow(ys).Not()}
+}
+
+// NegChecked returns -x and whether the result overflowed int16.
+func (x Int16) NegChecked() (z Int16, overflow Bool) {
+	z = x.Neg()
+	if x.IsConcrete() {
+		return z, Bool{C: x.C != 0 && x.C == -x.C}
+	}
+	return z, Bool{S: x.S.SNegNoOverflow().Not()}
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Int16) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToInt16().NE(x)
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Int16) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToInt16().NE(x)
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Int16) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToInt16().NE(x)
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Int16) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToInt16().NE(x)
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Int16) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToInt16().NE(x)
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Int16) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToInt16().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Int16) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToInt16().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Int16) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToInt16().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Int16) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToInt16().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Int16) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToInt16().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Int16) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToInt16().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
 // Int32 implements symbolic int32 values.
 type Int32 struct {
 	C int32
@@ -1276,6 +1986,40 @@ func (x Int32) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt32).(z3.BV)
 }
 
+func (Int32) zero() Int32 {
+	return Int32{}
+}
+
+func (Int32) sortOf(c *cache) z3.Sort { return c.sortInt32 }
+
+func (x Int32) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int32) fromSym(v z3.Value) Int32 { return Int32{S: v.(z3.BV)} }
+
+func (x Int32) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Int32) ite(cond Bool, y Int32) Int32 {
+	cache := getCache(cond.S.Context())
+	return Int32{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Int32) Min(y Int32) Int32 {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Int32) Max(y Int32) Int32 {
+	return Ite(x.GE(y), x, y)
+}
+
+// Abs returns the absolute value of x.
+// As in Go, the negation of the most negative int32 overflows, so
+// its absolute value is itself.
+func (x Int32) Abs() Int32 {
+	return Ite(x.LT(x.zero()), x.Neg(), x)
+}
+
 func (x Int32) Add(y Int32) Int32 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int32{C: x.C + y.C}
@@ -1321,6 +2065,7 @@ func (x Int32) Quo(y Int32) Int32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int32{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
@@ -1333,6 +2078,7 @@ func (x Int32) Rem(y Int32) Int32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int32{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
@@ -1591,67 +2337,293 @@ func (x Int32) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(32)}
 }
 
-// Int64 implements symbolic int64 values.
-type Int64 struct {
-	C int64
-	S z3.BV
-}
-
-// AnyInt64 returns an unconstrained symbolic Int64.
-func AnyInt64(ctx *z3.Context, name string) Int64 {
-	cache := getCache(ctx)
-	sym := cache.z3.FreshConst(name, cache.sortInt64).(z3.BV)
-	return Int64{S: sym}
-}
-
-// String returns x as a string.
-func (x Int64) String() string {
+func (x Int32) ToFloat32() Float32 {
 	if x.IsConcrete() {
-		return fmt.Sprint(x.C)
+		return Float32{C: float32(x.C)}
 	}
-	return x.S.String()
-}
-
-// IsConcrete returns true if x is concrete.
-func (x Int64) IsConcrete() bool {
-	return x.S.Context() == nil
+	return Float32{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat32)}
 }
 
-// Eval returns x's concrete value in model m.
-// This also evaluates x with model completion.
-func (x Int64) Eval(m *z3.Model) int64 {
+func (x Int32) ToFloat64() Float64 {
 	if x.IsConcrete() {
-		return x.C
-	}
-	c := m.Eval(x.S, true).(z3.BV)
-	val, ok, _ := c.AsInt64()
-	if !ok {
-		panic("model evaluation produced non-concrete value " + c.String())
-	}
-	return (int64)(val)
-}
-
-// sym returns x's symbolic value, creating it if necessary.
-func (x Int64) sym(c *cache) z3.BV {
-	if !x.IsConcrete() {
-		return x.S
+		return Float64{C: float64(x.C)}
 	}
-	return c.z3.FromInt(int64(x.C), c.sortInt64).(z3.BV)
+	return Float64{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat64)}
 }
 
-func (x Int64) Add(y Int64) Int64 {
+// AddChecked returns x + y and whether the result overflowed
+// int32. If it did, z is the wrapped result that Add returns.
+func (x Int32) AddChecked(y Int32) (z Int32, overflow Bool) {
+	z = x.Add(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Int64{C: x.C + y.C}
+		return z, Bool{C: (x.C >= 0) == (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Int64{S: x.sym(cache).Add(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SAddNoOverflow(ys).And(xs.SAddNoUnderflow(ys)).Not()}
 }
 
-func (x Int64) Sub(y Int64) Int64 {
+// SubChecked returns x - y and whether the result overflowed
+// int32. If it did, z is the wrapped result that Sub returns.
+func (x Int32) SubChecked(y Int32) (z Int32, overflow Bool) {
+	z = x.Sub(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) != (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SSubNoOverflow(ys).And(xs.SSubNoUnderflow(ys)).Not()}
+}
+
+// MulChecked returns x * y and whether the result overflowed
+// int32. If it did, z is the wrapped result that Mul returns.
+func (x Int32) MulChecked(y Int32) (z Int32, overflow Bool) {
+	z = x.Mul(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: x.C != 0 && (z.C/x.C != y.C || x.C == -1 && y.C != 0 && y.C == -y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SignExtend(32).Mul(ys.SignExtend(32)).Eq(xs.Mul(ys).SignExtend(32)).Not()}
+}
+
+// QuoChecked returns x / y and whether the result overflowed
+// int32. If it did, z is the wrapped result that Quo returns.
+func (x Int32) QuoChecked(y Int32) (z Int32, overflow Bool) {
+	z = x.Quo(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: y.C == -1 && x.C != 0 && x.C == -x.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SDivNoOverflow(ys).Not()}
+}
+
+// NegChecked returns -x and whether the result overflowed int32.
+func (x Int32) NegChecked() (z Int32, overflow Bool) {
+	z = x.Neg()
+	if x.IsConcrete() {
+		return z, Bool{C: x.C != 0 && x.C == -x.C}
+	}
+	return z, Bool{S: x.S.SNegNoOverflow().Not()}
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Int32) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToInt32().NE(x)
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Int32) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToInt32().NE(x)
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Int32) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToInt32().NE(x)
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Int32) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToInt32().NE(x)
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Int32) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToInt32().NE(x)
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Int32) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToInt32().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Int32) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToInt32().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Int32) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToInt32().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Int32) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToInt32().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Int32) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToInt32().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Int32) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToInt32().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// Int64 implements symbolic int64 values.
+type Int64 struct {
+	C int64
+	S z3.BV
+}
+
+// AnyInt64 returns an unconstrained symbolic Int64.
+func AnyInt64(ctx *z3.Context, name string) Int64 {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortInt64).(z3.BV)
+	return Int64{S: sym}
+}
+
+// String returns x as a string.
+func (x Int64) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Int64) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Int64) Eval(m *z3.Model) int64 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.BV)
+	val, ok, _ := c.AsInt64()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (int64)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Int64) sym(c *cache) z3.BV {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.z3.FromInt(int64(x.C), c.sortInt64).(z3.BV)
+}
+
+func (Int64) zero() Int64 {
+	return Int64{}
+}
+
+func (Int64) sortOf(c *cache) z3.Sort { return c.sortInt64 }
+
+func (x Int64) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int64) fromSym(v z3.Value) Int64 { return Int64{S: v.(z3.BV)} }
+
+func (x Int64) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Int64) ite(cond Bool, y Int64) Int64 {
+	cache := getCache(cond.S.Context())
+	return Int64{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Int64) Min(y Int64) Int64 {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Int64) Max(y Int64) Int64 {
+	return Ite(x.GE(y), x, y)
+}
+
+// Abs returns the absolute value of x.
+// As in Go, the negation of the most negative int64 overflows, so
+// its absolute value is itself.
+func (x Int64) Abs() Int64 {
+	return Ite(x.LT(x.zero()), x.Neg(), x)
+}
+
+func (x Int64) Add(y Int64) Int64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Int64{C: x.C + y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Int64{S: x.sym(cache).Add(y.sym(cache))}
+}
+
+func (x Int64) Sub(y Int64) Int64 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int64{C: x.C - y.C}
 	}
@@ -1684,6 +2656,7 @@ func (x Int64) Quo(y Int64) Int64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int64{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
@@ -1696,6 +2669,7 @@ func (x Int64) Rem(y Int64) Int64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Int64{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
@@ -1948,6 +2922,198 @@ func (x Int64) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(0)}
 }
 
+func (x Int64) ToFloat32() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: float32(x.C)}
+	}
+	return Float32{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat32)}
+}
+
+func (x Int64) ToFloat64() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: float64(x.C)}
+	}
+	return Float64{S: x.S.SToFloat(getCache(x.S.Context()).sortFloat64)}
+}
+
+// AddChecked returns x + y and whether the result overflowed
+// int64. If it did, z is the wrapped result that Add returns.
+func (x Int64) AddChecked(y Int64) (z Int64, overflow Bool) {
+	z = x.Add(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) == (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SAddNoOverflow(ys).And(xs.SAddNoUnderflow(ys)).Not()}
+}
+
+// SubChecked returns x - y and whether the result overflowed
+// int64. If it did, z is the wrapped result that Sub returns.
+func (x Int64) SubChecked(y Int64) (z Int64, overflow Bool) {
+	z = x.Sub(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: (x.C >= 0) != (y.C >= 0) && (z.C >= 0) != (x.C >= 0)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SSubNoOverflow(ys).And(xs.SSubNoUnderflow(ys)).Not()}
+}
+
+// MulChecked returns x * y and whether the result overflowed
+// int64. If it did, z is the wrapped result that Mul returns.
+func (x Int64) MulChecked(y Int64) (z Int64, overflow Bool) {
+	z = x.Mul(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: x.C != 0 && (z.C/x.C != y.C || x.C == -1 && y.C != 0 && y.C == -y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SignExtend(64).Mul(ys.SignExtend(64)).Eq(xs.Mul(ys).SignExtend(64)).Not()}
+}
+
+// QuoChecked returns x / y and whether the result overflowed
+// int64. If it did, z is the wrapped result that Quo returns.
+func (x Int64) QuoChecked(y Int64) (z Int64, overflow Bool) {
+	z = x.Quo(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: y.C == -1 && x.C != 0 && x.C == -x.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.SDivNoOverflow(ys).Not()}
+}
+
+// NegChecked returns -x and whether the result overflowed int64.
+func (x Int64) NegChecked() (z Int64, overflow Bool) {
+	z = x.Neg()
+	if x.IsConcrete() {
+		return z, Bool{C: x.C != 0 && x.C == -x.C}
+	}
+	return z, Bool{S: x.S.SNegNoOverflow().Not()}
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Int64) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToInt64().NE(x)
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Int64) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToInt64().NE(x)
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Int64) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToInt64().NE(x)
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Int64) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToInt64().NE(x)
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Int64) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToInt64().NE(x)
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Int64) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToInt64().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Int64) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToInt64().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Int64) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToInt64().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Int64) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToInt64().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Int64) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToInt64().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Int64) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToInt64().NE(x)
+	overflow = overflow.Or(x.LT(x.zero()))
+	return z, overflow
+}
+
 // Uint implements symbolic uint values.
 type Uint struct {
 	C uint
@@ -1996,6 +3162,33 @@ func (x Uint) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortUint).(z3.BV)
 }
 
+func (Uint) zero() Uint {
+	return Uint{}
+}
+
+func (Uint) sortOf(c *cache) z3.Sort { return c.sortUint }
+
+func (x Uint) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint) fromSym(v z3.Value) Uint { return Uint{S: v.(z3.BV)} }
+
+func (x Uint) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Uint) ite(cond Bool, y Uint) Uint {
+	cache := getCache(cond.S.Context())
+	return Uint{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Uint) Min(y Uint) Uint {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Uint) Max(y Uint) Uint {
+	return Ite(x.GE(y), x, y)
+}
+
 func (x Uint) Add(y Uint) Uint {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint{C: x.C + y.C}
@@ -2041,6 +3234,7 @@ func (x Uint) Quo(y Uint) Uint {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Uint{S: x.sym(cache).UDiv(y.sym(cache))}
 }
 
@@ -2053,6 +3247,7 @@ func (x Uint) Rem(y Uint) Uint {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Uint{S: x.sym(cache).URem(y.sym(cache))}
 }
 
@@ -2305,112 +3500,424 @@ func (x Uint) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(0)}
 }
 
-// Uint8 implements symbolic uint8 values.
-type Uint8 struct {
-	C uint8
-	S z3.BV
-}
-
-// AnyUint8 returns an unconstrained symbolic Uint8.
-func AnyUint8(ctx *z3.Context, name string) Uint8 {
-	cache := getCache(ctx)
-	sym := cache.z3.FreshConst(name, cache.sortUint8).(z3.BV)
-	return Uint8{S: sym}
-}
-
-// String returns x as a string.
-func (x Uint8) String() string {
+func (x Uint) ToFloat32() Float32 {
 	if x.IsConcrete() {
-		return fmt.Sprint(x.C)
+		return Float32{C: float32(x.C)}
 	}
-	return x.S.String()
+	return Float32{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat32)}
 }
 
-// IsConcrete returns true if x is concrete.
-func (x Uint8) IsConcrete() bool {
-	return x.S.Context() == nil
-}
-
-// Eval returns x's concrete value in model m.
-// This also evaluates x with model completion.
-func (x Uint8) Eval(m *z3.Model) uint8 {
+func (x Uint) ToFloat64() Float64 {
 	if x.IsConcrete() {
-		return x.C
-	}
-	c := m.Eval(x.S, true).(z3.BV)
-	val, ok, _ := c.AsUint64()
-	if !ok {
-		panic("model evaluation produced non-concrete value " + c.String())
-	}
-	return (uint8)(val)
-}
-
-// sym returns x's symbolic value, creating it if necessary.
-func (x Uint8) sym(c *cache) z3.BV {
-	if !x.IsConcrete() {
-		return x.S
+		return Float64{C: float64(x.C)}
 	}
-	return c.z3.FromInt(int64(x.C), c.sortUint8).(z3.BV)
+	return Float64{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat64)}
 }
 
-func (x Uint8) Add(y Uint8) Uint8 {
+// AddChecked returns x + y and whether the result overflowed
+// uint. If it did, z is the wrapped result that Add returns.
+func (x Uint) AddChecked(y Uint) (z Uint, overflow Bool) {
+	z = x.Add(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint8{C: x.C + y.C}
+		return z, Bool{C: z.C < x.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint8{S: x.sym(cache).Add(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UAddNoOverflow(ys).Not()}
 }
 
-func (x Uint8) Sub(y Uint8) Uint8 {
+// SubChecked returns x - y and whether the result overflowed
+// uint. If it did, z is the wrapped result that Sub returns.
+func (x Uint) SubChecked(y Uint) (z Uint, overflow Bool) {
+	z = x.Sub(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint8{C: x.C - y.C}
+		return z, Bool{C: x.C < y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint8{S: x.sym(cache).Sub(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.USubNoUnderflow(ys).Not()}
 }
 
-func (x Uint8) Mul(y Uint8) Uint8 {
+// MulChecked returns x * y and whether the result overflowed
+// uint. If it did, z is the wrapped result that Mul returns.
+func (x Uint) MulChecked(y Uint) (z Uint, overflow Bool) {
+	z = x.Mul(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint8{C: x.C * y.C}
+		return z, Bool{C: x.C != 0 && z.C/x.C != y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint8{S: x.sym(cache).Mul(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UMulNoOverflow(ys).Not()}
 }
 
-func (x Uint8) Quo(y Uint8) Uint8 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint8{C: x.C / y.C}
+// QuoChecked returns x / y and whether the result overflowed
+// uint. If it did, z is the wrapped result that Quo returns.
+// Unsigned division never overflows.
+func (x Uint) QuoChecked(y Uint) (z Uint, overflow Bool) {
+	z = x.Quo(y)
+	return z, Bool{C: false}
+}
+
+// NegChecked returns -x and whether the result overflowed uint.
+// The negation of any non-zero unsigned value overflows.
+func (x Uint) NegChecked() (z Uint, overflow Bool) {
+	z = x.Neg()
+	return z, x.NE(x.zero())
+}
+
+// LeadingZeros returns the number of leading zero bits in x, like bits.LeadingZeros.
+func (x Uint) LeadingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.LeadingZeros(x.C)}
 	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+	return Uint{S: bvLeadingZeros(x.S)}.ToInt()
+}
+
+// TrailingZeros returns the number of trailing zero bits in x, like bits.TrailingZeros.
+func (x Uint) TrailingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.TrailingZeros(x.C)}
 	}
-	cache := getCache(ctx)
-	return Uint8{S: x.sym(cache).UDiv(y.sym(cache))}
+	return Uint{S: bvTrailingZeros(x.S)}.ToInt()
 }
 
-func (x Uint8) Rem(y Uint8) Uint8 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint8{C: x.C % y.C}
+// OnesCount returns the number of one bits in x, like bits.OnesCount.
+func (x Uint) OnesCount() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.OnesCount(x.C)}
+	}
+	return Uint{S: bvOnesCount(x.S)}.ToInt()
+}
+
+// Len returns the minimum number of bits required to represent x, like bits.Len.
+func (x Uint) Len() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.Len(x.C)}
+	}
+	return Int{C: 64}.Sub(x.LeadingZeros())
+}
+
+// Reverse returns x with its bits in reversed order, like bits.Reverse.
+func (x Uint) Reverse() Uint {
+	if x.IsConcrete() {
+		return Uint{C: bits.Reverse(x.C)}
+	}
+	return Uint{S: bvReverse(x.S)}
+}
+
+// ReverseBytes returns x with its bytes in reversed order, like bits.ReverseBytes.
+func (x Uint) ReverseBytes() Uint {
+	if x.IsConcrete() {
+		return Uint{C: bits.ReverseBytes(x.C)}
+	}
+	return Uint{S: bvReverseBytes(x.S)}
+}
+
+// RotateLeft returns x rotated left by (k mod 64) bits, like
+// bits.RotateLeft. To rotate right by k bits, pass -k.
+func (x Uint) RotateLeft(k Int) Uint {
+	if x.IsConcrete() && k.IsConcrete() {
+		return Uint{C: bits.RotateLeft(x.C, k.C)}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
-		ctx = y.S.Context()
+		ctx = k.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint8{S: x.sym(cache).URem(y.sym(cache))}
+	return Uint{S: bvRotateLeft(x.sym(cache), k.ToUint().sym(cache))}
+}
+
+// Add returns the sum with carry of x, y, and carry, like
+// bits.Add. carry must be 0 or 1, and carryOut is 0 or 1.
+func Add(x, y, carry Uint) (sum, carryOut Uint) {
+	if x.IsConcrete() && y.IsConcrete() && carry.IsConcrete() {
+		s, c := bits.Add(x.C, y.C, carry.C)
+		return Uint{C: s}, Uint{C: c}
+	}
+	sum = x.Add(y).Add(carry)
+	carryOut = x.And(y).Or(x.Or(y).AndNot(sum)).Rsh(Uint64{C: 63})
+	return sum, carryOut
+}
+
+// Sub returns the difference of x, y, and borrow, like
+// bits.Sub. borrow must be 0 or 1, and borrowOut is 0 or 1.
+func Sub(x, y, borrow Uint) (diff, borrowOut Uint) {
+	if x.IsConcrete() && y.IsConcrete() && borrow.IsConcrete() {
+		d, b := bits.Sub(x.C, y.C, borrow.C)
+		return Uint{C: d}, Uint{C: b}
+	}
+	diff = x.Sub(y).Sub(borrow)
+	borrowOut = x.Not().And(y).Or(x.Xor(y).Not().And(diff)).Rsh(Uint64{C: 63})
+	return diff, borrowOut
+}
+
+// Mul returns the 128-bit product of x and y, like bits.Mul.
+func Mul(x, y Uint) (hi, lo Uint) {
+	if x.IsConcrete() && y.IsConcrete() {
+		h, l := bits.Mul(x.C, y.C)
+		return Uint{C: h}, Uint{C: l}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	p := x.sym(cache).ZeroExtend(64).Mul(y.sym(cache).ZeroExtend(64))
+	hi, lo = Uint{S: p.Extract(127, 64)}, Uint{S: p.Extract(63, 0)}
+	return hi, lo
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Uint) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToUint().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Uint) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToUint().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Uint) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToUint().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Uint) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToUint().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Uint) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToUint().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Uint) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToUint().NE(x)
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Uint) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToUint().NE(x)
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Uint) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToUint().NE(x)
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Uint) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToUint().NE(x)
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Uint) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToUint().NE(x)
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Uint) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToUint().NE(x)
+	return z, overflow
+}
+
+// Uint8 implements symbolic uint8 values.
+type Uint8 struct {
+	C uint8
+	S z3.BV
+}
+
+// AnyUint8 returns an unconstrained symbolic Uint8.
+func AnyUint8(ctx *z3.Context, name string) Uint8 {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortUint8).(z3.BV)
+	return Uint8{S: sym}
+}
+
+// String returns x as a string.
+func (x Uint8) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Uint8) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Uint8) Eval(m *z3.Model) uint8 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.BV)
+	val, ok, _ := c.AsUint64()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (uint8)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Uint8) sym(c *cache) z3.BV {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.z3.FromInt(int64(x.C), c.sortUint8).(z3.BV)
+}
+
+func (Uint8) zero() Uint8 {
+	return Uint8{}
+}
+
+func (Uint8) sortOf(c *cache) z3.Sort { return c.sortUint8 }
+
+func (x Uint8) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint8) fromSym(v z3.Value) Uint8 { return Uint8{S: v.(z3.BV)} }
+
+func (x Uint8) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Uint8) ite(cond Bool, y Uint8) Uint8 {
+	cache := getCache(cond.S.Context())
+	return Uint8{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Uint8) Min(y Uint8) Uint8 {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Uint8) Max(y Uint8) Uint8 {
+	return Ite(x.GE(y), x, y)
+}
+
+func (x Uint8) Add(y Uint8) Uint8 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint8{C: x.C + y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint8{S: x.sym(cache).Add(y.sym(cache))}
+}
+
+func (x Uint8) Sub(y Uint8) Uint8 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint8{C: x.C - y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint8{S: x.sym(cache).Sub(y.sym(cache))}
+}
+
+func (x Uint8) Mul(y Uint8) Uint8 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint8{C: x.C * y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint8{S: x.sym(cache).Mul(y.sym(cache))}
+}
+
+func (x Uint8) Quo(y Uint8) Uint8 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint8{C: x.C / y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uint8{S: x.sym(cache).UDiv(y.sym(cache))}
+}
+
+func (x Uint8) Rem(y Uint8) Uint8 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint8{C: x.C % y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uint8{S: x.sym(cache).URem(y.sym(cache))}
 }
 
 func (x Uint8) And(y Uint8) Uint8 {
@@ -2668,141 +4175,445 @@ func (x Uint8) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(56)}
 }
 
-// Uint16 implements symbolic uint16 values.
-type Uint16 struct {
-	C uint16
-	S z3.BV
-}
-
-// AnyUint16 returns an unconstrained symbolic Uint16.
-func AnyUint16(ctx *z3.Context, name string) Uint16 {
-	cache := getCache(ctx)
-	sym := cache.z3.FreshConst(name, cache.sortUint16).(z3.BV)
-	return Uint16{S: sym}
-}
-
-// String returns x as a string.
-func (x Uint16) String() string {
+func (x Uint8) ToFloat32() Float32 {
 	if x.IsConcrete() {
-		return fmt.Sprint(x.C)
+		return Float32{C: float32(x.C)}
 	}
-	return x.S.String()
-}
-
-// IsConcrete returns true if x is concrete.
-func (x Uint16) IsConcrete() bool {
-	return x.S.Context() == nil
+	return Float32{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat32)}
 }
 
-// Eval returns x's concrete value in model m.
-// This also evaluates x with model completion.
-func (x Uint16) Eval(m *z3.Model) uint16 {
+func (x Uint8) ToFloat64() Float64 {
 	if x.IsConcrete() {
-		return x.C
-	}
-	c := m.Eval(x.S, true).(z3.BV)
-	val, ok, _ := c.AsUint64()
-	if !ok {
-		panic("model evaluation produced non-concrete value " + c.String())
-	}
-	return (uint16)(val)
-}
-
-// sym returns x's symbolic value, creating it if necessary.
-func (x Uint16) sym(c *cache) z3.BV {
-	if !x.IsConcrete() {
-		return x.S
+		return Float64{C: float64(x.C)}
 	}
-	return c.z3.FromInt(int64(x.C), c.sortUint16).(z3.BV)
+	return Float64{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat64)}
 }
 
-func (x Uint16) Add(y Uint16) Uint16 {
+// AddChecked returns x + y and whether the result overflowed
+// uint8. If it did, z is the wrapped result that Add returns.
+func (x Uint8) AddChecked(y Uint8) (z Uint8, overflow Bool) {
+	z = x.Add(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint16{C: x.C + y.C}
+		return z, Bool{C: z.C < x.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint16{S: x.sym(cache).Add(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UAddNoOverflow(ys).Not()}
 }
 
-func (x Uint16) Sub(y Uint16) Uint16 {
+// SubChecked returns x - y and whether the result overflowed
+// uint8. If it did, z is the wrapped result that Sub returns.
+func (x Uint8) SubChecked(y Uint8) (z Uint8, overflow Bool) {
+	z = x.Sub(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint16{C: x.C - y.C}
+		return z, Bool{C: x.C < y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint16{S: x.sym(cache).Sub(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.USubNoUnderflow(ys).Not()}
 }
 
-func (x Uint16) Mul(y Uint16) Uint16 {
+// MulChecked returns x * y and whether the result overflowed
+// uint8. If it did, z is the wrapped result that Mul returns.
+func (x Uint8) MulChecked(y Uint8) (z Uint8, overflow Bool) {
+	z = x.Mul(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint16{C: x.C * y.C}
+		return z, Bool{C: x.C != 0 && z.C/x.C != y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint16{S: x.sym(cache).Mul(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UMulNoOverflow(ys).Not()}
 }
 
-func (x Uint16) Quo(y Uint16) Uint16 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint16{C: x.C / y.C}
+// QuoChecked returns x / y and whether the result overflowed
+// uint8. If it did, z is the wrapped result that Quo returns.
+// Unsigned division never overflows.
+func (x Uint8) QuoChecked(y Uint8) (z Uint8, overflow Bool) {
+	z = x.Quo(y)
+	return z, Bool{C: false}
+}
+
+// NegChecked returns -x and whether the result overflowed uint8.
+// The negation of any non-zero unsigned value overflows.
+func (x Uint8) NegChecked() (z Uint8, overflow Bool) {
+	z = x.Neg()
+	return z, x.NE(x.zero())
+}
+
+// LeadingZeros returns the number of leading zero bits in x, like bits.LeadingZeros8.
+func (x Uint8) LeadingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.LeadingZeros8(x.C)}
 	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+	return Uint8{S: bvLeadingZeros(x.S)}.ToInt()
+}
+
+// TrailingZeros returns the number of trailing zero bits in x, like bits.TrailingZeros8.
+func (x Uint8) TrailingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.TrailingZeros8(x.C)}
 	}
-	cache := getCache(ctx)
-	return Uint16{S: x.sym(cache).UDiv(y.sym(cache))}
+	return Uint8{S: bvTrailingZeros(x.S)}.ToInt()
 }
 
-func (x Uint16) Rem(y Uint16) Uint16 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint16{C: x.C % y.C}
+// OnesCount returns the number of one bits in x, like bits.OnesCount8.
+func (x Uint8) OnesCount() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.OnesCount8(x.C)}
 	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+	return Uint8{S: bvOnesCount(x.S)}.ToInt()
+}
+
+// Len returns the minimum number of bits required to represent x, like bits.Len8.
+func (x Uint8) Len() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.Len8(x.C)}
 	}
-	cache := getCache(ctx)
-	return Uint16{S: x.sym(cache).URem(y.sym(cache))}
+	return Int{C: 8}.Sub(x.LeadingZeros())
 }
 
-func (x Uint16) And(y Uint16) Uint16 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint16{C: x.C & y.C}
+// Reverse returns x with its bits in reversed order, like bits.Reverse8.
+func (x Uint8) Reverse() Uint8 {
+	if x.IsConcrete() {
+		return Uint8{C: bits.Reverse8(x.C)}
+	}
+	return Uint8{S: bvReverse(x.S)}
+}
+
+// RotateLeft returns x rotated left by (k mod 8) bits, like
+// bits.RotateLeft8. To rotate right by k bits, pass -k.
+func (x Uint8) RotateLeft(k Int) Uint8 {
+	if x.IsConcrete() && k.IsConcrete() {
+		return Uint8{C: bits.RotateLeft8(x.C, k.C)}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
-		ctx = y.S.Context()
+		ctx = k.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint16{S: x.sym(cache).And(y.sym(cache))}
+	return Uint8{S: bvRotateLeft(x.sym(cache), k.ToUint8().sym(cache))}
 }
 
-func (x Uint16) Or(y Uint16) Uint16 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint16{C: x.C | y.C}
+// Add8 returns the sum with carry of x, y, and carry, like
+// bits.Add, but for uint8. carry must be 0 or 1, and carryOut is 0 or 1.
+func Add8(x, y, carry Uint8) (sum, carryOut Uint8) {
+	if x.IsConcrete() && y.IsConcrete() && carry.IsConcrete() {
+		p := uint32(x.C) + uint32(y.C) + uint32(carry.C)
+		return Uint8{C: uint8(p)}, Uint8{C: uint8(p >> 8)}
 	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+	sum = x.Add(y).Add(carry)
+	carryOut = x.And(y).Or(x.Or(y).AndNot(sum)).Rsh(Uint64{C: 7})
+	return sum, carryOut
+}
+
+// Sub8 returns the difference of x, y, and borrow, like
+// bits.Sub, but for uint8. borrow must be 0 or 1, and borrowOut is 0 or 1.
+func Sub8(x, y, borrow Uint8) (diff, borrowOut Uint8) {
+	if x.IsConcrete() && y.IsConcrete() && borrow.IsConcrete() {
+		p := uint32(x.C) - uint32(y.C) - uint32(borrow.C)
+		return Uint8{C: uint8(p)}, Uint8{C: uint8(p >> 31)}
 	}
-	cache := getCache(ctx)
-	return Uint16{S: x.sym(cache).Or(y.sym(cache))}
+	diff = x.Sub(y).Sub(borrow)
+	borrowOut = x.Not().And(y).Or(x.Xor(y).Not().And(diff)).Rsh(Uint64{C: 7})
+	return diff, borrowOut
 }
 
-func (x Uint16) Xor(y Uint16) Uint16 {
+// Mul8 returns the 16-bit product of x and y, like bits.Mul, but for uint8.
+func Mul8(x, y Uint8) (hi, lo Uint8) {
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint16{C: x.C ^ y.C}
+		p := uint32(x.C) * uint32(y.C)
+		return Uint8{C: uint8(p >> 8)}, Uint8{C: uint8(p)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	p := x.sym(cache).ZeroExtend(8).Mul(y.sym(cache).ZeroExtend(8))
+	hi, lo = Uint8{S: p.Extract(15, 8)}, Uint8{S: p.Extract(7, 0)}
+	return hi, lo
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Uint8) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToUint8().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Uint8) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToUint8().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Uint8) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToUint8().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Uint8) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToUint8().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Uint8) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToUint8().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Uint8) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToUint8().NE(x)
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Uint8) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToUint8().NE(x)
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Uint8) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToUint8().NE(x)
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Uint8) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToUint8().NE(x)
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Uint8) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToUint8().NE(x)
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Uint8) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToUint8().NE(x)
+	return z, overflow
+}
+
+// Uint16 implements symbolic uint16 values.
+type Uint16 struct {
+	C uint16
+	S z3.BV
+}
+
+// AnyUint16 returns an unconstrained symbolic Uint16.
+func AnyUint16(ctx *z3.Context, name string) Uint16 {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortUint16).(z3.BV)
+	return Uint16{S: sym}
+}
+
+// String returns x as a string.
+func (x Uint16) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Uint16) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Uint16) Eval(m *z3.Model) uint16 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.BV)
+	val, ok, _ := c.AsUint64()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (uint16)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Uint16) sym(c *cache) z3.BV {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.z3.FromInt(int64(x.C), c.sortUint16).(z3.BV)
+}
+
+func (Uint16) zero() Uint16 {
+	return Uint16{}
+}
+
+func (Uint16) sortOf(c *cache) z3.Sort { return c.sortUint16 }
+
+func (x Uint16) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint16) fromSym(v z3.Value) Uint16 { return Uint16{S: v.(z3.BV)} }
+
+func (x Uint16) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Uint16) ite(cond Bool, y Uint16) Uint16 {
+	cache := getCache(cond.S.Context())
+	return Uint16{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Uint16) Min(y Uint16) Uint16 {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Uint16) Max(y Uint16) Uint16 {
+	return Ite(x.GE(y), x, y)
+}
+
+func (x Uint16) Add(y Uint16) Uint16 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint16{C: x.C + y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint16{S: x.sym(cache).Add(y.sym(cache))}
+}
+
+func (x Uint16) Sub(y Uint16) Uint16 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint16{C: x.C - y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint16{S: x.sym(cache).Sub(y.sym(cache))}
+}
+
+func (x Uint16) Mul(y Uint16) Uint16 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint16{C: x.C * y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint16{S: x.sym(cache).Mul(y.sym(cache))}
+}
+
+func (x Uint16) Quo(y Uint16) Uint16 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint16{C: x.C / y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uint16{S: x.sym(cache).UDiv(y.sym(cache))}
+}
+
+func (x Uint16) Rem(y Uint16) Uint16 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint16{C: x.C % y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uint16{S: x.sym(cache).URem(y.sym(cache))}
+}
+
+func (x Uint16) And(y Uint16) Uint16 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint16{C: x.C & y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint16{S: x.sym(cache).And(y.sym(cache))}
+}
+
+func (x Uint16) Or(y Uint16) Uint16 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint16{C: x.C | y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint16{S: x.sym(cache).Or(y.sym(cache))}
+}
+
+func (x Uint16) Xor(y Uint16) Uint16 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint16{C: x.C ^ y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
@@ -3031,115 +4842,427 @@ func (x Uint16) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(48)}
 }
 
-// Uint32 implements symbolic uint32 values.
-type Uint32 struct {
-	C uint32
-	S z3.BV
-}
-
-// AnyUint32 returns an unconstrained symbolic Uint32.
-func AnyUint32(ctx *z3.Context, name string) Uint32 {
-	cache := getCache(ctx)
-	sym := cache.z3.FreshConst(name, cache.sortUint32).(z3.BV)
-	return Uint32{S: sym}
-}
-
-// String returns x as a string.
-func (x Uint32) String() string {
+func (x Uint16) ToFloat32() Float32 {
 	if x.IsConcrete() {
-		return fmt.Sprint(x.C)
+		return Float32{C: float32(x.C)}
 	}
-	return x.S.String()
+	return Float32{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat32)}
 }
 
-// IsConcrete returns true if x is concrete.
-func (x Uint32) IsConcrete() bool {
-	return x.S.Context() == nil
-}
-
-// Eval returns x's concrete value in model m.
-// This also evaluates x with model completion.
-func (x Uint32) Eval(m *z3.Model) uint32 {
+func (x Uint16) ToFloat64() Float64 {
 	if x.IsConcrete() {
-		return x.C
-	}
-	c := m.Eval(x.S, true).(z3.BV)
-	val, ok, _ := c.AsUint64()
-	if !ok {
-		panic("model evaluation produced non-concrete value " + c.String())
-	}
-	return (uint32)(val)
-}
-
-// sym returns x's symbolic value, creating it if necessary.
-func (x Uint32) sym(c *cache) z3.BV {
-	if !x.IsConcrete() {
-		return x.S
+		return Float64{C: float64(x.C)}
 	}
-	return c.z3.FromInt(int64(x.C), c.sortUint32).(z3.BV)
+	return Float64{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat64)}
 }
 
-func (x Uint32) Add(y Uint32) Uint32 {
+// AddChecked returns x + y and whether the result overflowed
+// uint16. If it did, z is the wrapped result that Add returns.
+func (x Uint16) AddChecked(y Uint16) (z Uint16, overflow Bool) {
+	z = x.Add(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint32{C: x.C + y.C}
+		return z, Bool{C: z.C < x.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint32{S: x.sym(cache).Add(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UAddNoOverflow(ys).Not()}
 }
 
-func (x Uint32) Sub(y Uint32) Uint32 {
+// SubChecked returns x - y and whether the result overflowed
+// uint16. If it did, z is the wrapped result that Sub returns.
+func (x Uint16) SubChecked(y Uint16) (z Uint16, overflow Bool) {
+	z = x.Sub(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint32{C: x.C - y.C}
+		return z, Bool{C: x.C < y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint32{S: x.sym(cache).Sub(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.USubNoUnderflow(ys).Not()}
 }
 
-func (x Uint32) Mul(y Uint32) Uint32 {
+// MulChecked returns x * y and whether the result overflowed
+// uint16. If it did, z is the wrapped result that Mul returns.
+func (x Uint16) MulChecked(y Uint16) (z Uint16, overflow Bool) {
+	z = x.Mul(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint32{C: x.C * y.C}
+		return z, Bool{C: x.C != 0 && z.C/x.C != y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint32{S: x.sym(cache).Mul(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UMulNoOverflow(ys).Not()}
 }
 
-func (x Uint32) Quo(y Uint32) Uint32 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint32{C: x.C / y.C}
+// QuoChecked returns x / y and whether the result overflowed
+// uint16. If it did, z is the wrapped result that Quo returns.
+// Unsigned division never overflows.
+func (x Uint16) QuoChecked(y Uint16) (z Uint16, overflow Bool) {
+	z = x.Quo(y)
+	return z, Bool{C: false}
+}
+
+// NegChecked returns -x and whether the result overflowed uint16.
+// The negation of any non-zero unsigned value overflows.
+func (x Uint16) NegChecked() (z Uint16, overflow Bool) {
+	z = x.Neg()
+	return z, x.NE(x.zero())
+}
+
+// LeadingZeros returns the number of leading zero bits in x, like bits.LeadingZeros16.
+func (x Uint16) LeadingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.LeadingZeros16(x.C)}
 	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+	return Uint16{S: bvLeadingZeros(x.S)}.ToInt()
+}
+
+// TrailingZeros returns the number of trailing zero bits in x, like bits.TrailingZeros16.
+func (x Uint16) TrailingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.TrailingZeros16(x.C)}
 	}
-	cache := getCache(ctx)
-	return Uint32{S: x.sym(cache).UDiv(y.sym(cache))}
+	return Uint16{S: bvTrailingZeros(x.S)}.ToInt()
 }
 
-func (x Uint32) Rem(y Uint32) Uint32 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint32{C: x.C % y.C}
+// OnesCount returns the number of one bits in x, like bits.OnesCount16.
+func (x Uint16) OnesCount() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.OnesCount16(x.C)}
 	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+	return Uint16{S: bvOnesCount(x.S)}.ToInt()
+}
+
+// Len returns the minimum number of bits required to represent x, like bits.Len16.
+func (x Uint16) Len() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.Len16(x.C)}
 	}
-	cache := getCache(ctx)
-	return Uint32{S: x.sym(cache).URem(y.sym(cache))}
+	return Int{C: 16}.Sub(x.LeadingZeros())
 }
 
-func (x Uint32) And(y Uint32) Uint32 {
+// Reverse returns x with its bits in reversed order, like bits.Reverse16.
+func (x Uint16) Reverse() Uint16 {
+	if x.IsConcrete() {
+		return Uint16{C: bits.Reverse16(x.C)}
+	}
+	return Uint16{S: bvReverse(x.S)}
+}
+
+// ReverseBytes returns x with its bytes in reversed order, like bits.ReverseBytes16.
+func (x Uint16) ReverseBytes() Uint16 {
+	if x.IsConcrete() {
+		return Uint16{C: bits.ReverseBytes16(x.C)}
+	}
+	return Uint16{S: bvReverseBytes(x.S)}
+}
+
+// RotateLeft returns x rotated left by (k mod 16) bits, like
+// bits.RotateLeft16. To rotate right by k bits, pass -k.
+func (x Uint16) RotateLeft(k Int) Uint16 {
+	if x.IsConcrete() && k.IsConcrete() {
+		return Uint16{C: bits.RotateLeft16(x.C, k.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = k.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint16{S: bvRotateLeft(x.sym(cache), k.ToUint16().sym(cache))}
+}
+
+// Add16 returns the sum with carry of x, y, and carry, like
+// bits.Add, but for uint16. carry must be 0 or 1, and carryOut is 0 or 1.
+func Add16(x, y, carry Uint16) (sum, carryOut Uint16) {
+	if x.IsConcrete() && y.IsConcrete() && carry.IsConcrete() {
+		p := uint32(x.C) + uint32(y.C) + uint32(carry.C)
+		return Uint16{C: uint16(p)}, Uint16{C: uint16(p >> 16)}
+	}
+	sum = x.Add(y).Add(carry)
+	carryOut = x.And(y).Or(x.Or(y).AndNot(sum)).Rsh(Uint64{C: 15})
+	return sum, carryOut
+}
+
+// Sub16 returns the difference of x, y, and borrow, like
+// bits.Sub, but for uint16. borrow must be 0 or 1, and borrowOut is 0 or 1.
+func Sub16(x, y, borrow Uint16) (diff, borrowOut Uint16) {
+	if x.IsConcrete() && y.IsConcrete() && borrow.IsConcrete() {
+		p := uint32(x.C) - uint32(y.C) - uint32(borrow.C)
+		return Uint16{C: uint16(p)}, Uint16{C: uint16(p >> 31)}
+	}
+	diff = x.Sub(y).Sub(borrow)
+	borrowOut = x.Not().And(y).Or(x.Xor(y).Not().And(diff)).Rsh(Uint64{C: 15})
+	return diff, borrowOut
+}
+
+// Mul16 returns the 32-bit product of x and y, like bits.Mul, but for uint16.
+func Mul16(x, y Uint16) (hi, lo Uint16) {
+	if x.IsConcrete() && y.IsConcrete() {
+		p := uint32(x.C) * uint32(y.C)
+		return Uint16{C: uint16(p >> 16)}, Uint16{C: uint16(p)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	p := x.sym(cache).ZeroExtend(16).Mul(y.sym(cache).ZeroExtend(16))
+	hi, lo = Uint16{S: p.Extract(31, 16)}, Uint16{S: p.Extract(15, 0)}
+	return hi, lo
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Uint16) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToUint16().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Uint16) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToUint16().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Uint16) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToUint16().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Uint16) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToUint16().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Uint16) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToUint16().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Uint16) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToUint16().NE(x)
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Uint16) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToUint16().NE(x)
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Uint16) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToUint16().NE(x)
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Uint16) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToUint16().NE(x)
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Uint16) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToUint16().NE(x)
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Uint16) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToUint16().NE(x)
+	return z, overflow
+}
+
+// Uint32 implements symbolic uint32 values.
+type Uint32 struct {
+	C uint32
+	S z3.BV
+}
+
+// AnyUint32 returns an unconstrained symbolic Uint32.
+func AnyUint32(ctx *z3.Context, name string) Uint32 {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortUint32).(z3.BV)
+	return Uint32{S: sym}
+}
+
+// String returns x as a string.
+func (x Uint32) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Uint32) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Uint32) Eval(m *z3.Model) uint32 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.BV)
+	val, ok, _ := c.AsUint64()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (uint32)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Uint32) sym(c *cache) z3.BV {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.z3.FromInt(int64(x.C), c.sortUint32).(z3.BV)
+}
+
+func (Uint32) zero() Uint32 {
+	return Uint32{}
+}
+
+func (Uint32) sortOf(c *cache) z3.Sort { return c.sortUint32 }
+
+func (x Uint32) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint32) fromSym(v z3.Value) Uint32 { return Uint32{S: v.(z3.BV)} }
+
+func (x Uint32) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Uint32) ite(cond Bool, y Uint32) Uint32 {
+	cache := getCache(cond.S.Context())
+	return Uint32{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Uint32) Min(y Uint32) Uint32 {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Uint32) Max(y Uint32) Uint32 {
+	return Ite(x.GE(y), x, y)
+}
+
+func (x Uint32) Add(y Uint32) Uint32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint32{C: x.C + y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint32{S: x.sym(cache).Add(y.sym(cache))}
+}
+
+func (x Uint32) Sub(y Uint32) Uint32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint32{C: x.C - y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint32{S: x.sym(cache).Sub(y.sym(cache))}
+}
+
+func (x Uint32) Mul(y Uint32) Uint32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint32{C: x.C * y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint32{S: x.sym(cache).Mul(y.sym(cache))}
+}
+
+func (x Uint32) Quo(y Uint32) Uint32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint32{C: x.C / y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uint32{S: x.sym(cache).UDiv(y.sym(cache))}
+}
+
+func (x Uint32) Rem(y Uint32) Uint32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint32{C: x.C % y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uint32{S: x.sym(cache).URem(y.sym(cache))}
+}
+
+func (x Uint32) And(y Uint32) Uint32 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint32{C: x.C & y.C}
 	}
@@ -3394,119 +5517,431 @@ func (x Uint32) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(32)}
 }
 
-// Uint64 implements symbolic uint64 values.
-type Uint64 struct {
-	C uint64
-	S z3.BV
-}
-
-// AnyUint64 returns an unconstrained symbolic Uint64.
-func AnyUint64(ctx *z3.Context, name string) Uint64 {
-	cache := getCache(ctx)
-	sym := cache.z3.FreshConst(name, cache.sortUint64).(z3.BV)
-	return Uint64{S: sym}
-}
-
-// String returns x as a string.
-func (x Uint64) String() string {
+func (x Uint32) ToFloat32() Float32 {
 	if x.IsConcrete() {
-		return fmt.Sprint(x.C)
+		return Float32{C: float32(x.C)}
 	}
-	return x.S.String()
-}
-
-// IsConcrete returns true if x is concrete.
-func (x Uint64) IsConcrete() bool {
-	return x.S.Context() == nil
+	return Float32{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat32)}
 }
 
-// Eval returns x's concrete value in model m.
-// This also evaluates x with model completion.
-func (x Uint64) Eval(m *z3.Model) uint64 {
+func (x Uint32) ToFloat64() Float64 {
 	if x.IsConcrete() {
-		return x.C
-	}
-	c := m.Eval(x.S, true).(z3.BV)
-	val, ok, _ := c.AsUint64()
-	if !ok {
-		panic("model evaluation produced non-concrete value " + c.String())
-	}
-	return (uint64)(val)
-}
-
-// sym returns x's symbolic value, creating it if necessary.
-func (x Uint64) sym(c *cache) z3.BV {
-	if !x.IsConcrete() {
-		return x.S
+		return Float64{C: float64(x.C)}
 	}
-	return c.z3.FromInt(int64(x.C), c.sortUint64).(z3.BV)
+	return Float64{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat64)}
 }
 
-func (x Uint64) Add(y Uint64) Uint64 {
+// AddChecked returns x + y and whether the result overflowed
+// uint32. If it did, z is the wrapped result that Add returns.
+func (x Uint32) AddChecked(y Uint32) (z Uint32, overflow Bool) {
+	z = x.Add(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint64{C: x.C + y.C}
+		return z, Bool{C: z.C < x.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint64{S: x.sym(cache).Add(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UAddNoOverflow(ys).Not()}
 }
 
-func (x Uint64) Sub(y Uint64) Uint64 {
+// SubChecked returns x - y and whether the result overflowed
+// uint32. If it did, z is the wrapped result that Sub returns.
+func (x Uint32) SubChecked(y Uint32) (z Uint32, overflow Bool) {
+	z = x.Sub(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint64{C: x.C - y.C}
+		return z, Bool{C: x.C < y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint64{S: x.sym(cache).Sub(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.USubNoUnderflow(ys).Not()}
 }
 
-func (x Uint64) Mul(y Uint64) Uint64 {
+// MulChecked returns x * y and whether the result overflowed
+// uint32. If it did, z is the wrapped result that Mul returns.
+func (x Uint32) MulChecked(y Uint32) (z Uint32, overflow Bool) {
+	z = x.Mul(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uint64{C: x.C * y.C}
+		return z, Bool{C: x.C != 0 && z.C/x.C != y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uint64{S: x.sym(cache).Mul(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UMulNoOverflow(ys).Not()}
 }
 
-func (x Uint64) Quo(y Uint64) Uint64 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint64{C: x.C / y.C}
+// QuoChecked returns x / y and whether the result overflowed
+// uint32. If it did, z is the wrapped result that Quo returns.
+// Unsigned division never overflows.
+func (x Uint32) QuoChecked(y Uint32) (z Uint32, overflow Bool) {
+	z = x.Quo(y)
+	return z, Bool{C: false}
+}
+
+// NegChecked returns -x and whether the result overflowed uint32.
+// The negation of any non-zero unsigned value overflows.
+func (x Uint32) NegChecked() (z Uint32, overflow Bool) {
+	z = x.Neg()
+	return z, x.NE(x.zero())
+}
+
+// LeadingZeros returns the number of leading zero bits in x, like bits.LeadingZeros32.
+func (x Uint32) LeadingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.LeadingZeros32(x.C)}
 	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+	return Uint32{S: bvLeadingZeros(x.S)}.ToInt()
+}
+
+// TrailingZeros returns the number of trailing zero bits in x, like bits.TrailingZeros32.
+func (x Uint32) TrailingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.TrailingZeros32(x.C)}
 	}
-	cache := getCache(ctx)
-	return Uint64{S: x.sym(cache).UDiv(y.sym(cache))}
+	return Uint32{S: bvTrailingZeros(x.S)}.ToInt()
 }
 
-func (x Uint64) Rem(y Uint64) Uint64 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint64{C: x.C % y.C}
+// OnesCount returns the number of one bits in x, like bits.OnesCount32.
+func (x Uint32) OnesCount() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.OnesCount32(x.C)}
 	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+	return Uint32{S: bvOnesCount(x.S)}.ToInt()
+}
+
+// Len returns the minimum number of bits required to represent x, like bits.Len32.
+func (x Uint32) Len() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.Len32(x.C)}
 	}
-	cache := getCache(ctx)
-	return Uint64{S: x.sym(cache).URem(y.sym(cache))}
+	return Int{C: 32}.Sub(x.LeadingZeros())
 }
 
-func (x Uint64) And(y Uint64) Uint64 {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uint64{C: x.C & y.C}
+// Reverse returns x with its bits in reversed order, like bits.Reverse32.
+func (x Uint32) Reverse() Uint32 {
+	if x.IsConcrete() {
+		return Uint32{C: bits.Reverse32(x.C)}
 	}
-	ctx := x.S.Context()
+	return Uint32{S: bvReverse(x.S)}
+}
+
+// ReverseBytes returns x with its bytes in reversed order, like bits.ReverseBytes32.
+func (x Uint32) ReverseBytes() Uint32 {
+	if x.IsConcrete() {
+		return Uint32{C: bits.ReverseBytes32(x.C)}
+	}
+	return Uint32{S: bvReverseBytes(x.S)}
+}
+
+// RotateLeft returns x rotated left by (k mod 32) bits, like
+// bits.RotateLeft32. To rotate right by k bits, pass -k.
+func (x Uint32) RotateLeft(k Int) Uint32 {
+	if x.IsConcrete() && k.IsConcrete() {
+		return Uint32{C: bits.RotateLeft32(x.C, k.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = k.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint32{S: bvRotateLeft(x.sym(cache), k.ToUint32().sym(cache))}
+}
+
+// Add32 returns the sum with carry of x, y, and carry, like
+// bits.Add32. carry must be 0 or 1, and carryOut is 0 or 1.
+func Add32(x, y, carry Uint32) (sum, carryOut Uint32) {
+	if x.IsConcrete() && y.IsConcrete() && carry.IsConcrete() {
+		s, c := bits.Add32(x.C, y.C, carry.C)
+		return Uint32{C: s}, Uint32{C: c}
+	}
+	sum = x.Add(y).Add(carry)
+	carryOut = x.And(y).Or(x.Or(y).AndNot(sum)).Rsh(Uint64{C: 31})
+	return sum, carryOut
+}
+
+// Sub32 returns the difference of x, y, and borrow, like
+// bits.Sub32. borrow must be 0 or 1, and borrowOut is 0 or 1.
+func Sub32(x, y, borrow Uint32) (diff, borrowOut Uint32) {
+	if x.IsConcrete() && y.IsConcrete() && borrow.IsConcrete() {
+		d, b := bits.Sub32(x.C, y.C, borrow.C)
+		return Uint32{C: d}, Uint32{C: b}
+	}
+	diff = x.Sub(y).Sub(borrow)
+	borrowOut = x.Not().And(y).Or(x.Xor(y).Not().And(diff)).Rsh(Uint64{C: 31})
+	return diff, borrowOut
+}
+
+// Mul32 returns the 64-bit product of x and y, like bits.Mul32.
+func Mul32(x, y Uint32) (hi, lo Uint32) {
+	if x.IsConcrete() && y.IsConcrete() {
+		h, l := bits.Mul32(x.C, y.C)
+		return Uint32{C: h}, Uint32{C: l}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	p := x.sym(cache).ZeroExtend(32).Mul(y.sym(cache).ZeroExtend(32))
+	hi, lo = Uint32{S: p.Extract(63, 32)}, Uint32{S: p.Extract(31, 0)}
+	return hi, lo
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Uint32) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToUint32().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Uint32) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToUint32().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Uint32) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToUint32().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Uint32) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToUint32().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Uint32) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToUint32().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Uint32) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToUint32().NE(x)
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Uint32) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToUint32().NE(x)
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Uint32) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToUint32().NE(x)
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Uint32) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToUint32().NE(x)
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Uint32) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToUint32().NE(x)
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Uint32) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToUint32().NE(x)
+	return z, overflow
+}
+
+// Uint64 implements symbolic uint64 values.
+type Uint64 struct {
+	C uint64
+	S z3.BV
+}
+
+// AnyUint64 returns an unconstrained symbolic Uint64.
+func AnyUint64(ctx *z3.Context, name string) Uint64 {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortUint64).(z3.BV)
+	return Uint64{S: sym}
+}
+
+// String returns x as a string.
+func (x Uint64) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Uint64) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Uint64) Eval(m *z3.Model) uint64 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.BV)
+	val, ok, _ := c.AsUint64()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (uint64)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Uint64) sym(c *cache) z3.BV {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.z3.FromInt(int64(x.C), c.sortUint64).(z3.BV)
+}
+
+func (Uint64) zero() Uint64 {
+	return Uint64{}
+}
+
+func (Uint64) sortOf(c *cache) z3.Sort { return c.sortUint64 }
+
+func (x Uint64) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint64) fromSym(v z3.Value) Uint64 { return Uint64{S: v.(z3.BV)} }
+
+func (x Uint64) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Uint64) ite(cond Bool, y Uint64) Uint64 {
+	cache := getCache(cond.S.Context())
+	return Uint64{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Uint64) Min(y Uint64) Uint64 {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Uint64) Max(y Uint64) Uint64 {
+	return Ite(x.GE(y), x, y)
+}
+
+func (x Uint64) Add(y Uint64) Uint64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint64{C: x.C + y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint64{S: x.sym(cache).Add(y.sym(cache))}
+}
+
+func (x Uint64) Sub(y Uint64) Uint64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint64{C: x.C - y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint64{S: x.sym(cache).Sub(y.sym(cache))}
+}
+
+func (x Uint64) Mul(y Uint64) Uint64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint64{C: x.C * y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint64{S: x.sym(cache).Mul(y.sym(cache))}
+}
+
+func (x Uint64) Quo(y Uint64) Uint64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint64{C: x.C / y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uint64{S: x.sym(cache).UDiv(y.sym(cache))}
+}
+
+func (x Uint64) Rem(y Uint64) Uint64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint64{C: x.C % y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uint64{S: x.sym(cache).URem(y.sym(cache))}
+}
+
+func (x Uint64) And(y Uint64) Uint64 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uint64{C: x.C & y.C}
+	}
+	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
@@ -3751,201 +6186,1407 @@ func (x Uint64) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(0)}
 }
 
-// Uintptr implements symbolic uintptr values.
-type Uintptr struct {
-	C uintptr
-	S z3.BV
-}
-
-// AnyUintptr returns an unconstrained symbolic Uintptr.
-func AnyUintptr(ctx *z3.Context, name string) Uintptr {
-	cache := getCache(ctx)
-	sym := cache.z3.FreshConst(name, cache.sortUintptr).(z3.BV)
-	return Uintptr{S: sym}
-}
-
-// String returns x as a string.
-func (x Uintptr) String() string {
+func (x Uint64) ToFloat32() Float32 {
 	if x.IsConcrete() {
-		return fmt.Sprint(x.C)
+		return Float32{C: float32(x.C)}
 	}
-	return x.S.String()
-}
-
-// IsConcrete returns true if x is concrete.
-func (x Uintptr) IsConcrete() bool {
-	return x.S.Context() == nil
+	return Float32{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat32)}
 }
 
-// Eval returns x's concrete value in model m.
-// This also evaluates x with model completion.
-func (x Uintptr) Eval(m *z3.Model) uintptr {
+func (x Uint64) ToFloat64() Float64 {
 	if x.IsConcrete() {
-		return x.C
-	}
-	c := m.Eval(x.S, true).(z3.BV)
-	val, ok, _ := c.AsUint64()
-	if !ok {
-		panic("model evaluation produced non-concrete value " + c.String())
-	}
-	return (uintptr)(val)
-}
-
-// sym returns x's symbolic value, creating it if necessary.
-func (x Uintptr) sym(c *cache) z3.BV {
-	if !x.IsConcrete() {
-		return x.S
+		return Float64{C: float64(x.C)}
 	}
-	return c.z3.FromInt(int64(x.C), c.sortUintptr).(z3.BV)
+	return Float64{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat64)}
 }
 
-func (x Uintptr) Add(y Uintptr) Uintptr {
+// AddChecked returns x + y and whether the result overflowed
+// uint64. If it did, z is the wrapped result that Add returns.
+func (x Uint64) AddChecked(y Uint64) (z Uint64, overflow Bool) {
+	z = x.Add(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C + y.C}
+		return z, Bool{C: z.C < x.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uintptr{S: x.sym(cache).Add(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UAddNoOverflow(ys).Not()}
 }
 
-func (x Uintptr) Sub(y Uintptr) Uintptr {
+// SubChecked returns x - y and whether the result overflowed
+// uint64. If it did, z is the wrapped result that Sub returns.
+func (x Uint64) SubChecked(y Uint64) (z Uint64, overflow Bool) {
+	z = x.Sub(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C - y.C}
+		return z, Bool{C: x.C < y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uintptr{S: x.sym(cache).Sub(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.USubNoUnderflow(ys).Not()}
 }
 
-func (x Uintptr) Mul(y Uintptr) Uintptr {
+// MulChecked returns x * y and whether the result overflowed
+// uint64. If it did, z is the wrapped result that Mul returns.
+func (x Uint64) MulChecked(y Uint64) (z Uint64, overflow Bool) {
+	z = x.Mul(y)
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C * y.C}
+		return z, Bool{C: x.C != 0 && z.C/x.C != y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uintptr{S: x.sym(cache).Mul(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UMulNoOverflow(ys).Not()}
 }
 
-func (x Uintptr) Quo(y Uintptr) Uintptr {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C / y.C}
+// QuoChecked returns x / y and whether the result overflowed
+// uint64. If it did, z is the wrapped result that Quo returns.
+// Unsigned division never overflows.
+func (x Uint64) QuoChecked(y Uint64) (z Uint64, overflow Bool) {
+	z = x.Quo(y)
+	return z, Bool{C: false}
+}
+
+// NegChecked returns -x and whether the result overflowed uint64.
+// The negation of any non-zero unsigned value overflows.
+func (x Uint64) NegChecked() (z Uint64, overflow Bool) {
+	z = x.Neg()
+	return z, x.NE(x.zero())
+}
+
+// LeadingZeros returns the number of leading zero bits in x, like bits.LeadingZeros64.
+func (x Uint64) LeadingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.LeadingZeros64(x.C)}
+	}
+	return Uint64{S: bvLeadingZeros(x.S)}.ToInt()
+}
+
+// TrailingZeros returns the number of trailing zero bits in x, like bits.TrailingZeros64.
+func (x Uint64) TrailingZeros() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.TrailingZeros64(x.C)}
+	}
+	return Uint64{S: bvTrailingZeros(x.S)}.ToInt()
+}
+
+// OnesCount returns the number of one bits in x, like bits.OnesCount64.
+func (x Uint64) OnesCount() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.OnesCount64(x.C)}
+	}
+	return Uint64{S: bvOnesCount(x.S)}.ToInt()
+}
+
+// Len returns the minimum number of bits required to represent x, like bits.Len64.
+func (x Uint64) Len() Int {
+	if x.IsConcrete() {
+		return Int{C: bits.Len64(x.C)}
+	}
+	return Int{C: 64}.Sub(x.LeadingZeros())
+}
+
+// Reverse returns x with its bits in reversed order, like bits.Reverse64.
+func (x Uint64) Reverse() Uint64 {
+	if x.IsConcrete() {
+		return Uint64{C: bits.Reverse64(x.C)}
+	}
+	return Uint64{S: bvReverse(x.S)}
+}
+
+// ReverseBytes returns x with its bytes in reversed order, like bits.ReverseBytes64.
+func (x Uint64) ReverseBytes() Uint64 {
+	if x.IsConcrete() {
+		return Uint64{C: bits.ReverseBytes64(x.C)}
+	}
+	return Uint64{S: bvReverseBytes(x.S)}
+}
+
+// RotateLeft returns x rotated left by (k mod 64) bits, like
+// bits.RotateLeft64. To rotate right by k bits, pass -k.
+func (x Uint64) RotateLeft(k Int) Uint64 {
+	if x.IsConcrete() && k.IsConcrete() {
+		return Uint64{C: bits.RotateLeft64(x.C, k.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = k.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uint64{S: bvRotateLeft(x.sym(cache), k.ToUint64().sym(cache))}
+}
+
+// Add64 returns the sum with carry of x, y, and carry, like
+// bits.Add64. carry must be 0 or 1, and carryOut is 0 or 1.
+func Add64(x, y, carry Uint64) (sum, carryOut Uint64) {
+	if x.IsConcrete() && y.IsConcrete() && carry.IsConcrete() {
+		s, c := bits.Add64(x.C, y.C, carry.C)
+		return Uint64{C: s}, Uint64{C: c}
+	}
+	sum = x.Add(y).Add(carry)
+	carryOut = x.And(y).Or(x.Or(y).AndNot(sum)).Rsh(Uint64{C: 63})
+	return sum, carryOut
+}
+
+// Sub64 returns the difference of x, y, and borrow, like
+// bits.Sub64. borrow must be 0 or 1, and borrowOut is 0 or 1.
+func Sub64(x, y, borrow Uint64) (diff, borrowOut Uint64) {
+	if x.IsConcrete() && y.IsConcrete() && borrow.IsConcrete() {
+		d, b := bits.Sub64(x.C, y.C, borrow.C)
+		return Uint64{C: d}, Uint64{C: b}
+	}
+	diff = x.Sub(y).Sub(borrow)
+	borrowOut = x.Not().And(y).Or(x.Xor(y).Not().And(diff)).Rsh(Uint64{C: 63})
+	return diff, borrowOut
+}
+
+// Mul64 returns the 128-bit product of x and y, like bits.Mul64.
+func Mul64(x, y Uint64) (hi, lo Uint64) {
+	if x.IsConcrete() && y.IsConcrete() {
+		h, l := bits.Mul64(x.C, y.C)
+		return Uint64{C: h}, Uint64{C: l}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	p := x.sym(cache).ZeroExtend(64).Mul(y.sym(cache).ZeroExtend(64))
+	hi, lo = Uint64{S: p.Extract(127, 64)}, Uint64{S: p.Extract(63, 0)}
+	return hi, lo
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Uint64) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToUint64().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Uint64) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToUint64().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Uint64) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToUint64().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Uint64) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToUint64().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Uint64) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToUint64().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Uint64) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToUint64().NE(x)
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Uint64) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToUint64().NE(x)
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Uint64) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToUint64().NE(x)
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Uint64) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToUint64().NE(x)
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Uint64) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToUint64().NE(x)
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Uint64) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToUint64().NE(x)
+	return z, overflow
+}
+
+// Uintptr implements symbolic uintptr values.
+type Uintptr struct {
+	C uintptr
+	S z3.BV
+}
+
+// AnyUintptr returns an unconstrained symbolic Uintptr.
+func AnyUintptr(ctx *z3.Context, name string) Uintptr {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortUintptr).(z3.BV)
+	return Uintptr{S: sym}
+}
+
+// String returns x as a string.
+func (x Uintptr) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Uintptr) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Uintptr) Eval(m *z3.Model) uintptr {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.BV)
+	val, ok, _ := c.AsUint64()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (uintptr)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Uintptr) sym(c *cache) z3.BV {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.z3.FromInt(int64(x.C), c.sortUintptr).(z3.BV)
+}
+
+func (Uintptr) zero() Uintptr {
+	return Uintptr{}
+}
+
+func (Uintptr) sortOf(c *cache) z3.Sort { return c.sortUintptr }
+
+func (x Uintptr) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uintptr) fromSym(v z3.Value) Uintptr { return Uintptr{S: v.(z3.BV)} }
+
+func (x Uintptr) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Uintptr) ite(cond Bool, y Uintptr) Uintptr {
+	cache := getCache(cond.S.Context())
+	return Uintptr{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.BV)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Uintptr) Min(y Uintptr) Uintptr {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Uintptr) Max(y Uintptr) Uintptr {
+	return Ite(x.GE(y), x, y)
+}
+
+func (x Uintptr) Add(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C + y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uintptr{S: x.sym(cache).Add(y.sym(cache))}
+}
+
+func (x Uintptr) Sub(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C - y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uintptr{S: x.sym(cache).Sub(y.sym(cache))}
+}
+
+func (x Uintptr) Mul(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C * y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uintptr{S: x.sym(cache).Mul(y.sym(cache))}
+}
+
+func (x Uintptr) Quo(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C / y.C}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
 	return Uintptr{S: x.sym(cache).UDiv(y.sym(cache))}
 }
 
-func (x Uintptr) Rem(y Uintptr) Uintptr {
+func (x Uintptr) Rem(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C % y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "integer divide by zero")
+	return Uintptr{S: x.sym(cache).URem(y.sym(cache))}
+}
+
+func (x Uintptr) And(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C & y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uintptr{S: x.sym(cache).And(y.sym(cache))}
+}
+
+func (x Uintptr) Or(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C | y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uintptr{S: x.sym(cache).Or(y.sym(cache))}
+}
+
+func (x Uintptr) Xor(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C ^ y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uintptr{S: x.sym(cache).Xor(y.sym(cache))}
+}
+
+func (x Uintptr) Lsh(y Uint64) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C << y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	var rs z3.BV
+	if y.IsConcrete() {
+		if y.C >= 64 {
+			return Uintptr{C: 0}
+		}
+	}
+	rs = y.sym(cache)
+	return Uintptr{S: x.sym(cache).Lsh(rs)}
+}
+
+func (x Uintptr) Rsh(y Uint64) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C >> y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	var rs z3.BV
+	if y.IsConcrete() {
+		if y.C >= 64 {
+			return Uintptr{C: 0}
+		}
+	}
+	rs = y.sym(cache)
+	return Uintptr{S: x.sym(cache).URsh(rs)}
+}
+
+func (x Uintptr) AndNot(y Uintptr) Uintptr {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Uintptr{C: x.C &^ y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Uintptr{S: x.sym(cache).And(y.Not().sym(cache))}
+}
+
+func (x Uintptr) Eq(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C == y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).Eq(y.sym(cache))}
+}
+
+func (x Uintptr) NE(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C != y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).NE(y.sym(cache))}
+}
+
+func (x Uintptr) LT(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C < y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).ULT(y.sym(cache))}
+}
+
+func (x Uintptr) LE(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C <= y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).ULE(y.sym(cache))}
+}
+
+func (x Uintptr) GT(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C > y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).UGT(y.sym(cache))}
+}
+
+func (x Uintptr) GE(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C >= y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).UGE(y.sym(cache))}
+}
+
+func (x Uintptr) Neg() Uintptr {
+	if x.IsConcrete() {
+		return Uintptr{C: -x.C}
+	}
+	return Uintptr{S: x.S.Neg()}
+}
+
+func (x Uintptr) Not() Uintptr {
+	if x.IsConcrete() {
+		return Uintptr{C: ^x.C}
+	}
+	return Uintptr{S: x.S.Not()}
+}
+
+func (x Uintptr) ToInt() Int {
+	if x.IsConcrete() {
+		return Int{C: int(x.C)}
+	}
+	return Int{S: x.S.ZeroExtend(0)}
+}
+
+func (x Uintptr) ToInt8() Int8 {
+	if x.IsConcrete() {
+		return Int8{C: int8(x.C)}
+	}
+	return Int8{S: x.S.Extract(7, 0)}
+}
+
+func (x Uintptr) ToInt16() Int16 {
+	if x.IsConcrete() {
+		return Int16{C: int16(x.C)}
+	}
+	return Int16{S: x.S.Extract(15, 0)}
+}
+
+func (x Uintptr) ToInt32() Int32 {
+	if x.IsConcrete() {
+		return Int32{C: int32(x.C)}
+	}
+	return Int32{S: x.S.Extract(31, 0)}
+}
+
+func (x Uintptr) ToInt64() Int64 {
+	if x.IsConcrete() {
+		return Int64{C: int64(x.C)}
+	}
+	return Int64{S: x.S.ZeroExtend(0)}
+}
+
+func (x Uintptr) ToUint() Uint {
+	if x.IsConcrete() {
+		return Uint{C: uint(x.C)}
+	}
+	return Uint{S: x.S.ZeroExtend(0)}
+}
+
+func (x Uintptr) ToUint8() Uint8 {
+	if x.IsConcrete() {
+		return Uint8{C: uint8(x.C)}
+	}
+	return Uint8{S: x.S.Extract(7, 0)}
+}
+
+func (x Uintptr) ToUint16() Uint16 {
+	if x.IsConcrete() {
+		return Uint16{C: uint16(x.C)}
+	}
+	return Uint16{S: x.S.Extract(15, 0)}
+}
+
+func (x Uintptr) ToUint32() Uint32 {
+	if x.IsConcrete() {
+		return Uint32{C: uint32(x.C)}
+	}
+	return Uint32{S: x.S.Extract(31, 0)}
+}
+
+func (x Uintptr) ToUint64() Uint64 {
+	if x.IsConcrete() {
+		return Uint64{C: uint64(x.C)}
+	}
+	return Uint64{S: x.S.ZeroExtend(0)}
+}
+
+func (x Uintptr) ToUintptr() Uintptr {
+	if x.IsConcrete() {
+		return Uintptr{C: uintptr(x.C)}
+	}
+	return Uintptr{S: x.S.ZeroExtend(0)}
+}
+
+func (x Uintptr) ToFloat32() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: float32(x.C)}
+	}
+	return Float32{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat32)}
+}
+
+func (x Uintptr) ToFloat64() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: float64(x.C)}
+	}
+	return Float64{S: x.S.UToFloat(getCache(x.S.Context()).sortFloat64)}
+}
+
+// AddChecked returns x + y and whether the result overflowed
+// uintptr. If it did, z is the wrapped result that Add returns.
+func (x Uintptr) AddChecked(y Uintptr) (z Uintptr, overflow Bool) {
+	z = x.Add(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: z.C < x.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UAddNoOverflow(ys).Not()}
+}
+
+// SubChecked returns x - y and whether the result overflowed
+// uintptr. If it did, z is the wrapped result that Sub returns.
+func (x Uintptr) SubChecked(y Uintptr) (z Uintptr, overflow Bool) {
+	z = x.Sub(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: x.C < y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.USubNoUnderflow(ys).Not()}
+}
+
+// MulChecked returns x * y and whether the result overflowed
+// uintptr. If it did, z is the wrapped result that Mul returns.
+func (x Uintptr) MulChecked(y Uintptr) (z Uintptr, overflow Bool) {
+	z = x.Mul(y)
+	if x.IsConcrete() && y.IsConcrete() {
+		return z, Bool{C: x.C != 0 && z.C/x.C != y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return z, Bool{S: xs.UMulNoOverflow(ys).Not()}
+}
+
+// QuoChecked returns x / y and whether the result overflowed
+// uintptr. If it did, z is the wrapped result that Quo returns.
+// Unsigned division never overflows.
+func (x Uintptr) QuoChecked(y Uintptr) (z Uintptr, overflow Bool) {
+	z = x.Quo(y)
+	return z, Bool{C: false}
+}
+
+// NegChecked returns -x and whether the result overflowed uintptr.
+// The negation of any non-zero unsigned value overflows.
+func (x Uintptr) NegChecked() (z Uintptr, overflow Bool) {
+	z = x.Neg()
+	return z, x.NE(x.zero())
+}
+
+// AddUintptr returns the sum with carry of x, y, and carry, like
+// bits.Add, but for uintptr. carry must be 0 or 1, and carryOut is 0 or 1.
+func AddUintptr(x, y, carry Uintptr) (sum, carryOut Uintptr) {
+	if x.IsConcrete() && y.IsConcrete() && carry.IsConcrete() {
+		s, c := bits.Add(uint(x.C), uint(y.C), uint(carry.C))
+		return Uintptr{C: uintptr(s)}, Uintptr{C: uintptr(c)}
+	}
+	sum = x.Add(y).Add(carry)
+	carryOut = x.And(y).Or(x.Or(y).AndNot(sum)).Rsh(Uint64{C: 63})
+	return sum, carryOut
+}
+
+// SubUintptr returns the difference of x, y, and borrow, like
+// bits.Sub, but for uintptr. borrow must be 0 or 1, and borrowOut is 0 or 1.
+func SubUintptr(x, y, borrow Uintptr) (diff, borrowOut Uintptr) {
+	if x.IsConcrete() && y.IsConcrete() && borrow.IsConcrete() {
+		d, b := bits.Sub(uint(x.C), uint(y.C), uint(borrow.C))
+		return Uintptr{C: uintptr(d)}, Uintptr{C: uintptr(b)}
+	}
+	diff = x.Sub(y).Sub(borrow)
+	borrowOut = x.Not().And(y).Or(x.Xor(y).Not().And(diff)).Rsh(Uint64{C: 63})
+	return diff, borrowOut
+}
+
+// MulUintptr returns the 128-bit product of x and y, like bits.Mul, but for uintptr.
+func MulUintptr(x, y Uintptr) (hi, lo Uintptr) {
+	if x.IsConcrete() && y.IsConcrete() {
+		h, l := bits.Mul(uint(x.C), uint(y.C))
+		return Uintptr{C: uintptr(h)}, Uintptr{C: uintptr(l)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	p := x.sym(cache).ZeroExtend(64).Mul(y.sym(cache).ZeroExtend(64))
+	hi, lo = Uintptr{S: p.Extract(127, 64)}, Uintptr{S: p.Extract(63, 0)}
+	return hi, lo
+}
+
+// ToIntChecked returns int(x) and whether x is out of the range
+// of int. If it is, z is the truncated or wrapped result that
+// ToInt returns.
+func (x Uintptr) ToIntChecked() (z Int, overflow Bool) {
+	z = x.ToInt()
+	overflow = z.ToUintptr().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt8Checked returns int8(x) and whether x is out of the range
+// of int8. If it is, z is the truncated or wrapped result that
+// ToInt8 returns.
+func (x Uintptr) ToInt8Checked() (z Int8, overflow Bool) {
+	z = x.ToInt8()
+	overflow = z.ToUintptr().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt16Checked returns int16(x) and whether x is out of the range
+// of int16. If it is, z is the truncated or wrapped result that
+// ToInt16 returns.
+func (x Uintptr) ToInt16Checked() (z Int16, overflow Bool) {
+	z = x.ToInt16()
+	overflow = z.ToUintptr().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt32Checked returns int32(x) and whether x is out of the range
+// of int32. If it is, z is the truncated or wrapped result that
+// ToInt32 returns.
+func (x Uintptr) ToInt32Checked() (z Int32, overflow Bool) {
+	z = x.ToInt32()
+	overflow = z.ToUintptr().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToInt64Checked returns int64(x) and whether x is out of the range
+// of int64. If it is, z is the truncated or wrapped result that
+// ToInt64 returns.
+func (x Uintptr) ToInt64Checked() (z Int64, overflow Bool) {
+	z = x.ToInt64()
+	overflow = z.ToUintptr().NE(x)
+	overflow = overflow.Or(z.LT(z.zero()))
+	return z, overflow
+}
+
+// ToUintChecked returns uint(x) and whether x is out of the range
+// of uint. If it is, z is the truncated or wrapped result that
+// ToUint returns.
+func (x Uintptr) ToUintChecked() (z Uint, overflow Bool) {
+	z = x.ToUint()
+	overflow = z.ToUintptr().NE(x)
+	return z, overflow
+}
+
+// ToUint8Checked returns uint8(x) and whether x is out of the range
+// of uint8. If it is, z is the truncated or wrapped result that
+// ToUint8 returns.
+func (x Uintptr) ToUint8Checked() (z Uint8, overflow Bool) {
+	z = x.ToUint8()
+	overflow = z.ToUintptr().NE(x)
+	return z, overflow
+}
+
+// ToUint16Checked returns uint16(x) and whether x is out of the range
+// of uint16. If it is, z is the truncated or wrapped result that
+// ToUint16 returns.
+func (x Uintptr) ToUint16Checked() (z Uint16, overflow Bool) {
+	z = x.ToUint16()
+	overflow = z.ToUintptr().NE(x)
+	return z, overflow
+}
+
+// ToUint32Checked returns uint32(x) and whether x is out of the range
+// of uint32. If it is, z is the truncated or wrapped result that
+// ToUint32 returns.
+func (x Uintptr) ToUint32Checked() (z Uint32, overflow Bool) {
+	z = x.ToUint32()
+	overflow = z.ToUintptr().NE(x)
+	return z, overflow
+}
+
+// ToUint64Checked returns uint64(x) and whether x is out of the range
+// of uint64. If it is, z is the truncated or wrapped result that
+// ToUint64 returns.
+func (x Uintptr) ToUint64Checked() (z Uint64, overflow Bool) {
+	z = x.ToUint64()
+	overflow = z.ToUintptr().NE(x)
+	return z, overflow
+}
+
+// ToUintptrChecked returns uintptr(x) and whether x is out of the range
+// of uintptr. If it is, z is the truncated or wrapped result that
+// ToUintptr returns.
+func (x Uintptr) ToUintptrChecked() (z Uintptr, overflow Bool) {
+	z = x.ToUintptr()
+	overflow = z.ToUintptr().NE(x)
+	return z, overflow
+}
+
+// Float32 implements symbolic float32 values.
+type Float32 struct {
+	C float32
+	S z3.Float
+}
+
+// AnyFloat32 returns an unconstrained symbolic Float32.
+func AnyFloat32(ctx *z3.Context, name string) Float32 {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortFloat32).(z3.Float)
+	return Float32{S: sym}
+}
+
+// String returns x as a string.
+func (x Float32) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Float32) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Float32) Eval(m *z3.Model) float32 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.Float)
+	val, ok, _ := c.AsFloat32()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (float32)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Float32) sym(c *cache) z3.Float {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.z3.FromFloat32(x.C, c.sortFloat32)
+}
+
+func (Float32) zero() Float32 {
+	return Float32{}
+}
+
+func (Float32) sortOf(c *cache) z3.Sort { return c.sortFloat32 }
+
+func (x Float32) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Float32) fromSym(v z3.Value) Float32 { return Float32{S: v.(z3.Float)} }
+
+func (x Float32) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Float32) ite(cond Bool, y Float32) Float32 {
+	cache := getCache(cond.S.Context())
+	return Float32{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.Float)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+// If either is NaN, the result is NaN.
+func (x Float32) Min(y Float32) Float32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		if x.C != x.C {
+			return x
+		}
+		if y.C != y.C {
+			return y
+		}
+		return Float32{C: float32(math.Min(float64(x.C), float64(y.C)))}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	pickX := xs.LT(ys).Or(xs.IsZero().And(ys.IsZero(), xs.IsNegative()))
+	res := pickX.IfThenElse(xs, ys)
+	res = ys.IsNaN().IfThenElse(ys, res)
+	return Float32{S: xs.IsNaN().IfThenElse(xs, res).(z3.Float)}
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+// If either is NaN, the result is NaN.
+func (x Float32) Max(y Float32) Float32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		if x.C != x.C {
+			return x
+		}
+		if y.C != y.C {
+			return y
+		}
+		return Float32{C: float32(math.Max(float64(x.C), float64(y.C)))}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	pickX := xs.GT(ys).Or(xs.IsZero().And(ys.IsZero(), ys.IsNegative()))
+	res := pickX.IfThenElse(xs, ys)
+	res = ys.IsNaN().IfThenElse(ys, res)
+	return Float32{S: xs.IsNaN().IfThenElse(xs, res).(z3.Float)}
+}
+
+// Abs returns the absolute value of x.
+// Like math.Abs, this clears the sign bit of x.
+func (x Float32) Abs() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: float32(math.Abs(float64(x.C)))}
+	}
+	return Float32{S: x.S.Abs()}
+}
+
+func (x Float32) Add(y Float32) Float32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Float32{C: float32(x.C + y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Float32{S: x.sym(cache).Add(y.sym(cache))}
+}
+
+func (x Float32) Sub(y Float32) Float32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Float32{C: float32(x.C - y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Float32{S: x.sym(cache).Sub(y.sym(cache))}
+}
+
+func (x Float32) Mul(y Float32) Float32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Float32{C: float32(x.C * y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Float32{S: x.sym(cache).Mul(y.sym(cache))}
+}
+
+func (x Float32) Quo(y Float32) Float32 {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Float32{C: float32(x.C / y.C)}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Float32{S: x.sym(cache).Div(y.sym(cache))}
+}
+
+func (x Float32) Eq(y Float32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C == y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).IEEEEq(y.sym(cache))}
+}
+
+func (x Float32) NE(y Float32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C != y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).IEEEEq(y.sym(cache)).Not()}
+}
+
+func (x Float32) LT(y Float32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C < y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).LT(y.sym(cache))}
+}
+
+func (x Float32) LE(y Float32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C <= y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).LE(y.sym(cache))}
+}
+
+func (x Float32) GT(y Float32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C > y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).GT(y.sym(cache))}
+}
+
+func (x Float32) GE(y Float32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C >= y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).GE(y.sym(cache))}
+}
+
+func (x Float32) Neg() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: -x.C}
+	}
+	return Float32{S: x.S.Neg()}
+}
+
+func (x Float32) ToInt() Int {
+	if x.IsConcrete() {
+		return Int{C: int(x.C)}
+	}
+	return Int{S: x.S.Round(z3.RoundToZero).ToSBV(64)}
+}
+
+func (x Float32) ToInt8() Int8 {
+	if x.IsConcrete() {
+		return Int8{C: int8(x.C)}
+	}
+	return Int8{S: x.S.Round(z3.RoundToZero).ToSBV(8)}
+}
+
+func (x Float32) ToInt16() Int16 {
+	if x.IsConcrete() {
+		return Int16{C: int16(x.C)}
+	}
+	return Int16{S: x.S.Round(z3.RoundToZero).ToSBV(16)}
+}
+
+func (x Float32) ToInt32() Int32 {
+	if x.IsConcrete() {
+		return Int32{C: int32(x.C)}
+	}
+	return Int32{S: x.S.Round(z3.RoundToZero).ToSBV(32)}
+}
+
+func (x Float32) ToInt64() Int64 {
+	if x.IsConcrete() {
+		return Int64{C: int64(x.C)}
+	}
+	return Int64{S: x.S.Round(z3.RoundToZero).ToSBV(64)}
+}
+
+func (x Float32) ToUint() Uint {
+	if x.IsConcrete() {
+		return Uint{C: uint(x.C)}
+	}
+	return Uint{S: x.S.Round(z3.RoundToZero).ToUBV(64)}
+}
+
+func (x Float32) ToUint8() Uint8 {
+	if x.IsConcrete() {
+		return Uint8{C: uint8(x.C)}
+	}
+	return Uint8{S: x.S.Round(z3.RoundToZero).ToUBV(8)}
+}
+
+func (x Float32) ToUint16() Uint16 {
+	if x.IsConcrete() {
+		return Uint16{C: uint16(x.C)}
+	}
+	return Uint16{S: x.S.Round(z3.RoundToZero).ToUBV(16)}
+}
+
+func (x Float32) ToUint32() Uint32 {
+	if x.IsConcrete() {
+		return Uint32{C: uint32(x.C)}
+	}
+	return Uint32{S: x.S.Round(z3.RoundToZero).ToUBV(32)}
+}
+
+func (x Float32) ToUint64() Uint64 {
+	if x.IsConcrete() {
+		return Uint64{C: uint64(x.C)}
+	}
+	return Uint64{S: x.S.Round(z3.RoundToZero).ToUBV(64)}
+}
+
+func (x Float32) ToUintptr() Uintptr {
+	if x.IsConcrete() {
+		return Uintptr{C: uintptr(x.C)}
+	}
+	return Uintptr{S: x.S.Round(z3.RoundToZero).ToUBV(64)}
+}
+
+func (x Float32) ToFloat32() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: float32(x.C)}
+	}
+	return Float32{S: x.S}
+}
+
+func (x Float32) ToFloat64() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: float64(x.C)}
+	}
+	return Float64{S: x.S.ToFloat(getCache(x.S.Context()).sortFloat64)}
+}
+
+// Float64 implements symbolic float64 values.
+type Float64 struct {
+	C float64
+	S z3.Float
+}
+
+// AnyFloat64 returns an unconstrained symbolic Float64.
+func AnyFloat64(ctx *z3.Context, name string) Float64 {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortFloat64).(z3.Float)
+	return Float64{S: sym}
+}
+
+// String returns x as a string.
+func (x Float64) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x Float64) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x Float64) Eval(m *z3.Model) float64 {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.Float)
+	val, ok, _ := c.AsFloat64()
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (float64)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x Float64) sym(c *cache) z3.Float {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.z3.FromFloat64(x.C, c.sortFloat64)
+}
+
+func (Float64) zero() Float64 {
+	return Float64{}
+}
+
+func (Float64) sortOf(c *cache) z3.Sort { return c.sortFloat64 }
+
+func (x Float64) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Float64) fromSym(v z3.Value) Float64 { return Float64{S: v.(z3.Float)} }
+
+func (x Float64) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Float64) ite(cond Bool, y Float64) Float64 {
+	cache := getCache(cond.S.Context())
+	return Float64{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.Float)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+// If either is NaN, the result is NaN.
+func (x Float64) Min(y Float64) Float64 {
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C % y.C}
+		if x.C != x.C {
+			return x
+		}
+		if y.C != y.C {
+			return y
+		}
+		return Float64{C: float64(math.Min(float64(x.C), float64(y.C)))}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uintptr{S: x.sym(cache).URem(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	pickX := xs.LT(ys).Or(xs.IsZero().And(ys.IsZero(), xs.IsNegative()))
+	res := pickX.IfThenElse(xs, ys)
+	res = ys.IsNaN().IfThenElse(ys, res)
+	return Float64{S: xs.IsNaN().IfThenElse(xs, res).(z3.Float)}
 }
 
-func (x Uintptr) And(y Uintptr) Uintptr {
+// Max returns the larger of x and y, like Go's built-in max.
+// If either is NaN, the result is NaN.
+func (x Float64) Max(y Float64) Float64 {
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C & y.C}
+		if x.C != x.C {
+			return x
+		}
+		if y.C != y.C {
+			return y
+		}
+		return Float64{C: float64(math.Max(float64(x.C), float64(y.C)))}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uintptr{S: x.sym(cache).And(y.sym(cache))}
+	xs, ys := x.sym(cache), y.sym(cache)
+	pickX := xs.GT(ys).Or(xs.IsZero().And(ys.IsZero(), ys.IsNegative()))
+	res := pickX.IfThenElse(xs, ys)
+	res = ys.IsNaN().IfThenElse(ys, res)
+	return Float64{S: xs.IsNaN().IfThenElse(xs, res).(z3.Float)}
 }
 
-func (x Uintptr) Or(y Uintptr) Uintptr {
-	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C | y.C}
-	}
-	ctx := x.S.Context()
-	if ctx == nil {
-		ctx = y.S.Context()
+// Abs returns the absolute value of x.
+// Like math.Abs, this clears the sign bit of x.
+func (x Float64) Abs() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: float64(math.Abs(float64(x.C)))}
 	}
-	cache := getCache(ctx)
-	return Uintptr{S: x.sym(cache).Or(y.sym(cache))}
+	return Float64{S: x.S.Abs()}
 }
 
-func (x Uintptr) Xor(y Uintptr) Uintptr {
+func (x Float64) Add(y Float64) Float64 {
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C ^ y.C}
+		return Float64{C: float64(x.C + y.C)}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uintptr{S: x.sym(cache).Xor(y.sym(cache))}
+	return Float64{S: x.sym(cache).Add(y.sym(cache))}
 }
 
-func (x Uintptr) Lsh(y Uint64) Uintptr {
+func (x Float64) Sub(y Float64) Float64 {
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C << y.C}
+		return Float64{C: float64(x.C - y.C)}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	var rs z3.BV
-	if y.IsConcrete() {
-		if y.C >= 64 {
-			return Uintptr{C: 0}
-		}
-	}
-	rs = y.sym(cache)
-	return Uintptr{S: x.sym(cache).Lsh(rs)}
+	return Float64{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
-func (x Uintptr) Rsh(y Uint64) Uintptr {
+func (x Float64) Mul(y Float64) Float64 {
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C >> y.C}
+		return Float64{C: float64(x.C * y.C)}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	var rs z3.BV
-	if y.IsConcrete() {
-		if y.C >= 64 {
-			return Uintptr{C: 0}
-		}
-	}
-	rs = y.sym(cache)
-	return Uintptr{S: x.sym(cache).URsh(rs)}
+	return Float64{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
-func (x Uintptr) AndNot(y Uintptr) Uintptr {
+func (x Float64) Quo(y Float64) Float64 {
 	if x.IsConcrete() && y.IsConcrete() {
-		return Uintptr{C: x.C &^ y.C}
+		return Float64{C: float64(x.C / y.C)}
 	}
 	ctx := x.S.Context()
 	if ctx == nil {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Uintptr{S: x.sym(cache).And(y.Not().sym(cache))}
+	return Float64{S: x.sym(cache).Div(y.sym(cache))}
 }
 
-func (x Uintptr) Eq(y Uintptr) Bool {
+func (x Float64) Eq(y Float64) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C == y.C}
 	}
@@ -3954,10 +7595,10 @@ func (x Uintptr) Eq(y Uintptr) Bool {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Bool{S: x.sym(cache).Eq(y.sym(cache))}
+	return Bool{S: x.sym(cache).IEEEEq(y.sym(cache))}
 }
 
-func (x Uintptr) NE(y Uintptr) Bool {
+func (x Float64) NE(y Float64) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C != y.C}
 	}
@@ -3966,10 +7607,10 @@ func (x Uintptr) NE(y Uintptr) Bool {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Bool{S: x.sym(cache).NE(y.sym(cache))}
+	return Bool{S: x.sym(cache).IEEEEq(y.sym(cache)).Not()}
 }
 
-func (x Uintptr) LT(y Uintptr) Bool {
+func (x Float64) LT(y Float64) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C < y.C}
 	}
@@ -3978,10 +7619,10 @@ func (x Uintptr) LT(y Uintptr) Bool {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Bool{S: x.sym(cache).ULT(y.sym(cache))}
+	return Bool{S: x.sym(cache).LT(y.sym(cache))}
 }
 
-func (x Uintptr) LE(y Uintptr) Bool {
+func (x Float64) LE(y Float64) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C <= y.C}
 	}
@@ -3990,10 +7631,10 @@ func (x Uintptr) LE(y Uintptr) Bool {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Bool{S: x.sym(cache).ULE(y.sym(cache))}
+	return Bool{S: x.sym(cache).LE(y.sym(cache))}
 }
 
-func (x Uintptr) GT(y Uintptr) Bool {
+func (x Float64) GT(y Float64) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C > y.C}
 	}
@@ -4002,10 +7643,10 @@ func (x Uintptr) GT(y Uintptr) Bool {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Bool{S: x.sym(cache).UGT(y.sym(cache))}
+	return Bool{S: x.sym(cache).GT(y.sym(cache))}
 }
 
-func (x Uintptr) GE(y Uintptr) Bool {
+func (x Float64) GE(y Float64) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C >= y.C}
 	}
@@ -4014,98 +7655,264 @@ func (x Uintptr) GE(y Uintptr) Bool {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
-	return Bool{S: x.sym(cache).UGE(y.sym(cache))}
-}
-
-func (x Uintptr) Neg() Uintptr {
-	if x.IsConcrete() {
-		return Uintptr{C: -x.C}
-	}
-	return Uintptr{S: x.S.Neg()}
+	return Bool{S: x.sym(cache).GE(y.sym(cache))}
 }
 
-func (x Uintptr) Not() Uintptr {
+func (x Float64) Neg() Float64 {
 	if x.IsConcrete() {
-		return Uintptr{C: ^x.C}
+		return Float64{C: -x.C}
 	}
-	return Uintptr{S: x.S.Not()}
+	return Float64{S: x.S.Neg()}
 }
 
-func (x Uintptr) ToInt() Int {
+func (x Float64) ToInt() Int {
 	if x.IsConcrete() {
 		return Int{C: int(x.C)}
 	}
-	return Int{S: x.S.ZeroExtend(0)}
+	return Int{S: x.S.Round(z3.RoundToZero).ToSBV(64)}
 }
 
-func (x Uintptr) ToInt8() Int8 {
+func (x Float64) ToInt8() Int8 {
 	if x.IsConcrete() {
 		return Int8{C: int8(x.C)}
 	}
-	return Int8{S: x.S.Extract(7, 0)}
+	return Int8{S: x.S.Round(z3.RoundToZero).ToSBV(8)}
 }
 
-func (x Uintptr) ToInt16() Int16 {
+func (x Float64) ToInt16() Int16 {
 	if x.IsConcrete() {
 		return Int16{C: int16(x.C)}
 	}
-	return Int16{S: x.S.Extract(15, 0)}
+	return Int16{S: x.S.Round(z3.RoundToZero).ToSBV(16)}
 }
 
-func (x Uintptr) ToInt32() Int32 {
+func (x Float64) ToInt32() Int32 {
 	if x.IsConcrete() {
 		return Int32{C: int32(x.C)}
 	}
-	return Int32{S: x.S.Extract(31, 0)}
+	return Int32{S: x.S.Round(z3.RoundToZero).ToSBV(32)}
 }
 
-func (x Uintptr) ToInt64() Int64 {
+func (x Float64) ToInt64() Int64 {
 	if x.IsConcrete() {
 		return Int64{C: int64(x.C)}
 	}
-	return Int64{S: x.S.ZeroExtend(0)}
+	return Int64{S: x.S.Round(z3.RoundToZero).ToSBV(64)}
 }
 
-func (x Uintptr) ToUint() Uint {
+func (x Float64) ToUint() Uint {
 	if x.IsConcrete() {
 		return Uint{C: uint(x.C)}
 	}
-	return Uint{S: x.S.ZeroExtend(0)}
+	return Uint{S: x.S.Round(z3.RoundToZero).ToUBV(64)}
 }
 
-func (x Uintptr) ToUint8() Uint8 {
+func (x Float64) ToUint8() Uint8 {
 	if x.IsConcrete() {
 		return Uint8{C: uint8(x.C)}
 	}
-	return Uint8{S: x.S.Extract(7, 0)}
+	return Uint8{S: x.S.Round(z3.RoundToZero).ToUBV(8)}
 }
 
-func (x Uintptr) ToUint16() Uint16 {
+func (x Float64) ToUint16() Uint16 {
 	if x.IsConcrete() {
 		return Uint16{C: uint16(x.C)}
 	}
-	return Uint16{S: x.S.Extract(15, 0)}
+	return Uint16{S: x.S.Round(z3.RoundToZero).ToUBV(16)}
 }
 
-func (x Uintptr) ToUint32() Uint32 {
+func (x Float64) ToUint32() Uint32 {
 	if x.IsConcrete() {
 		return Uint32{C: uint32(x.C)}
 	}
-	return Uint32{S: x.S.Extract(31, 0)}
+	return Uint32{S: x.S.Round(z3.RoundToZero).ToUBV(32)}
 }
 
-func (x Uintptr) ToUint64() Uint64 {
+func (x Float64) ToUint64() Uint64 {
 	if x.IsConcrete() {
 		return Uint64{C: uint64(x.C)}
 	}
-	return Uint64{S: x.S.ZeroExtend(0)}
+	return Uint64{S: x.S.Round(z3.RoundToZero).ToUBV(64)}
 }
 
-func (x Uintptr) ToUintptr() Uintptr {
+func (x Float64) ToUintptr() Uintptr {
 	if x.IsConcrete() {
 		return Uintptr{C: uintptr(x.C)}
 	}
-	return Uintptr{S: x.S.ZeroExtend(0)}
+	return Uintptr{S: x.S.Round(z3.RoundToZero).ToUBV(64)}
+}
+
+func (x Float64) ToFloat32() Float32 {
+	if x.IsConcrete() {
+		return Float32{C: float32(x.C)}
+	}
+	return Float32{S: x.S.ToFloat(getCache(x.S.Context()).sortFloat32)}
+}
+
+func (x Float64) ToFloat64() Float64 {
+	if x.IsConcrete() {
+		return Float64{C: float64(x.C)}
+	}
+	return Float64{S: x.S}
+}
+
+// String implements symbolic string values.
+type String struct {
+	C string
+	S z3.Seq
+}
+
+// AnyString returns an unconstrained symbolic String.
+func AnyString(ctx *z3.Context, name string) String {
+	cache := getCache(ctx)
+	sym := cache.z3.FreshConst(name, cache.sortString).(z3.Seq)
+	return String{S: sym}
+}
+
+// String returns x as a string.
+func (x String) String() string {
+	if x.IsConcrete() {
+		return fmt.Sprint(x.C)
+	}
+	return x.S.String()
+}
+
+// IsConcrete returns true if x is concrete.
+func (x String) IsConcrete() bool {
+	return x.S.Context() == nil
+}
+
+// Eval returns x's concrete value in model m.
+// This also evaluates x with model completion.
+func (x String) Eval(m *z3.Model) string {
+	if x.IsConcrete() {
+		return x.C
+	}
+	c := m.Eval(x.S, true).(z3.Seq)
+	val, ok := evalString(m, c)
+	if !ok {
+		panic("model evaluation produced non-concrete value " + c.String())
+	}
+	return (string)(val)
+}
+
+// sym returns x's symbolic value, creating it if necessary.
+func (x String) sym(c *cache) z3.Seq {
+	if !x.IsConcrete() {
+		return x.S
+	}
+	return c.fromString(x.C)
+}
+
+func (String) zero() String {
+	return String{}
+}
+
+func (String) sortOf(c *cache) z3.Sort { return c.sortString }
+
+func (x String) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (String) fromSym(v z3.Value) String { return String{S: v.(z3.Seq)} }
+
+func (x String) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x String) ite(cond Bool, y String) String {
+	cache := getCache(cond.S.Context())
+	return String{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.Seq)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x String) Min(y String) String {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x String) Max(y String) String {
+	return Ite(x.GE(y), x, y)
+}
+
+func (x String) Add(y String) String {
+	if x.IsConcrete() && y.IsConcrete() {
+		return String{C: x.C + y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return String{S: x.sym(cache).Concat(y.sym(cache))}
+}
+
+func (x String) Eq(y String) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C == y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).Eq(y.sym(cache))}
+}
+
+func (x String) NE(y String) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C != y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: x.sym(cache).NE(y.sym(cache))}
+}
+
+func (x String) LT(y String) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C < y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: cache.stringLT(x.sym(cache), y.sym(cache))}
+}
+
+func (x String) LE(y String) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C <= y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: cache.stringLE(x.sym(cache), y.sym(cache))}
+}
+
+func (x String) GT(y String) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C > y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: cache.stringGT(x.sym(cache), y.sym(cache))}
+}
+
+func (x String) GE(y String) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		return Bool{C: x.C >= y.C}
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	return Bool{S: cache.stringGE(x.sym(cache), y.sym(cache))}
 }
 
 // Integer implements symbolic *big.Int values.
@@ -4156,6 +7963,38 @@ func (x Integer) sym(c *cache) z3.Int {
 	return c.z3.FromBigInt(x.C, c.sortInteger).(z3.Int)
 }
 
+func (Integer) zero() Integer {
+	return Integer{C: new(big.Int)}
+}
+
+func (Integer) sortOf(c *cache) z3.Sort { return c.sortInteger }
+
+func (x Integer) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Integer) fromSym(v z3.Value) Integer { return Integer{S: v.(z3.Int)} }
+
+func (x Integer) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Integer) ite(cond Bool, y Integer) Integer {
+	cache := getCache(cond.S.Context())
+	return Integer{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.Int)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Integer) Min(y Integer) Integer {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Integer) Max(y Integer) Integer {
+	return Ite(x.GE(y), x, y)
+}
+
+// Abs returns the absolute value of x.
+func (x Integer) Abs() Integer {
+	return Ite(x.LT(x.zero()), x.Neg(), x)
+}
+
 func (x Integer) Add(y Integer) Integer {
 	if x.IsConcrete() && y.IsConcrete() {
 		z := Integer{C: new(big.Int)}
@@ -4209,6 +8048,7 @@ func (x Integer) Quo(y Integer) Integer {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "division by zero")
 	xs, ys := x.sym(cache), y.sym(cache)
 	zero := cache.z3.FromInt(0, cache.sortInteger).(z3.Int)
 	one := cache.z3.FromInt(1, cache.sortInteger).(z3.Int)
@@ -4226,6 +8066,7 @@ func (x Integer) Rem(y Integer) Integer {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "division by zero")
 	xs, ys := x.sym(cache), y.sym(cache)
 	zero := cache.z3.FromInt(0, cache.sortInteger).(z3.Int)
 	one := cache.z3.FromInt(1, cache.sortInteger).(z3.Int)
@@ -4364,6 +8205,38 @@ func (x Real) sym(c *cache) z3.Real {
 	return c.z3.FromBigRat(x.C)
 }
 
+func (Real) zero() Real {
+	return Real{C: new(big.Rat)}
+}
+
+func (Real) sortOf(c *cache) z3.Sort { return c.sortReal }
+
+func (x Real) symValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Real) fromSym(v z3.Value) Real { return Real{S: v.(z3.Real)} }
+
+func (x Real) evalValue(m *z3.Model) reflect.Value { return reflect.ValueOf(x.Eval(m)) }
+
+func (x Real) ite(cond Bool, y Real) Real {
+	cache := getCache(cond.S.Context())
+	return Real{S: cond.S.IfThenElse(x.sym(cache), y.sym(cache)).(z3.Real)}
+}
+
+// Min returns the smaller of x and y, like Go's built-in min.
+func (x Real) Min(y Real) Real {
+	return Ite(x.LE(y), x, y)
+}
+
+// Max returns the larger of x and y, like Go's built-in max.
+func (x Real) Max(y Real) Real {
+	return Ite(x.GE(y), x, y)
+}
+
+// Abs returns the absolute value of x.
+func (x Real) Abs() Real {
+	return Ite(x.LT(x.zero()), x.Neg(), x)
+}
+
 func (x Real) Add(y Real) Real {
 	if x.IsConcrete() && y.IsConcrete() {
 		z := Real{C: new(big.Rat)}
@@ -4417,6 +8290,7 @@ func (x Real) Quo(y Real) Real {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	cache.checkPanic(y.Eq(y.zero()), "division by zero")
 	return Real{S: x.sym(cache).Div(y.sym(cache))}
 }
 
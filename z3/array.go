@@ -76,3 +76,49 @@ func (ctx *Context) ConstArray(domain Sort, value Value) Array {
 // This is useful for extracting array values interpreted by models.
 //
 //wrap:expr Default:Value x : Z3_mk_array_default x
+
+// AsStores returns the default value of x and the indexes and values
+// stored in x, if x is a constant array updated by zero or more
+// stores. Model evaluation produces arrays of this form. Stores are
+// returned in the order they were applied, so a later store to an
+// index overrides an earlier one.
+//
+// If x is not of this form, AsStores returns isLiteral false.
+func (x Array) AsStores() (def Value, idx, vals []Value, isLiteral bool) {
+	ctx := x.ctx
+	wrap := func(c C.Z3_ast) Value {
+		return wrapValue(ctx, func() C.Z3_ast { return c }).lift(KindUnknown)
+	}
+	var cdef C.Z3_ast
+	var cidx, cvals []C.Z3_ast
+	ctx.do(func() {
+		c := x.c
+		for {
+			if !z3ToBool(C.Z3_is_app(ctx.c, c)) {
+				return
+			}
+			app := C.Z3_to_app(ctx.c, c)
+			switch C.Z3_get_decl_kind(ctx.c, C.Z3_get_app_decl(ctx.c, app)) {
+			case C.Z3_OP_STORE:
+				cidx = append(cidx, C.Z3_get_app_arg(ctx.c, app, 1))
+				cvals = append(cvals, C.Z3_get_app_arg(ctx.c, app, 2))
+				c = C.Z3_get_app_arg(ctx.c, app, 0)
+				continue
+			case C.Z3_OP_CONST_ARRAY:
+				cdef = C.Z3_get_app_arg(ctx.c, app, 0)
+			}
+			return
+		}
+	})
+	if cdef == nil {
+		runtime.KeepAlive(x)
+		return nil, nil, nil, false
+	}
+	def = wrap(cdef)
+	for i := len(cidx) - 1; i >= 0; i-- {
+		idx = append(idx, wrap(cidx[i]))
+		vals = append(vals, wrap(cvals[i]))
+	}
+	runtime.KeepAlive(x)
+	return def, idx, vals, true
+}
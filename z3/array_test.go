@@ -0,0 +1,44 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestArrayAsStores(t *testing.T) {
+	ctx := NewContext(nil)
+	intSort := ctx.IntSort()
+	one, two := ctx.FromInt(1, intSort), ctx.FromInt(2, intSort)
+
+	arr := ctx.ConstArray(intSort, ctx.FromBool(false))
+	arr = arr.Store(one, ctx.FromBool(true)).Store(two, ctx.FromBool(true)).Store(one, ctx.FromBool(false))
+	def, idx, vals, ok := arr.AsStores()
+	if !ok || len(idx) != 3 {
+		t.Fatalf("AsStores(%s) = %v, %v, %v, %v", arr, def, idx, vals, ok)
+	}
+	if v, _ := def.(Bool).AsBool(); v {
+		t.Errorf("want default false, got %s", def)
+	}
+	if v, _ := vals[2].(Bool).AsBool(); v || !idx[2].AsAST().Equal(one.AsAST()) {
+		t.Errorf("want last store 1 -> false, got %s -> %s", idx[2], vals[2])
+	}
+
+	if _, _, _, ok := ctx.Const("a", arr.Sort()).(Array).AsStores(); ok {
+		t.Errorf("AsStores of constant succeeded")
+	}
+
+	// Arrays from models should be literals.
+	a := ctx.Const("a", arr.Sort()).(Array)
+	s := NewSolver(ctx)
+	s.Assert(a.Select(one).(Bool))
+	s.Assert(a.Select(two).(Bool).Not())
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("not satisfiable: %v", err)
+	}
+	m := s.Model()
+	av := m.Eval(a, true).(Array)
+	if _, _, _, ok := av.AsStores(); !ok {
+		t.Fatalf("AsStores(%s) failed", av)
+	}
+}
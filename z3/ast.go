@@ -43,7 +43,7 @@ func wrapAST(ctx *Context, c C.Z3_ast) AST {
 	// refcount on the first, Z3 will reclaim the first object!
 	C.Z3_inc_ref(ctx.c, c)
 	runtime.SetFinalizer(impl, func(impl *astImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_dec_ref(impl.ctx.c, impl.c)
 		})
 	})
@@ -109,7 +109,7 @@ func (ast AST) ID() uint64 {
 // Translate copies ast into the target Context.
 func (ast AST) Translate(target *Context) AST {
 	var res AST
-	target.do(func() {
+	ast.ctx.doBoth(target, func() {
 		res = wrapAST(target, C.Z3_translate(ast.ctx.c, ast.c, target.c))
 	})
 	runtime.KeepAlive(ast)
@@ -126,6 +126,43 @@ func (ast AST) Kind() ASTKind {
 	return res
 }
 
+// Decl returns the function declaration of application ast. Constants
+// are applications of a function with no arguments.
+//
+// It panics if ast is not an application. That is, ast must have Kind
+// ASTKindApp or ASTKindNumeral.
+func (ast AST) Decl() FuncDecl {
+	var decl FuncDecl
+	ast.ctx.do(func() {
+		if !z3ToBool(C.Z3_is_app(ast.ctx.c, ast.c)) {
+			panic("AST is not an application")
+		}
+		app := C.Z3_to_app(ast.ctx.c, ast.c)
+		decl = wrapFuncDecl(ast.ctx, C.Z3_get_app_decl(ast.ctx.c, app))
+	})
+	runtime.KeepAlive(ast)
+	return decl
+}
+
+// Args returns the arguments of application ast, or nil if ast is not
+// an application.
+func (ast AST) Args() []AST {
+	var args []AST
+	ast.ctx.do(func() {
+		if !z3ToBool(C.Z3_is_app(ast.ctx.c, ast.c)) {
+			return
+		}
+		app := C.Z3_to_app(ast.ctx.c, ast.c)
+		n := C.Z3_get_app_num_args(ast.ctx.c, app)
+		args = make([]AST, n)
+		for i := C.uint(0); i < n; i++ {
+			args[i] = wrapAST(ast.ctx, C.Z3_get_app_arg(ast.ctx.c, app, i))
+		}
+	})
+	runtime.KeepAlive(ast)
+	return args
+}
+
 // AsValue returns this AST as a symbolic value.
 //
 // It panics if ast is not a value expression. That is, ast must have
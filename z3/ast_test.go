@@ -62,3 +62,23 @@ func TestASTTranslate(t *testing.T) {
 	x := ctx1.BoolConst("x")
 	x.AsAST().Translate(ctx2).AsValue().(Bool).Eq(ctx2.FromBool(true))
 }
+
+func TestASTDeclArgs(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	sum := x.Add(y).AsAST()
+	if got := sum.Decl().Kind(); got != DeclKindAdd {
+		t.Errorf("Decl().Kind() = %v, want DeclKindAdd", got)
+	}
+	args := sum.Args()
+	if len(args) != 2 || !args[0].Equal(x.AsAST()) || !args[1].Equal(y.AsAST()) {
+		t.Errorf("Args() = %v, want [x y]", args)
+	}
+	if got := x.AsAST().Decl().Name(); got != "x" {
+		t.Errorf("Decl().Name() = %q, want \"x\"", got)
+	}
+	if args := ctx.IntSort().AsAST().Args(); args != nil {
+		t.Errorf("sort Args() = %v, want nil", args)
+	}
+	wantPanic(t, "not an application", func() { ctx.IntSort().AsAST().Decl() })
+}
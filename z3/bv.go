@@ -362,4 +362,80 @@ func (lit BV) AsUint64() (val uint64, isLiteral, ok bool) {
 //
 //wrap:expr UToFloat:Float l s:Sort : Z3_mk_fpa_to_fp_unsigned @rm l s
 
-// TODO: Z3_mk_bv*_no_{over,under}flow
+// UAddNoOverflow returns true if l + r does not overflow, where l and
+// r are unsigned.
+//
+// l and r must have the same size.
+//
+//wrap:expr UAddNoOverflow:Bool l r : Z3_mk_bvadd_no_overflow l r "false"
+
+// SAddNoOverflow returns true if l + r does not overflow above the
+// largest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+//
+//wrap:expr SAddNoOverflow:Bool l r : Z3_mk_bvadd_no_overflow l r "true"
+
+// SAddNoUnderflow returns true if l + r does not underflow below the
+// smallest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+//
+//wrap:expr SAddNoUnderflow:Bool Z3_mk_bvadd_no_underflow l r
+
+// SSubNoOverflow returns true if l - r does not overflow above the
+// largest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+//
+//wrap:expr SSubNoOverflow:Bool Z3_mk_bvsub_no_overflow l r
+
+// USubNoUnderflow returns true if l - r does not underflow below 0,
+// where l and r are unsigned.
+//
+// l and r must have the same size.
+//
+//wrap:expr USubNoUnderflow:Bool l r : Z3_mk_bvsub_no_underflow l r "false"
+
+// SSubNoUnderflow returns true if l - r does not underflow below the
+// smallest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+//
+//wrap:expr SSubNoUnderflow:Bool l r : Z3_mk_bvsub_no_underflow l r "true"
+
+// SDivNoOverflow returns true if l / r does not overflow, where l and
+// r are signed. This overflows only if l is the smallest signed
+// value and r is -1.
+//
+// l and r must have the same size.
+//
+//wrap:expr SDivNoOverflow:Bool Z3_mk_bvsdiv_no_overflow l r
+
+// SNegNoOverflow returns true if -l does not overflow, where l is
+// signed. This overflows only if l is the smallest signed value.
+//
+//wrap:expr SNegNoOverflow:Bool Z3_mk_bvneg_no_overflow l
+
+// UMulNoOverflow returns true if l * r does not overflow, where l and
+// r are unsigned.
+//
+// l and r must have the same size.
+//
+//wrap:expr UMulNoOverflow:Bool l r : Z3_mk_bvmul_no_overflow l r "false"
+
+// SMulNoOverflow returns true if l * r does not overflow above the
+// largest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+//
+// Z3 4.8.12 incorrectly returns false whenever l or r is negative.
+//
+//wrap:expr SMulNoOverflow:Bool l r : Z3_mk_bvmul_no_overflow l r "true"
+
+// SMulNoUnderflow returns true if l * r does not underflow below the
+// smallest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+//
+//wrap:expr SMulNoUnderflow:Bool Z3_mk_bvmul_no_underflow l r
@@ -596,3 +596,168 @@ func (l BV) UToFloat(s Sort) Float {
 	runtime.KeepAlive(s)
 	return Float(val)
 }
+
+// UAddNoOverflow returns true if l + r does not overflow, where l and
+// r are unsigned.
+//
+// l and r must have the same size.
+func (l BV) UAddNoOverflow(r BV) Bool {
+	// Generated from bv.go:370.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvadd_no_overflow(ctx.c, l.c, r.c, false)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SAddNoOverflow returns true if l + r does not overflow above the
+// largest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+func (l BV) SAddNoOverflow(r BV) Bool {
+	// Generated from bv.go:377.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvadd_no_overflow(ctx.c, l.c, r.c, true)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SAddNoUnderflow returns true if l + r does not underflow below the
+// smallest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+func (l BV) SAddNoUnderflow(r BV) Bool {
+	// Generated from bv.go:384.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvadd_no_underflow(ctx.c, l.c, r.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SSubNoOverflow returns true if l - r does not overflow above the
+// largest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+func (l BV) SSubNoOverflow(r BV) Bool {
+	// Generated from bv.go:391.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvsub_no_overflow(ctx.c, l.c, r.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// USubNoUnderflow returns true if l - r does not underflow below 0,
+// where l and r are unsigned.
+//
+// l and r must have the same size.
+func (l BV) USubNoUnderflow(r BV) Bool {
+	// Generated from bv.go:398.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvsub_no_underflow(ctx.c, l.c, r.c, false)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SSubNoUnderflow returns true if l - r does not underflow below the
+// smallest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+func (l BV) SSubNoUnderflow(r BV) Bool {
+	// Generated from bv.go:405.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvsub_no_underflow(ctx.c, l.c, r.c, true)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SDivNoOverflow returns true if l / r does not overflow, where l and
+// r are signed. This overflows only if l is the smallest signed
+// value and r is -1.
+//
+// l and r must have the same size.
+func (l BV) SDivNoOverflow(r BV) Bool {
+	// Generated from bv.go:413.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvsdiv_no_overflow(ctx.c, l.c, r.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SNegNoOverflow returns true if -l does not overflow, where l is
+// signed. This overflows only if l is the smallest signed value.
+func (l BV) SNegNoOverflow() Bool {
+	// Generated from bv.go:418.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvneg_no_overflow(ctx.c, l.c)
+	})
+	runtime.KeepAlive(l)
+	return Bool(val)
+}
+
+// UMulNoOverflow returns true if l * r does not overflow, where l and
+// r are unsigned.
+//
+// l and r must have the same size.
+func (l BV) UMulNoOverflow(r BV) Bool {
+	// Generated from bv.go:425.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvmul_no_overflow(ctx.c, l.c, r.c, false)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SMulNoOverflow returns true if l * r does not overflow above the
+// largest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+//
+// Z3 4.8.12 incorrectly returns false whenever l or r is negative.
+func (l BV) SMulNoOverflow(r BV) Bool {
+	// Generated from bv.go:434.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvmul_no_overflow(ctx.c, l.c, r.c, true)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SMulNoUnderflow returns true if l * r does not underflow below the
+// smallest signed value, where l and r are signed.
+//
+// l and r must have the same size.
+func (l BV) SMulNoUnderflow(r BV) Bool {
+	// Generated from bv.go:441.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvmul_no_underflow(ctx.c, l.c, r.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
@@ -86,3 +86,42 @@ func TestBVAsInt64(t *testing.T) {
 		t.Errorf("-1:128 as int: expected %v, %v, %v; got %v, %v, %v", -1, true, true, vs, isConst, ok)
 	}
 }
+
+func TestBVNoOverflow(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.BVSort(4)
+	inU := func(v int) bool { return 0 <= v && v < 16 }
+	inS := func(v int) bool { return -8 <= v && v < 8 }
+
+	for x := -8; x < 8; x++ {
+		for y := -8; y < 8; y++ {
+			l, r := ctx.FromInt(int64(x), s).(BV), ctx.FromInt(int64(y), s).(BV)
+			ux, uy := x&15, y&15
+			check := func(name string, got Bool, want bool) {
+				t.Helper()
+				if simplifyBool(t, ctx, got) != want {
+					t.Errorf("%s(%d, %d) = %v, want %v", name, x, y, !want, want)
+				}
+			}
+			check("UAddNoOverflow", l.UAddNoOverflow(r), inU(ux+uy))
+			check("SAddNoOverflow", l.SAddNoOverflow(r), x+y < 8)
+			check("SAddNoUnderflow", l.SAddNoUnderflow(r), x+y >= -8)
+			check("SSubNoOverflow", l.SSubNoOverflow(r), x-y < 8)
+			check("USubNoUnderflow", l.USubNoUnderflow(r), ux >= uy)
+			check("SSubNoUnderflow", l.SSubNoUnderflow(r), x-y >= -8)
+			check("UMulNoOverflow", l.UMulNoOverflow(r), inU(ux*uy))
+			if x >= 0 && y >= 0 {
+				// See the Z3 bug noted on SMulNoOverflow.
+				check("SMulNoOverflow", l.SMulNoOverflow(r), x*y < 8)
+			}
+			check("SMulNoUnderflow", l.SMulNoUnderflow(r), x*y >= -8)
+			if y != 0 {
+				check("SDivNoOverflow", l.SDivNoOverflow(r), inS(x/y))
+			}
+		}
+		l := ctx.FromInt(int64(x), s).(BV)
+		if got, want := simplifyBool(t, ctx, l.SNegNoOverflow()), inS(-x); got != want {
+			t.Errorf("SNegNoOverflow(%d) = %v, want %v", x, got, want)
+		}
+	}
+}
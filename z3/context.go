@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -30,6 +31,9 @@ import "C"
 // Context is thread-safe. However, most operations block other
 // operations (one notable exception is Interrupt). Hence, to do
 // things in parallel, it's best to create multiple Contexts.
+// Solver.Translate copies a Solver's assertions to another Context,
+// and package portfolio uses this to check a problem under several
+// configurations in parallel.
 type Context struct {
 	*contextImpl
 
@@ -53,8 +57,23 @@ type Context struct {
 	// error. Use Context.do to acquire this around a Z3 operation
 	// and panic if the operation has an error status.
 	lock sync.Mutex
+
+	// seq orders Contexts so doBoth can acquire two locks in a
+	// consistent order.
+	seq uint64
+
+	// callbacks is the number of Propagator callbacks running in
+	// this Context. These release lock so the Propagator can use
+	// the Context, but Z3 is still in the middle of an operation,
+	// so while callbacks is non-zero, finalizers add their work to
+	// released rather than calling Z3. Both are protected by lock.
+	callbacks int
+	released  []func()
 }
 
+// contextSeq is the seq of the most recently created Context.
+var contextSeq uint64
+
 type contextImpl struct {
 	c C.Z3_context
 }
@@ -110,6 +129,9 @@ func NewContext(config *Config) *Context {
 		value{},
 		nil,
 		sync.Mutex{},
+		atomic.AddUint64(&contextSeq, 1),
+		0,
+		nil,
 	}
 	// Install an error handler that turns errors into Go panics.
 	// This error handler is equivalent to a longjmp on the C++
@@ -215,6 +237,38 @@ func (ctx *Context) do(f func()) {
 	f()
 }
 
+// release calls f with the per-context lock held to release a Z3
+// object from a finalizer. If a Propagator callback is running, it
+// instead defers f until the callback returns.
+func (ctx *Context) release(f func()) {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	if ctx.callbacks > 0 {
+		ctx.released = append(ctx.released, f)
+		return
+	}
+	f()
+}
+
+// doBoth is like do, but holds the locks of both ctx and other.
+// Operations that read from one Context and write to another, like
+// translation, need both locks. To avoid deadlock when two goroutines
+// translate in opposite directions, doBoth always acquires the lock
+// of the older Context first.
+func (ctx *Context) doBoth(other *Context, f func()) {
+	if ctx == other {
+		ctx.do(f)
+		return
+	}
+	first, second := ctx, other
+	if first.seq > second.seq {
+		first, second = second, first
+	}
+	first.do(func() {
+		second.do(f)
+	})
+}
+
 // symbol interns name as a Z3 symbol.
 func (ctx *Context) symbol(name string) C.Z3_symbol {
 	if sym, ok := ctx.syms[name]; ok {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func expectPanic(t *testing.T, pattern string, f func()) {
@@ -32,3 +33,46 @@ func TestErrorHandling(t *testing.T) {
 	y := ctx.BVConst("y", 2)
 	expectPanic(t, "are incompatible", func() { x.Eq(y) })
 }
+
+func TestTranslateConcurrent(t *testing.T) {
+	// Translating between two Contexts in opposite directions at
+	// the same time must not deadlock.
+	ctxs := []*Context{NewContext(nil), NewContext(nil)}
+	var xs []Int
+	var solvers []*Solver
+	var models []*Model
+	for _, ctx := range ctxs {
+		x := ctx.IntConst("x")
+		xs = append(xs, x)
+		s := NewSolver(ctx)
+		s.Assert(x.GT(ctx.FromInt(3, ctx.IntSort()).(Int)))
+		if sat, err := s.Check(); !sat {
+			t.Fatalf("not satisfiable: %v", err)
+		}
+		solvers = append(solvers, s)
+		models = append(models, s.Model())
+	}
+
+	done := make(chan bool)
+	for i := range ctxs {
+		src, dst := i, 1-i
+		dstSolver := NewSolver(ctxs[dst])
+		go func() {
+			for j := 0; j < 50; j++ {
+				xs[src].AsAST().Translate(ctxs[dst])
+				solvers[src].Translate(ctxs[dst])
+				models[src].Translate(ctxs[dst])
+				solvers[src].TranslateInto(dstSolver)
+			}
+			done <- true
+		}()
+	}
+	timeout := time.After(time.Minute)
+	for range ctxs {
+		select {
+		case <-done:
+		case <-timeout:
+			t.Fatal("deadlock translating between Contexts")
+		}
+	}
+}
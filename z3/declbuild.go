@@ -0,0 +1,435 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// An arity is the number of arguments and integer parameters of an
+// interpreted function. If args is negative, the function is n-ary
+// and takes at least -args arguments.
+type arity struct {
+	args, params int
+}
+
+// declArity gives the arity of each interpreted function that build
+// can construct.
+var declArity = map[DeclKind]arity{
+	DeclKindTrue:     {0, 0},
+	DeclKindFalse:    {0, 0},
+	DeclKindEq:       {2, 0},
+	DeclKindDistinct: {-2, 0},
+	DeclKindITE:      {3, 0},
+	DeclKindAnd:      {-1, 0},
+	DeclKindOr:       {-1, 0},
+	DeclKindIff:      {2, 0},
+	DeclKindXor:      {-2, 0},
+	DeclKindNot:      {1, 0},
+	DeclKindImplies:  {2, 0},
+
+	DeclKindLE:     {2, 0},
+	DeclKindGE:     {2, 0},
+	DeclKindLT:     {2, 0},
+	DeclKindGT:     {2, 0},
+	DeclKindAdd:    {-1, 0},
+	DeclKindSub:    {-1, 0},
+	DeclKindUMinus: {1, 0},
+	DeclKindMul:    {-1, 0},
+	DeclKindDiv:    {2, 0},
+	DeclKindIDiv:   {2, 0},
+	DeclKindRem:    {2, 0},
+	DeclKindMod:    {2, 0},
+	DeclKindToReal: {1, 0},
+	DeclKindToInt:  {1, 0},
+	DeclKindIsInt:  {1, 0},
+	DeclKindPower:  {2, 0},
+
+	DeclKindBNeg:           {1, 0},
+	DeclKindBAdd:           {-2, 0},
+	DeclKindBSub:           {2, 0},
+	DeclKindBMul:           {-2, 0},
+	DeclKindBSDiv:          {2, 0},
+	DeclKindBUDiv:          {2, 0},
+	DeclKindBSRem:          {2, 0},
+	DeclKindBURem:          {2, 0},
+	DeclKindBSMod:          {2, 0},
+	DeclKindBSDivI:         {2, 0},
+	DeclKindBUDivI:         {2, 0},
+	DeclKindBSRemI:         {2, 0},
+	DeclKindBURemI:         {2, 0},
+	DeclKindBSModI:         {2, 0},
+	DeclKindULEQ:           {2, 0},
+	DeclKindSLEQ:           {2, 0},
+	DeclKindUGEQ:           {2, 0},
+	DeclKindSGEQ:           {2, 0},
+	DeclKindULT:            {2, 0},
+	DeclKindSLT:            {2, 0},
+	DeclKindUGT:            {2, 0},
+	DeclKindSGT:            {2, 0},
+	DeclKindBAnd:           {-2, 0},
+	DeclKindBOr:            {-2, 0},
+	DeclKindBNot:           {1, 0},
+	DeclKindBXor:           {-2, 0},
+	DeclKindBNand:          {2, 0},
+	DeclKindBNor:           {2, 0},
+	DeclKindBXnor:          {2, 0},
+	DeclKindConcat:         {-2, 0},
+	DeclKindSignExt:        {1, 1},
+	DeclKindZeroExt:        {1, 1},
+	DeclKindExtract:        {1, 2},
+	DeclKindRepeat:         {1, 1},
+	DeclKindBRedOr:         {1, 0},
+	DeclKindBRedAnd:        {1, 0},
+	DeclKindBShl:           {2, 0},
+	DeclKindBLShr:          {2, 0},
+	DeclKindBAShr:          {2, 0},
+	DeclKindRotateLeft:     {1, 1},
+	DeclKindRotateRight:    {1, 1},
+	DeclKindExtRotateLeft:  {2, 0},
+	DeclKindExtRotateRight: {2, 0},
+	DeclKindInt2BV:         {1, 1},
+	DeclKindBV2Int:         {1, 0},
+
+	DeclKindSelect:     {2, 0},
+	DeclKindStore:      {3, 0},
+	DeclKindConstArray: {1, 0},
+
+	DeclKindSeqUnit:   {1, 0},
+	DeclKindSeqEmpty:  {0, 0},
+	DeclKindSeqConcat: {-1, 0},
+	DeclKindSeqLength: {1, 0},
+
+	DeclKindFPARoundNearestTiesToEven: {0, 0},
+	DeclKindFPARoundNearestTiesToAway: {0, 0},
+	DeclKindFPARoundTowardPositive:    {0, 0},
+	DeclKindFPARoundTowardNegative:    {0, 0},
+	DeclKindFPARoundTowardZero:        {0, 0},
+	DeclKindFPAPlusInf:                {0, 0},
+	DeclKindFPAMinusInf:               {0, 0},
+	DeclKindFPANaN:                    {0, 0},
+	DeclKindFPAPlusZero:               {0, 0},
+	DeclKindFPAMinusZero:              {0, 0},
+	DeclKindFPAAdd:                    {3, 0},
+	DeclKindFPASub:                    {3, 0},
+	DeclKindFPANeg:                    {1, 0},
+	DeclKindFPAMul:                    {3, 0},
+	DeclKindFPADiv:                    {3, 0},
+	DeclKindFPARem:                    {2, 0},
+	DeclKindFPAAbs:                    {1, 0},
+	DeclKindFPAMin:                    {2, 0},
+	DeclKindFPAMax:                    {2, 0},
+	DeclKindFPAFMA:                    {4, 0},
+	DeclKindFPASqrt:                   {2, 0},
+	DeclKindFPARoundToIntegral:        {2, 0},
+	DeclKindFPAEq:                     {2, 0},
+	DeclKindFPALT:                     {2, 0},
+	DeclKindFPAGT:                     {2, 0},
+	DeclKindFPALE:                     {2, 0},
+	DeclKindFPAGE:                     {2, 0},
+	DeclKindFPAIsNaN:                  {1, 0},
+	DeclKindFPAIsInf:                  {1, 0},
+	DeclKindFPAIsZero:                 {1, 0},
+	DeclKindFPAIsNormal:               {1, 0},
+	DeclKindFPAIsSubnormal:            {1, 0},
+	DeclKindFPAIsNegative:             {1, 0},
+	DeclKindFPAIsPositive:             {1, 0},
+	DeclKindFPAFP:                     {3, 0},
+	DeclKindFPAToFP:                   {-1, 0},
+	DeclKindFPAToFPUnsigned:           {2, 0},
+	DeclKindFPAToUBV:                  {2, 0},
+	DeclKindFPAToSBV:                  {2, 0},
+	DeclKindFPAToReal:                 {1, 0},
+	DeclKindFPAToIEEEBV:               {1, 0},
+}
+
+// buildable returns whether values of function kind can be
+// marshaled and unmarshaled.
+func buildable(kind DeclKind) bool {
+	switch kind {
+	case DeclKindUninterpreted, DeclKindANum, DeclKindBNum, DeclKindFPANum,
+		DeclKindAsArray, DeclKindDTConstructor, DeclKindDTAccessor:
+		return true
+	}
+	_, ok := declArity[kind]
+	return ok
+}
+
+// build returns the application of the interpreted function kind to
+// args. The number of args and params must match declArity[kind].
+// range_ is the sort of the result, which determines the function
+// for kinds such as DeclKindSeqEmpty.
+//
+// This must be called with the ctx lock held.
+func build(c C.Z3_context, kind DeclKind, args []C.Z3_ast, params []int, range_ C.Z3_sort) C.Z3_ast {
+	n := C.uint(len(args))
+	var p *C.Z3_ast
+	if len(args) > 0 {
+		p = &args[0]
+	}
+	// nary applies an n-ary function that the API only exposes as
+	// the binary function f.
+	nary := func(f func(x, y C.Z3_ast) C.Z3_ast) C.Z3_ast {
+		x := f(args[0], args[1])
+		if len(args) == 2 {
+			return x
+		}
+		decl := C.Z3_get_app_decl(c, C.Z3_to_app(c, x))
+		return C.Z3_mk_app(c, decl, n, p)
+	}
+	param := func(i int) C.uint {
+		return C.uint(params[i])
+	}
+	switch kind {
+	case DeclKindTrue:
+		return C.Z3_mk_true(c)
+	case DeclKindFalse:
+		return C.Z3_mk_false(c)
+	case DeclKindEq:
+		return C.Z3_mk_eq(c, args[0], args[1])
+	case DeclKindDistinct:
+		return C.Z3_mk_distinct(c, n, p)
+	case DeclKindITE:
+		return C.Z3_mk_ite(c, args[0], args[1], args[2])
+	case DeclKindAnd:
+		return C.Z3_mk_and(c, n, p)
+	case DeclKindOr:
+		return C.Z3_mk_or(c, n, p)
+	case DeclKindIff:
+		return C.Z3_mk_iff(c, args[0], args[1])
+	case DeclKindXor:
+		return nary(func(x, y C.Z3_ast) C.Z3_ast { return C.Z3_mk_xor(c, x, y) })
+	case DeclKindNot:
+		return C.Z3_mk_not(c, args[0])
+	case DeclKindImplies:
+		return C.Z3_mk_implies(c, args[0], args[1])
+
+	case DeclKindLE:
+		return C.Z3_mk_le(c, args[0], args[1])
+	case DeclKindGE:
+		return C.Z3_mk_ge(c, args[0], args[1])
+	case DeclKindLT:
+		return C.Z3_mk_lt(c, args[0], args[1])
+	case DeclKindGT:
+		return C.Z3_mk_gt(c, args[0], args[1])
+	case DeclKindAdd:
+		return C.Z3_mk_add(c, n, p)
+	case DeclKindSub:
+		return C.Z3_mk_sub(c, n, p)
+	case DeclKindUMinus:
+		return C.Z3_mk_unary_minus(c, args[0])
+	case DeclKindMul:
+		return C.Z3_mk_mul(c, n, p)
+	case DeclKindDiv, DeclKindIDiv:
+		return C.Z3_mk_div(c, args[0], args[1])
+	case DeclKindRem:
+		return C.Z3_mk_rem(c, args[0], args[1])
+	case DeclKindMod:
+		return C.Z3_mk_mod(c, args[0], args[1])
+	case DeclKindToReal:
+		return C.Z3_mk_int2real(c, args[0])
+	case DeclKindToInt:
+		return C.Z3_mk_real2int(c, args[0])
+	case DeclKindIsInt:
+		return C.Z3_mk_is_int(c, args[0])
+	case DeclKindPower:
+		return C.Z3_mk_power(c, args[0], args[1])
+
+	case DeclKindBNeg:
+		return C.Z3_mk_bvneg(c, args[0])
+	case DeclKindBAdd:
+		return nary(func(x, y C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvadd(c, x, y) })
+	case DeclKindBSub:
+		return C.Z3_mk_bvsub(c, args[0], args[1])
+	case DeclKindBMul:
+		return nary(func(x, y C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvmul(c, x, y) })
+	case DeclKindBSDiv, DeclKindBSDivI:
+		return C.Z3_mk_bvsdiv(c, args[0], args[1])
+	case DeclKindBUDiv, DeclKindBUDivI:
+		return C.Z3_mk_bvudiv(c, args[0], args[1])
+	case DeclKindBSRem, DeclKindBSRemI:
+		return C.Z3_mk_bvsrem(c, args[0], args[1])
+	case DeclKindBURem, DeclKindBURemI:
+		return C.Z3_mk_bvurem(c, args[0], args[1])
+	case DeclKindBSMod, DeclKindBSModI:
+		return C.Z3_mk_bvsmod(c, args[0], args[1])
+	case DeclKindULEQ:
+		return C.Z3_mk_bvule(c, args[0], args[1])
+	case DeclKindSLEQ:
+		return C.Z3_mk_bvsle(c, args[0], args[1])
+	case DeclKindUGEQ:
+		return C.Z3_mk_bvuge(c, args[0], args[1])
+	case DeclKindSGEQ:
+		return C.Z3_mk_bvsge(c, args[0], args[1])
+	case DeclKindULT:
+		return C.Z3_mk_bvult(c, args[0], args[1])
+	case DeclKindSLT:
+		return C.Z3_mk_bvslt(c, args[0], args[1])
+	case DeclKindUGT:
+		return C.Z3_mk_bvugt(c, args[0], args[1])
+	case DeclKindSGT:
+		return C.Z3_mk_bvsgt(c, args[0], args[1])
+	case DeclKindBAnd:
+		return nary(func(x, y C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvand(c, x, y) })
+	case DeclKindBOr:
+		return nary(func(x, y C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvor(c, x, y) })
+	case DeclKindBNot:
+		return C.Z3_mk_bvnot(c, args[0])
+	case DeclKindBXor:
+		return nary(func(x, y C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvxor(c, x, y) })
+	case DeclKindBNand:
+		return C.Z3_mk_bvnand(c, args[0], args[1])
+	case DeclKindBNor:
+		return C.Z3_mk_bvnor(c, args[0], args[1])
+	case DeclKindBXnor:
+		return C.Z3_mk_bvxnor(c, args[0], args[1])
+	case DeclKindConcat:
+		return nary(func(x, y C.Z3_ast) C.Z3_ast { return C.Z3_mk_concat(c, x, y) })
+	case DeclKindSignExt:
+		return C.Z3_mk_sign_ext(c, param(0), args[0])
+	case DeclKindZeroExt:
+		return C.Z3_mk_zero_ext(c, param(0), args[0])
+	case DeclKindExtract:
+		return C.Z3_mk_extract(c, param(0), param(1), args[0])
+	case DeclKindRepeat:
+		return C.Z3_mk_repeat(c, param(0), args[0])
+	case DeclKindBRedOr:
+		return C.Z3_mk_bvredor(c, args[0])
+	case DeclKindBRedAnd:
+		return C.Z3_mk_bvredand(c, args[0])
+	case DeclKindBShl:
+		return C.Z3_mk_bvshl(c, args[0], args[1])
+	case DeclKindBLShr:
+		return C.Z3_mk_bvlshr(c, args[0], args[1])
+	case DeclKindBAShr:
+		return C.Z3_mk_bvashr(c, args[0], args[1])
+	case DeclKindRotateLeft:
+		return C.Z3_mk_rotate_left(c, param(0), args[0])
+	case DeclKindRotateRight:
+		return C.Z3_mk_rotate_right(c, param(0), args[0])
+	case DeclKindExtRotateLeft:
+		return C.Z3_mk_ext_rotate_left(c, args[0], args[1])
+	case DeclKindExtRotateRight:
+		return C.Z3_mk_ext_rotate_right(c, args[0], args[1])
+	case DeclKindInt2BV:
+		return C.Z3_mk_int2bv(c, param(0), args[0])
+	case DeclKindBV2Int:
+		return C.Z3_mk_bv2int(c, args[0], false)
+
+	case DeclKindSelect:
+		return C.Z3_mk_select(c, args[0], args[1])
+	case DeclKindStore:
+		return C.Z3_mk_store(c, args[0], args[1], args[2])
+	case DeclKindConstArray:
+		return C.Z3_mk_const_array(c, C.Z3_get_array_sort_domain(c, range_), args[0])
+
+	case DeclKindSeqUnit:
+		return C.Z3_mk_seq_unit(c, args[0])
+	case DeclKindSeqEmpty:
+		return C.Z3_mk_seq_empty(c, range_)
+	case DeclKindSeqConcat:
+		return C.Z3_mk_seq_concat(c, n, p)
+	case DeclKindSeqLength:
+		return C.Z3_mk_seq_length(c, args[0])
+
+	case DeclKindFPARoundNearestTiesToEven:
+		return C.Z3_mk_fpa_rne(c)
+	case DeclKindFPARoundNearestTiesToAway:
+		return C.Z3_mk_fpa_rna(c)
+	case DeclKindFPARoundTowardPositive:
+		return C.Z3_mk_fpa_rtp(c)
+	case DeclKindFPARoundTowardNegative:
+		return C.Z3_mk_fpa_rtn(c)
+	case DeclKindFPARoundTowardZero:
+		return C.Z3_mk_fpa_rtz(c)
+	case DeclKindFPAPlusInf:
+		return C.Z3_mk_fpa_inf(c, range_, false)
+	case DeclKindFPAMinusInf:
+		return C.Z3_mk_fpa_inf(c, range_, true)
+	case DeclKindFPANaN:
+		return C.Z3_mk_fpa_nan(c, range_)
+	case DeclKindFPAPlusZero:
+		return C.Z3_mk_fpa_zero(c, range_, false)
+	case DeclKindFPAMinusZero:
+		return C.Z3_mk_fpa_zero(c, range_, true)
+	case DeclKindFPAAdd:
+		return C.Z3_mk_fpa_add(c, args[0], args[1], args[2])
+	case DeclKindFPASub:
+		return C.Z3_mk_fpa_sub(c, args[0], args[1], args[2])
+	case DeclKindFPANeg:
+		return C.Z3_mk_fpa_neg(c, args[0])
+	case DeclKindFPAMul:
+		return C.Z3_mk_fpa_mul(c, args[0], args[1], args[2])
+	case DeclKindFPADiv:
+		return C.Z3_mk_fpa_div(c, args[0], args[1], args[2])
+	case DeclKindFPARem:
+		return C.Z3_mk_fpa_rem(c, args[0], args[1])
+	case DeclKindFPAAbs:
+		return C.Z3_mk_fpa_abs(c, args[0])
+	case DeclKindFPAMin:
+		return C.Z3_mk_fpa_min(c, args[0], args[1])
+	case DeclKindFPAMax:
+		return C.Z3_mk_fpa_max(c, args[0], args[1])
+	case DeclKindFPAFMA:
+		return C.Z3_mk_fpa_fma(c, args[0], args[1], args[2], args[3])
+	case DeclKindFPASqrt:
+		return C.Z3_mk_fpa_sqrt(c, args[0], args[1])
+	case DeclKindFPARoundToIntegral:
+		return C.Z3_mk_fpa_round_to_integral(c, args[0], args[1])
+	case DeclKindFPAEq:
+		return C.Z3_mk_fpa_eq(c, args[0], args[1])
+	case DeclKindFPALT:
+		return C.Z3_mk_fpa_lt(c, args[0], args[1])
+	case DeclKindFPAGT:
+		return C.Z3_mk_fpa_gt(c, args[0], args[1])
+	case DeclKindFPALE:
+		return C.Z3_mk_fpa_leq(c, args[0], args[1])
+	case DeclKindFPAGE:
+		return C.Z3_mk_fpa_geq(c, args[0], args[1])
+	case DeclKindFPAIsNaN:
+		return C.Z3_mk_fpa_is_nan(c, args[0])
+	case DeclKindFPAIsInf:
+		return C.Z3_mk_fpa_is_infinite(c, args[0])
+	case DeclKindFPAIsZero:
+		return C.Z3_mk_fpa_is_zero(c, args[0])
+	case DeclKindFPAIsNormal:
+		return C.Z3_mk_fpa_is_normal(c, args[0])
+	case DeclKindFPAIsSubnormal:
+		return C.Z3_mk_fpa_is_subnormal(c, args[0])
+	case DeclKindFPAIsNegative:
+		return C.Z3_mk_fpa_is_negative(c, args[0])
+	case DeclKindFPAIsPositive:
+		return C.Z3_mk_fpa_is_positive(c, args[0])
+	case DeclKindFPAFP:
+		return C.Z3_mk_fpa_fp(c, args[0], args[1], args[2])
+	case DeclKindFPAToFP:
+		switch len(args) {
+		case 1:
+			return C.Z3_mk_fpa_to_fp_bv(c, args[0], range_)
+		case 2:
+			switch C.Z3_get_sort_kind(c, C.Z3_get_sort(c, args[1])) {
+			case C.Z3_BV_SORT:
+				return C.Z3_mk_fpa_to_fp_signed(c, args[0], args[1], range_)
+			case C.Z3_FLOATING_POINT_SORT:
+				return C.Z3_mk_fpa_to_fp_float(c, args[0], args[1], range_)
+			case C.Z3_REAL_SORT:
+				return C.Z3_mk_fpa_to_fp_real(c, args[0], args[1], range_)
+			}
+		}
+	case DeclKindFPAToFPUnsigned:
+		return C.Z3_mk_fpa_to_fp_unsigned(c, args[0], args[1], range_)
+	case DeclKindFPAToUBV:
+		return C.Z3_mk_fpa_to_ubv(c, args[0], args[1], C.Z3_get_bv_sort_size(c, range_))
+	case DeclKindFPAToSBV:
+		return C.Z3_mk_fpa_to_sbv(c, args[0], args[1], C.Z3_get_bv_sort_size(c, range_))
+	case DeclKindFPAToReal:
+		return C.Z3_mk_fpa_to_real(c, args[0])
+	case DeclKindFPAToIEEEBV:
+		return C.Z3_mk_fpa_to_ieee_bv(c, args[0])
+	}
+	marshalErrorf("cannot construct %s of %d arguments", kind, len(args))
+	panic("unreachable")
+}
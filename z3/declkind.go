@@ -0,0 +1,591 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "strconv"
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// DeclKind identifies an interpreted function declaration, such as
+// "+" or a ProofRule, or is DeclKindUninterpreted. The kinds are
+// documented with the Z3_OP_* constants in the Z3 API.
+type DeclKind int
+
+// DeclKindUninterpreted is the DeclKind of uninterpreted functions,
+// including those created by Context.FuncDecl.
+const DeclKindUninterpreted = DeclKind(C.Z3_OP_UNINTERPRETED)
+
+// Basic operations.
+const (
+	DeclKindTrue     = DeclKind(C.Z3_OP_TRUE)
+	DeclKindFalse    = DeclKind(C.Z3_OP_FALSE)
+	DeclKindEq       = DeclKind(C.Z3_OP_EQ)
+	DeclKindDistinct = DeclKind(C.Z3_OP_DISTINCT)
+	DeclKindITE      = DeclKind(C.Z3_OP_ITE)
+	DeclKindAnd      = DeclKind(C.Z3_OP_AND)
+	DeclKindOr       = DeclKind(C.Z3_OP_OR)
+	DeclKindIff      = DeclKind(C.Z3_OP_IFF)
+	DeclKindXor      = DeclKind(C.Z3_OP_XOR)
+	DeclKindNot      = DeclKind(C.Z3_OP_NOT)
+	DeclKindImplies  = DeclKind(C.Z3_OP_IMPLIES)
+)
+
+// Arithmetic operations.
+const (
+	DeclKindANum   = DeclKind(C.Z3_OP_ANUM)
+	DeclKindLE     = DeclKind(C.Z3_OP_LE)
+	DeclKindGE     = DeclKind(C.Z3_OP_GE)
+	DeclKindLT     = DeclKind(C.Z3_OP_LT)
+	DeclKindGT     = DeclKind(C.Z3_OP_GT)
+	DeclKindAdd    = DeclKind(C.Z3_OP_ADD)
+	DeclKindSub    = DeclKind(C.Z3_OP_SUB)
+	DeclKindUMinus = DeclKind(C.Z3_OP_UMINUS)
+	DeclKindMul    = DeclKind(C.Z3_OP_MUL)
+	DeclKindDiv    = DeclKind(C.Z3_OP_DIV)
+	DeclKindIDiv   = DeclKind(C.Z3_OP_IDIV)
+	DeclKindRem    = DeclKind(C.Z3_OP_REM)
+	DeclKindMod    = DeclKind(C.Z3_OP_MOD)
+	DeclKindToReal = DeclKind(C.Z3_OP_TO_REAL)
+	DeclKindToInt  = DeclKind(C.Z3_OP_TO_INT)
+	DeclKindIsInt  = DeclKind(C.Z3_OP_IS_INT)
+	DeclKindPower  = DeclKind(C.Z3_OP_POWER)
+)
+
+// Bit-vector operations.
+const (
+	DeclKindBNum           = DeclKind(C.Z3_OP_BNUM)
+	DeclKindBNeg           = DeclKind(C.Z3_OP_BNEG)
+	DeclKindBAdd           = DeclKind(C.Z3_OP_BADD)
+	DeclKindBSub           = DeclKind(C.Z3_OP_BSUB)
+	DeclKindBMul           = DeclKind(C.Z3_OP_BMUL)
+	DeclKindBSDiv          = DeclKind(C.Z3_OP_BSDIV)
+	DeclKindBUDiv          = DeclKind(C.Z3_OP_BUDIV)
+	DeclKindBSRem          = DeclKind(C.Z3_OP_BSREM)
+	DeclKindBURem          = DeclKind(C.Z3_OP_BUREM)
+	DeclKindBSMod          = DeclKind(C.Z3_OP_BSMOD)
+	DeclKindBSDiv0         = DeclKind(C.Z3_OP_BSDIV0)
+	DeclKindBUDiv0         = DeclKind(C.Z3_OP_BUDIV0)
+	DeclKindBSRem0         = DeclKind(C.Z3_OP_BSREM0)
+	DeclKindBURem0         = DeclKind(C.Z3_OP_BUREM0)
+	DeclKindBSMod0         = DeclKind(C.Z3_OP_BSMOD0)
+	DeclKindBSDivI         = DeclKind(C.Z3_OP_BSDIV_I)
+	DeclKindBUDivI         = DeclKind(C.Z3_OP_BUDIV_I)
+	DeclKindBSRemI         = DeclKind(C.Z3_OP_BSREM_I)
+	DeclKindBURemI         = DeclKind(C.Z3_OP_BUREM_I)
+	DeclKindBSModI         = DeclKind(C.Z3_OP_BSMOD_I)
+	DeclKindULEQ           = DeclKind(C.Z3_OP_ULEQ)
+	DeclKindSLEQ           = DeclKind(C.Z3_OP_SLEQ)
+	DeclKindUGEQ           = DeclKind(C.Z3_OP_UGEQ)
+	DeclKindSGEQ           = DeclKind(C.Z3_OP_SGEQ)
+	DeclKindULT            = DeclKind(C.Z3_OP_ULT)
+	DeclKindSLT            = DeclKind(C.Z3_OP_SLT)
+	DeclKindUGT            = DeclKind(C.Z3_OP_UGT)
+	DeclKindSGT            = DeclKind(C.Z3_OP_SGT)
+	DeclKindBAnd           = DeclKind(C.Z3_OP_BAND)
+	DeclKindBOr            = DeclKind(C.Z3_OP_BOR)
+	DeclKindBNot           = DeclKind(C.Z3_OP_BNOT)
+	DeclKindBXor           = DeclKind(C.Z3_OP_BXOR)
+	DeclKindBNand          = DeclKind(C.Z3_OP_BNAND)
+	DeclKindBNor           = DeclKind(C.Z3_OP_BNOR)
+	DeclKindBXnor          = DeclKind(C.Z3_OP_BXNOR)
+	DeclKindConcat         = DeclKind(C.Z3_OP_CONCAT)
+	DeclKindSignExt        = DeclKind(C.Z3_OP_SIGN_EXT)
+	DeclKindZeroExt        = DeclKind(C.Z3_OP_ZERO_EXT)
+	DeclKindExtract        = DeclKind(C.Z3_OP_EXTRACT)
+	DeclKindRepeat         = DeclKind(C.Z3_OP_REPEAT)
+	DeclKindBRedOr         = DeclKind(C.Z3_OP_BREDOR)
+	DeclKindBRedAnd        = DeclKind(C.Z3_OP_BREDAND)
+	DeclKindBComp          = DeclKind(C.Z3_OP_BCOMP)
+	DeclKindBShl           = DeclKind(C.Z3_OP_BSHL)
+	DeclKindBLShr          = DeclKind(C.Z3_OP_BLSHR)
+	DeclKindBAShr          = DeclKind(C.Z3_OP_BASHR)
+	DeclKindRotateLeft     = DeclKind(C.Z3_OP_ROTATE_LEFT)
+	DeclKindRotateRight    = DeclKind(C.Z3_OP_ROTATE_RIGHT)
+	DeclKindExtRotateLeft  = DeclKind(C.Z3_OP_EXT_ROTATE_LEFT)
+	DeclKindExtRotateRight = DeclKind(C.Z3_OP_EXT_ROTATE_RIGHT)
+	DeclKindInt2BV         = DeclKind(C.Z3_OP_INT2BV)
+	DeclKindBV2Int         = DeclKind(C.Z3_OP_BV2INT)
+)
+
+// Array operations.
+const (
+	DeclKindSelect     = DeclKind(C.Z3_OP_SELECT)
+	DeclKindStore      = DeclKind(C.Z3_OP_STORE)
+	DeclKindConstArray = DeclKind(C.Z3_OP_CONST_ARRAY)
+	DeclKindAsArray    = DeclKind(C.Z3_OP_AS_ARRAY)
+)
+
+// Datatype operations.
+const (
+	DeclKindDTConstructor = DeclKind(C.Z3_OP_DT_CONSTRUCTOR)
+	DeclKindDTAccessor    = DeclKind(C.Z3_OP_DT_ACCESSOR)
+)
+
+// Sequence operations.
+const (
+	DeclKindSeqUnit   = DeclKind(C.Z3_OP_SEQ_UNIT)
+	DeclKindSeqEmpty  = DeclKind(C.Z3_OP_SEQ_EMPTY)
+	DeclKindSeqConcat = DeclKind(C.Z3_OP_SEQ_CONCAT)
+	DeclKindSeqLength = DeclKind(C.Z3_OP_SEQ_LENGTH)
+)
+
+// Floating-point operations.
+const (
+	DeclKindFPARoundNearestTiesToEven = DeclKind(C.Z3_OP_FPA_RM_NEAREST_TIES_TO_EVEN)
+	DeclKindFPARoundNearestTiesToAway = DeclKind(C.Z3_OP_FPA_RM_NEAREST_TIES_TO_AWAY)
+	DeclKindFPARoundTowardPositive    = DeclKind(C.Z3_OP_FPA_RM_TOWARD_POSITIVE)
+	DeclKindFPARoundTowardNegative    = DeclKind(C.Z3_OP_FPA_RM_TOWARD_NEGATIVE)
+	DeclKindFPARoundTowardZero        = DeclKind(C.Z3_OP_FPA_RM_TOWARD_ZERO)
+	DeclKindFPANum                    = DeclKind(C.Z3_OP_FPA_NUM)
+	DeclKindFPAPlusInf                = DeclKind(C.Z3_OP_FPA_PLUS_INF)
+	DeclKindFPAMinusInf               = DeclKind(C.Z3_OP_FPA_MINUS_INF)
+	DeclKindFPANaN                    = DeclKind(C.Z3_OP_FPA_NAN)
+	DeclKindFPAPlusZero               = DeclKind(C.Z3_OP_FPA_PLUS_ZERO)
+	DeclKindFPAMinusZero              = DeclKind(C.Z3_OP_FPA_MINUS_ZERO)
+	DeclKindFPAAdd                    = DeclKind(C.Z3_OP_FPA_ADD)
+	DeclKindFPASub                    = DeclKind(C.Z3_OP_FPA_SUB)
+	DeclKindFPANeg                    = DeclKind(C.Z3_OP_FPA_NEG)
+	DeclKindFPAMul                    = DeclKind(C.Z3_OP_FPA_MUL)
+	DeclKindFPADiv                    = DeclKind(C.Z3_OP_FPA_DIV)
+	DeclKindFPARem                    = DeclKind(C.Z3_OP_FPA_REM)
+	DeclKindFPAAbs                    = DeclKind(C.Z3_OP_FPA_ABS)
+	DeclKindFPAMin                    = DeclKind(C.Z3_OP_FPA_MIN)
+	DeclKindFPAMax                    = DeclKind(C.Z3_OP_FPA_MAX)
+	DeclKindFPAFMA                    = DeclKind(C.Z3_OP_FPA_FMA)
+	DeclKindFPASqrt                   = DeclKind(C.Z3_OP_FPA_SQRT)
+	DeclKindFPARoundToIntegral        = DeclKind(C.Z3_OP_FPA_ROUND_TO_INTEGRAL)
+	DeclKindFPAEq                     = DeclKind(C.Z3_OP_FPA_EQ)
+	DeclKindFPALT                     = DeclKind(C.Z3_OP_FPA_LT)
+	DeclKindFPAGT                     = DeclKind(C.Z3_OP_FPA_GT)
+	DeclKindFPALE                     = DeclKind(C.Z3_OP_FPA_LE)
+	DeclKindFPAGE                     = DeclKind(C.Z3_OP_FPA_GE)
+	DeclKindFPAIsNaN                  = DeclKind(C.Z3_OP_FPA_IS_NAN)
+	DeclKindFPAIsInf                  = DeclKind(C.Z3_OP_FPA_IS_INF)
+	DeclKindFPAIsZero                 = DeclKind(C.Z3_OP_FPA_IS_ZERO)
+	DeclKindFPAIsNormal               = DeclKind(C.Z3_OP_FPA_IS_NORMAL)
+	DeclKindFPAIsSubnormal            = DeclKind(C.Z3_OP_FPA_IS_SUBNORMAL)
+	DeclKindFPAIsNegative             = DeclKind(C.Z3_OP_FPA_IS_NEGATIVE)
+	DeclKindFPAIsPositive             = DeclKind(C.Z3_OP_FPA_IS_POSITIVE)
+	DeclKindFPAFP                     = DeclKind(C.Z3_OP_FPA_FP)
+	DeclKindFPAToFP                   = DeclKind(C.Z3_OP_FPA_TO_FP)
+	DeclKindFPAToFPUnsigned           = DeclKind(C.Z3_OP_FPA_TO_FP_UNSIGNED)
+	DeclKindFPAToUBV                  = DeclKind(C.Z3_OP_FPA_TO_UBV)
+	DeclKindFPAToSBV                  = DeclKind(C.Z3_OP_FPA_TO_SBV)
+	DeclKindFPAToReal                 = DeclKind(C.Z3_OP_FPA_TO_REAL)
+	DeclKindFPAToIEEEBV               = DeclKind(C.Z3_OP_FPA_TO_IEEE_BV)
+)
+
+// declKinds lists the named DeclKinds.
+var declKinds = []DeclKind{
+	DeclKindUninterpreted,
+	DeclKindTrue,
+	DeclKindFalse,
+	DeclKindEq,
+	DeclKindDistinct,
+	DeclKindITE,
+	DeclKindAnd,
+	DeclKindOr,
+	DeclKindIff,
+	DeclKindXor,
+	DeclKindNot,
+	DeclKindImplies,
+	DeclKindANum,
+	DeclKindLE,
+	DeclKindGE,
+	DeclKindLT,
+	DeclKindGT,
+	DeclKindAdd,
+	DeclKindSub,
+	DeclKindUMinus,
+	DeclKindMul,
+	DeclKindDiv,
+	DeclKindIDiv,
+	DeclKindRem,
+	DeclKindMod,
+	DeclKindToReal,
+	DeclKindToInt,
+	DeclKindIsInt,
+	DeclKindPower,
+	DeclKindBNum,
+	DeclKindBNeg,
+	DeclKindBAdd,
+	DeclKindBSub,
+	DeclKindBMul,
+	DeclKindBSDiv,
+	DeclKindBUDiv,
+	DeclKindBSRem,
+	DeclKindBURem,
+	DeclKindBSMod,
+	DeclKindBSDiv0,
+	DeclKindBUDiv0,
+	DeclKindBSRem0,
+	DeclKindBURem0,
+	DeclKindBSMod0,
+	DeclKindBSDivI,
+	DeclKindBUDivI,
+	DeclKindBSRemI,
+	DeclKindBURemI,
+	DeclKindBSModI,
+	DeclKindULEQ,
+	DeclKindSLEQ,
+	DeclKindUGEQ,
+	DeclKindSGEQ,
+	DeclKindULT,
+	DeclKindSLT,
+	DeclKindUGT,
+	DeclKindSGT,
+	DeclKindBAnd,
+	DeclKindBOr,
+	DeclKindBNot,
+	DeclKindBXor,
+	DeclKindBNand,
+	DeclKindBNor,
+	DeclKindBXnor,
+	DeclKindConcat,
+	DeclKindSignExt,
+	DeclKindZeroExt,
+	DeclKindExtract,
+	DeclKindRepeat,
+	DeclKindBRedOr,
+	DeclKindBRedAnd,
+	DeclKindBComp,
+	DeclKindBShl,
+	DeclKindBLShr,
+	DeclKindBAShr,
+	DeclKindRotateLeft,
+	DeclKindRotateRight,
+	DeclKindExtRotateLeft,
+	DeclKindExtRotateRight,
+	DeclKindInt2BV,
+	DeclKindBV2Int,
+	DeclKindSelect,
+	DeclKindStore,
+	DeclKindConstArray,
+	DeclKindAsArray,
+	DeclKindDTConstructor,
+	DeclKindDTAccessor,
+	DeclKindSeqUnit,
+	DeclKindSeqEmpty,
+	DeclKindSeqConcat,
+	DeclKindSeqLength,
+	DeclKindFPARoundNearestTiesToEven,
+	DeclKindFPARoundNearestTiesToAway,
+	DeclKindFPARoundTowardPositive,
+	DeclKindFPARoundTowardNegative,
+	DeclKindFPARoundTowardZero,
+	DeclKindFPANum,
+	DeclKindFPAPlusInf,
+	DeclKindFPAMinusInf,
+	DeclKindFPANaN,
+	DeclKindFPAPlusZero,
+	DeclKindFPAMinusZero,
+	DeclKindFPAAdd,
+	DeclKindFPASub,
+	DeclKindFPANeg,
+	DeclKindFPAMul,
+	DeclKindFPADiv,
+	DeclKindFPARem,
+	DeclKindFPAAbs,
+	DeclKindFPAMin,
+	DeclKindFPAMax,
+	DeclKindFPAFMA,
+	DeclKindFPASqrt,
+	DeclKindFPARoundToIntegral,
+	DeclKindFPAEq,
+	DeclKindFPALT,
+	DeclKindFPAGT,
+	DeclKindFPALE,
+	DeclKindFPAGE,
+	DeclKindFPAIsNaN,
+	DeclKindFPAIsInf,
+	DeclKindFPAIsZero,
+	DeclKindFPAIsNormal,
+	DeclKindFPAIsSubnormal,
+	DeclKindFPAIsNegative,
+	DeclKindFPAIsPositive,
+	DeclKindFPAFP,
+	DeclKindFPAToFP,
+	DeclKindFPAToFPUnsigned,
+	DeclKindFPAToUBV,
+	DeclKindFPAToSBV,
+	DeclKindFPAToReal,
+	DeclKindFPAToIEEEBV,
+}
+
+// String returns k as a string like "DeclKindAdd".
+func (k DeclKind) String() string {
+	switch k {
+	case DeclKindUninterpreted:
+		return "DeclKindUninterpreted"
+	case DeclKindTrue:
+		return "DeclKindTrue"
+	case DeclKindFalse:
+		return "DeclKindFalse"
+	case DeclKindEq:
+		return "DeclKindEq"
+	case DeclKindDistinct:
+		return "DeclKindDistinct"
+	case DeclKindITE:
+		return "DeclKindITE"
+	case DeclKindAnd:
+		return "DeclKindAnd"
+	case DeclKindOr:
+		return "DeclKindOr"
+	case DeclKindIff:
+		return "DeclKindIff"
+	case DeclKindXor:
+		return "DeclKindXor"
+	case DeclKindNot:
+		return "DeclKindNot"
+	case DeclKindImplies:
+		return "DeclKindImplies"
+	case DeclKindANum:
+		return "DeclKindANum"
+	case DeclKindLE:
+		return "DeclKindLE"
+	case DeclKindGE:
+		return "DeclKindGE"
+	case DeclKindLT:
+		return "DeclKindLT"
+	case DeclKindGT:
+		return "DeclKindGT"
+	case DeclKindAdd:
+		return "DeclKindAdd"
+	case DeclKindSub:
+		return "DeclKindSub"
+	case DeclKindUMinus:
+		return "DeclKindUMinus"
+	case DeclKindMul:
+		return "DeclKindMul"
+	case DeclKindDiv:
+		return "DeclKindDiv"
+	case DeclKindIDiv:
+		return "DeclKindIDiv"
+	case DeclKindRem:
+		return "DeclKindRem"
+	case DeclKindMod:
+		return "DeclKindMod"
+	case DeclKindToReal:
+		return "DeclKindToReal"
+	case DeclKindToInt:
+		return "DeclKindToInt"
+	case DeclKindIsInt:
+		return "DeclKindIsInt"
+	case DeclKindPower:
+		return "DeclKindPower"
+	case DeclKindBNum:
+		return "DeclKindBNum"
+	case DeclKindBNeg:
+		return "DeclKindBNeg"
+	case DeclKindBAdd:
+		return "DeclKindBAdd"
+	case DeclKindBSub:
+		return "DeclKindBSub"
+	case DeclKindBMul:
+		return "DeclKindBMul"
+	case DeclKindBSDiv:
+		return "DeclKindBSDiv"
+	case DeclKindBUDiv:
+		return "DeclKindBUDiv"
+	case DeclKindBSRem:
+		return "DeclKindBSRem"
+	case DeclKindBURem:
+		return "DeclKindBURem"
+	case DeclKindBSMod:
+		return "DeclKindBSMod"
+	case DeclKindBSDiv0:
+		return "DeclKindBSDiv0"
+	case DeclKindBUDiv0:
+		return "DeclKindBUDiv0"
+	case DeclKindBSRem0:
+		return "DeclKindBSRem0"
+	case DeclKindBURem0:
+		return "DeclKindBURem0"
+	case DeclKindBSMod0:
+		return "DeclKindBSMod0"
+	case DeclKindBSDivI:
+		return "DeclKindBSDivI"
+	case DeclKindBUDivI:
+		return "DeclKindBUDivI"
+	case DeclKindBSRemI:
+		return "DeclKindBSRemI"
+	case DeclKindBURemI:
+		return "DeclKindBURemI"
+	case DeclKindBSModI:
+		return "DeclKindBSModI"
+	case DeclKindULEQ:
+		return "DeclKindULEQ"
+	case DeclKindSLEQ:
+		return "DeclKindSLEQ"
+	case DeclKindUGEQ:
+		return "DeclKindUGEQ"
+	case DeclKindSGEQ:
+		return "DeclKindSGEQ"
+	case DeclKindULT:
+		return "DeclKindULT"
+	case DeclKindSLT:
+		return "DeclKindSLT"
+	case DeclKindUGT:
+		return "DeclKindUGT"
+	case DeclKindSGT:
+		return "DeclKindSGT"
+	case DeclKindBAnd:
+		return "DeclKindBAnd"
+	case DeclKindBOr:
+		return "DeclKindBOr"
+	case DeclKindBNot:
+		return "DeclKindBNot"
+	case DeclKindBXor:
+		return "DeclKindBXor"
+	case DeclKindBNand:
+		return "DeclKindBNand"
+	case DeclKindBNor:
+		return "DeclKindBNor"
+	case DeclKindBXnor:
+		return "DeclKindBXnor"
+	case DeclKindConcat:
+		return "DeclKindConcat"
+	case DeclKindSignExt:
+		return "DeclKindSignExt"
+	case DeclKindZeroExt:
+		return "DeclKindZeroExt"
+	case DeclKindExtract:
+		return "DeclKindExtract"
+	case DeclKindRepeat:
+		return "DeclKindRepeat"
+	case DeclKindBRedOr:
+		return "DeclKindBRedOr"
+	case DeclKindBRedAnd:
+		return "DeclKindBRedAnd"
+	case DeclKindBComp:
+		return "DeclKindBComp"
+	case DeclKindBShl:
+		return "DeclKindBShl"
+	case DeclKindBLShr:
+		return "DeclKindBLShr"
+	case DeclKindBAShr:
+		return "DeclKindBAShr"
+	case DeclKindRotateLeft:
+		return "DeclKindRotateLeft"
+	case DeclKindRotateRight:
+		return "DeclKindRotateRight"
+	case DeclKindExtRotateLeft:
+		return "DeclKindExtRotateLeft"
+	case DeclKindExtRotateRight:
+		return "DeclKindExtRotateRight"
+	case DeclKindInt2BV:
+		return "DeclKindInt2BV"
+	case DeclKindBV2Int:
+		return "DeclKindBV2Int"
+	case DeclKindSelect:
+		return "DeclKindSelect"
+	case DeclKindStore:
+		return "DeclKindStore"
+	case DeclKindConstArray:
+		return "DeclKindConstArray"
+	case DeclKindAsArray:
+		return "DeclKindAsArray"
+	case DeclKindDTConstructor:
+		return "DeclKindDTConstructor"
+	case DeclKindDTAccessor:
+		return "DeclKindDTAccessor"
+	case DeclKindSeqUnit:
+		return "DeclKindSeqUnit"
+	case DeclKindSeqEmpty:
+		return "DeclKindSeqEmpty"
+	case DeclKindSeqConcat:
+		return "DeclKindSeqConcat"
+	case DeclKindSeqLength:
+		return "DeclKindSeqLength"
+	case DeclKindFPARoundNearestTiesToEven:
+		return "DeclKindFPARoundNearestTiesToEven"
+	case DeclKindFPARoundNearestTiesToAway:
+		return "DeclKindFPARoundNearestTiesToAway"
+	case DeclKindFPARoundTowardPositive:
+		return "DeclKindFPARoundTowardPositive"
+	case DeclKindFPARoundTowardNegative:
+		return "DeclKindFPARoundTowardNegative"
+	case DeclKindFPARoundTowardZero:
+		return "DeclKindFPARoundTowardZero"
+	case DeclKindFPANum:
+		return "DeclKindFPANum"
+	case DeclKindFPAPlusInf:
+		return "DeclKindFPAPlusInf"
+	case DeclKindFPAMinusInf:
+		return "DeclKindFPAMinusInf"
+	case DeclKindFPANaN:
+		return "DeclKindFPANaN"
+	case DeclKindFPAPlusZero:
+		return "DeclKindFPAPlusZero"
+	case DeclKindFPAMinusZero:
+		return "DeclKindFPAMinusZero"
+	case DeclKindFPAAdd:
+		return "DeclKindFPAAdd"
+	case DeclKindFPASub:
+		return "DeclKindFPASub"
+	case DeclKindFPANeg:
+		return "DeclKindFPANeg"
+	case DeclKindFPAMul:
+		return "DeclKindFPAMul"
+	case DeclKindFPADiv:
+		return "DeclKindFPADiv"
+	case DeclKindFPARem:
+		return "DeclKindFPARem"
+	case DeclKindFPAAbs:
+		return "DeclKindFPAAbs"
+	case DeclKindFPAMin:
+		return "DeclKindFPAMin"
+	case DeclKindFPAMax:
+		return "DeclKindFPAMax"
+	case DeclKindFPAFMA:
+		return "DeclKindFPAFMA"
+	case DeclKindFPASqrt:
+		return "DeclKindFPASqrt"
+	case DeclKindFPARoundToIntegral:
+		return "DeclKindFPARoundToIntegral"
+	case DeclKindFPAEq:
+		return "DeclKindFPAEq"
+	case DeclKindFPALT:
+		return "DeclKindFPALT"
+	case DeclKindFPAGT:
+		return "DeclKindFPAGT"
+	case DeclKindFPALE:
+		return "DeclKindFPALE"
+	case DeclKindFPAGE:
+		return "DeclKindFPAGE"
+	case DeclKindFPAIsNaN:
+		return "DeclKindFPAIsNaN"
+	case DeclKindFPAIsInf:
+		return "DeclKindFPAIsInf"
+	case DeclKindFPAIsZero:
+		return "DeclKindFPAIsZero"
+	case DeclKindFPAIsNormal:
+		return "DeclKindFPAIsNormal"
+	case DeclKindFPAIsSubnormal:
+		return "DeclKindFPAIsSubnormal"
+	case DeclKindFPAIsNegative:
+		return "DeclKindFPAIsNegative"
+	case DeclKindFPAIsPositive:
+		return "DeclKindFPAIsPositive"
+	case DeclKindFPAFP:
+		return "DeclKindFPAFP"
+	case DeclKindFPAToFP:
+		return "DeclKindFPAToFP"
+	case DeclKindFPAToFPUnsigned:
+		return "DeclKindFPAToFPUnsigned"
+	case DeclKindFPAToUBV:
+		return "DeclKindFPAToUBV"
+	case DeclKindFPAToSBV:
+		return "DeclKindFPAToSBV"
+	case DeclKindFPAToReal:
+		return "DeclKindFPAToReal"
+	case DeclKindFPAToIEEEBV:
+		return "DeclKindFPAToIEEEBV"
+	}
+	return "DeclKind(" + strconv.Itoa(int(k)) + ")"
+}
@@ -130,8 +130,25 @@ func (ctx *Context) FromBigInt(val *big.Int, sort Sort) Value {
 	return sval.lift(sort.Kind())
 }
 
-// TODO: FromBigFloat for real and float sorts (or maybe just float
-// sorts since we can deduce the sbits from the big.Float?).
+// FromBigFloat returns a literal whose value is val. sort must have
+// kind real or float.
+//
+// If sort is a floating-point sort and val cannot be represented
+// exactly in sort, the result is rounded according to the current
+// rounding mode. If sort is the real sort, val must be finite.
+func (ctx *Context) FromBigFloat(val *big.Float, sort Sort) Value {
+	switch sort.Kind() {
+	case KindFloatingPoint:
+		return ctx.floatFromBigFloat(val, sort)
+	case KindReal:
+		if val.IsInf() {
+			panic("cannot represent " + val.String() + " as a real")
+		}
+		rat, _ := val.Rat(nil)
+		return ctx.FromBigRat(rat)
+	}
+	panic("sort " + sort.String() + " cannot represent a big.Float")
+}
 
 // FromInt returns a literal whose value is val. sort must have kind
 // int, real, finite-domain, bit-vector, or float.
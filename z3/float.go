@@ -5,6 +5,7 @@
 package z3
 
 import (
+	"math"
 	"math/big"
 	"runtime"
 )
@@ -58,6 +59,10 @@ func init() {
 //     Single precision     8    24  (float32)
 //     Double precision    11    53  (float64)
 //     Quad precision      15   113
+//     bfloat16             8     8
+//
+// Float16Sort, Float32Sort, Float64Sort, Float128Sort, and
+// BFloat16Sort return these common sorts.
 func (ctx *Context) FloatSort(ebits, sbits int) Sort {
 	var sort Sort
 	ctx.do(func() {
@@ -66,6 +71,36 @@ func (ctx *Context) FloatSort(ebits, sbits int) Sort {
 	return sort
 }
 
+// Float16Sort returns the IEEE 754-2008 binary16 (half precision)
+// floating-point sort.
+func (ctx *Context) Float16Sort() Sort {
+	return ctx.FloatSort(5, 11)
+}
+
+// Float32Sort returns the IEEE 754-2008 binary32 (single precision)
+// floating-point sort. This is equivalent to Go's float32.
+func (ctx *Context) Float32Sort() Sort {
+	return ctx.FloatSort(8, 24)
+}
+
+// Float64Sort returns the IEEE 754-2008 binary64 (double precision)
+// floating-point sort. This is equivalent to Go's float64.
+func (ctx *Context) Float64Sort() Sort {
+	return ctx.FloatSort(11, 53)
+}
+
+// Float128Sort returns the IEEE 754-2008 binary128 (quad precision)
+// floating-point sort.
+func (ctx *Context) Float128Sort() Sort {
+	return ctx.FloatSort(15, 113)
+}
+
+// BFloat16Sort returns the "brain floating-point" sort, which has the
+// same exponent range as float32, but only 8 significand bits.
+func (ctx *Context) BFloat16Sort() Sort {
+	return ctx.FloatSort(8, 8)
+}
+
 // RoundingMode represents a floating-point rounding mode.
 //
 // The zero value of RoundingMode is RoundToNearestEven, which is the
@@ -444,6 +479,113 @@ exact:
 	return ctx.FloatFromBits(bvSign, bvExp, bvSig)
 }
 
+func (ctx *Context) floatFromBigFloat(val *big.Float, sort Sort) Float {
+	if val.IsInf() {
+		return ctx.FloatInf(sort, val.Signbit())
+	}
+	if val.Sign() == 0 {
+		return ctx.FloatZero(sort, val.Signbit())
+	}
+	ebits, sbits := sort.FloatSize()
+	bias := 1<<uint(ebits-1) - 1
+	emin, emax := 1-bias, bias
+	neg := val.Signbit()
+
+	// Compute the exponent of the least significant bit we can
+	// represent. Subnormal numbers have the same least significant
+	// bit as the smallest normal number, but fewer significant
+	// bits.
+	exp := val.MantExp(nil) - 1
+	if exp < emin {
+		exp = emin
+	}
+	lsb := exp - (sbits - 1)
+
+	// Scale val so the least significant bit is in the ones
+	// place and round it to an integer significand. Scaling a
+	// big.Float by a power of two is exact.
+	var x big.Float
+	x.Abs(val)
+	x.SetMantExp(&x, -lsb)
+	sig, _ := x.Int(nil)
+	var frac big.Float
+	if frac.Sub(&x, new(big.Float).SetInt(sig)).Sign() != 0 {
+		// It's not exact, so round it.
+		up := false
+		switch ctx.RoundingMode() {
+		case RoundToNearestEven:
+			switch frac.Cmp(big.NewFloat(0.5)) {
+			case 0:
+				up = sig.Bit(0) != 0
+			case 1:
+				up = true
+			}
+		case RoundToNearestAway:
+			up = frac.Cmp(big.NewFloat(0.5)) >= 0
+		case RoundToPositive:
+			up = !neg
+		case RoundToNegative:
+			up = neg
+		case RoundToZero:
+		}
+		if up {
+			sig.Add(sig, big.NewInt(1))
+		}
+	}
+	if sig.BitLen() > sbits {
+		// Rounding carried into a new bit. sig is now exactly
+		// a power of two, so we can shift it down without
+		// losing anything.
+		sig.Rsh(sig, 1)
+		lsb++
+	}
+	if sig.Sign() == 0 {
+		// Underflow to zero.
+		return ctx.FloatZero(sort, neg)
+	}
+
+	// Compute the exponent and check for overflow.
+	exp = lsb + sig.BitLen() - 1
+	if exp > emax {
+		inf := true
+		switch ctx.RoundingMode() {
+		case RoundToPositive:
+			inf = !neg
+		case RoundToNegative:
+			inf = neg
+		case RoundToZero:
+			inf = false
+		}
+		if inf {
+			return ctx.FloatInf(sort, neg)
+		}
+		// Round to the largest finite value.
+		exp = emax
+		sig.Lsh(big.NewInt(1), uint(sbits))
+		sig.Sub(sig, big.NewInt(1))
+	}
+
+	// Construct the biased exponent bits and clear the hidden
+	// significand bit. Subnormal numbers have a 0 exponent and
+	// no hidden bit.
+	var bexp int
+	if sig.BitLen() == sbits {
+		bexp = exp + bias
+		sig.SetBit(sig, sbits-1, 0)
+	}
+
+	// Construct the bit-vector components.
+	var bvSign BV
+	if neg {
+		bvSign = ctx.FromInt(1, ctx.BVSort(1)).(BV)
+	} else {
+		bvSign = ctx.FromInt(0, ctx.BVSort(1)).(BV)
+	}
+	bvExp := ctx.FromInt(int64(bexp), ctx.BVSort(ebits)).(BV)
+	bvSig := ctx.FromBigInt(sig, ctx.BVSort(sbits-1)).(BV)
+	return ctx.FloatFromBits(bvSign, bvExp, bvSig)
+}
+
 // AsBigFloat returns the value of lit as a math/big.Float. If lit is
 // not a literal, it returns nil, false. If lit is NaN, it returns
 // nil, true (because big.Float cannot represent NaN).
@@ -478,12 +620,50 @@ func (lit Float) AsBigFloat() (val *big.Float, isLiteral bool) {
 		out.Parse("-inf", 10)
 	case lit.isAppOf(C.Z3_OP_FPA_NAN):
 		return nil, true
+	case lit.isAppOf(C.Z3_OP_FPA_FP):
+		// This may be constructed from literal bit-vectors
+		// (e.g., by FloatFromBits). Simplification will fold
+		// those into a literal.
+		lit2 := lit.ctx.Simplify(lit, nil).(Float)
+		if lit2.isAppOf(C.Z3_OP_FPA_FP) {
+			return nil, false
+		}
+		return lit2.AsBigFloat()
 	default:
 		return nil, false
 	}
 	return &out, true
 }
 
+// AsFloat64 returns the value of lit as a float64. If lit is not a
+// literal, it returns 0, false, false. If lit cannot be represented
+// exactly as a float64, it is rounded to the nearest float64 and
+// AsFloat64 returns false for exact.
+func (lit Float) AsFloat64() (val float64, isLiteral, exact bool) {
+	f, isLiteral := lit.AsBigFloat()
+	if !isLiteral {
+		return 0, false, false
+	}
+	if f == nil {
+		return math.NaN(), true, true
+	}
+	val, acc := f.Float64()
+	return val, true, acc == big.Exact
+}
+
+// AsFloat32 is like AsFloat64, but returns a float32.
+func (lit Float) AsFloat32() (val float32, isLiteral, exact bool) {
+	f, isLiteral := lit.AsBigFloat()
+	if !isLiteral {
+		return 0, false, false
+	}
+	if f == nil {
+		return float32(math.NaN()), true, true
+	}
+	val, acc := f.Float32()
+	return val, true, acc == big.Exact
+}
+
 //go:generate go run genwrap.go -t Float $GOFILE
 
 // Abs returns the absolute value of l.
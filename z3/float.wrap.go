@@ -29,7 +29,7 @@ func (l Float) NE(r Float) Bool {
 
 // Abs returns the absolute value of l.
 func (l Float) Abs() Float {
-	// Generated from float.go:499.
+	// Generated from float.go:671.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_abs(ctx.c, l.c)
@@ -40,7 +40,7 @@ func (l Float) Abs() Float {
 
 // Neg returns -l.
 func (l Float) Neg() Float {
-	// Generated from float.go:503.
+	// Generated from float.go:675.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_neg(ctx.c, l.c)
@@ -53,7 +53,7 @@ func (l Float) Neg() Float {
 //
 // Add uses the current rounding mode.
 func (l Float) Add(r Float) Float {
-	// Generated from float.go:509.
+	// Generated from float.go:681.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -68,7 +68,7 @@ func (l Float) Add(r Float) Float {
 //
 // Sub uses the current rounding mode.
 func (l Float) Sub(r Float) Float {
-	// Generated from float.go:515.
+	// Generated from float.go:687.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -83,7 +83,7 @@ func (l Float) Sub(r Float) Float {
 //
 // Mul uses the current rounding mode.
 func (l Float) Mul(r Float) Float {
-	// Generated from float.go:521.
+	// Generated from float.go:693.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -98,7 +98,7 @@ func (l Float) Mul(r Float) Float {
 //
 // Div uses the current rounding mode.
 func (l Float) Div(r Float) Float {
-	// Generated from float.go:527.
+	// Generated from float.go:699.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -114,7 +114,7 @@ func (l Float) Div(r Float) Float {
 // MulAdd uses the current rounding mode on the result of the whole
 // operation.
 func (l Float) MulAdd(r Float, a Float) Float {
-	// Generated from float.go:534.
+	// Generated from float.go:706.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -130,7 +130,7 @@ func (l Float) MulAdd(r Float, a Float) Float {
 //
 // Sqrt uses the current rounding mode.
 func (l Float) Sqrt() Float {
-	// Generated from float.go:540.
+	// Generated from float.go:712.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -142,7 +142,7 @@ func (l Float) Sqrt() Float {
 
 // Rem returns the remainder of l/r.
 func (l Float) Rem(r Float) Float {
-	// Generated from float.go:544.
+	// Generated from float.go:716.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_rem(ctx.c, l.c, r.c)
@@ -155,7 +155,7 @@ func (l Float) Rem(r Float) Float {
 // Round rounds l to an integral floating-point value according to
 // rounding mode rm.
 func (l Float) Round(rm RoundingMode) Float {
-	// Generated from float.go:549.
+	// Generated from float.go:721.
 	ctx := l.ctx
 	rmc := rm.ast(ctx)
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -168,7 +168,7 @@ func (l Float) Round(rm RoundingMode) Float {
 
 // Min returns the minimum of l and r.
 func (l Float) Min(r Float) Float {
-	// Generated from float.go:553.
+	// Generated from float.go:725.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_min(ctx.c, l.c, r.c)
@@ -180,7 +180,7 @@ func (l Float) Min(r Float) Float {
 
 // Max returns the maximum of l and r.
 func (l Float) Max(r Float) Float {
-	// Generated from float.go:557.
+	// Generated from float.go:729.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_max(ctx.c, l.c, r.c)
@@ -196,7 +196,7 @@ func (l Float) Max(r Float) Float {
 // contrast, under IEEE equality, ±0 == ±0, while NaN != NaN and ±inf
 // != ±inf.
 func (l Float) IEEEEq(r Float) Bool {
-	// Generated from float.go:565.
+	// Generated from float.go:737.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_eq(ctx.c, l.c, r.c)
@@ -208,7 +208,7 @@ func (l Float) IEEEEq(r Float) Bool {
 
 // LT returns l < r.
 func (l Float) LT(r Float) Bool {
-	// Generated from float.go:569.
+	// Generated from float.go:741.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_lt(ctx.c, l.c, r.c)
@@ -220,7 +220,7 @@ func (l Float) LT(r Float) Bool {
 
 // LE returns l <= r.
 func (l Float) LE(r Float) Bool {
-	// Generated from float.go:573.
+	// Generated from float.go:745.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_leq(ctx.c, l.c, r.c)
@@ -232,7 +232,7 @@ func (l Float) LE(r Float) Bool {
 
 // GT returns l > r.
 func (l Float) GT(r Float) Bool {
-	// Generated from float.go:577.
+	// Generated from float.go:749.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_gt(ctx.c, l.c, r.c)
@@ -244,7 +244,7 @@ func (l Float) GT(r Float) Bool {
 
 // GE returns l >= r.
 func (l Float) GE(r Float) Bool {
-	// Generated from float.go:581.
+	// Generated from float.go:753.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_geq(ctx.c, l.c, r.c)
@@ -256,7 +256,7 @@ func (l Float) GE(r Float) Bool {
 
 // IsNormal returns true if l is a normal floating-point number.
 func (l Float) IsNormal() Bool {
-	// Generated from float.go:585.
+	// Generated from float.go:757.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_normal(ctx.c, l.c)
@@ -267,7 +267,7 @@ func (l Float) IsNormal() Bool {
 
 // IsSubnormal returns true if l is a subnormal floating-point number.
 func (l Float) IsSubnormal() Bool {
-	// Generated from float.go:589.
+	// Generated from float.go:761.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_subnormal(ctx.c, l.c)
@@ -278,7 +278,7 @@ func (l Float) IsSubnormal() Bool {
 
 // IsZero returns true if l is ±0.
 func (l Float) IsZero() Bool {
-	// Generated from float.go:593.
+	// Generated from float.go:765.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_zero(ctx.c, l.c)
@@ -289,7 +289,7 @@ func (l Float) IsZero() Bool {
 
 // IsInfinite returns true if l is ±∞.
 func (l Float) IsInfinite() Bool {
-	// Generated from float.go:597.
+	// Generated from float.go:769.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_infinite(ctx.c, l.c)
@@ -300,7 +300,7 @@ func (l Float) IsInfinite() Bool {
 
 // IsNaN returns true if l is NaN.
 func (l Float) IsNaN() Bool {
-	// Generated from float.go:601.
+	// Generated from float.go:773.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_nan(ctx.c, l.c)
@@ -311,7 +311,7 @@ func (l Float) IsNaN() Bool {
 
 // IsNegative returns true if l is negative.
 func (l Float) IsNegative() Bool {
-	// Generated from float.go:605.
+	// Generated from float.go:777.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_negative(ctx.c, l.c)
@@ -322,7 +322,7 @@ func (l Float) IsNegative() Bool {
 
 // IsPositive returns true if l is positive.
 func (l Float) IsPositive() Bool {
-	// Generated from float.go:609.
+	// Generated from float.go:781.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_positive(ctx.c, l.c)
@@ -337,7 +337,7 @@ func (l Float) IsPositive() Bool {
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Float) ToFloat(s Sort) Float {
-	// Generated from float.go:617.
+	// Generated from float.go:789.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -354,7 +354,7 @@ func (l Float) ToFloat(s Sort) Float {
 // If the result is not in the range [0, 2^bits-1], the result is
 // unspecified.
 func (l Float) ToUBV(bits int) BV {
-	// Generated from float.go:625.
+	// Generated from float.go:797.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -370,7 +370,7 @@ func (l Float) ToUBV(bits int) BV {
 // If the result is not in the range [-2^(bits-1), 2^(bits-1)-1], the
 // result is unspecified.
 func (l Float) ToSBV(bits int) BV {
-	// Generated from float.go:633.
+	// Generated from float.go:805.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -384,7 +384,7 @@ func (l Float) ToSBV(bits int) BV {
 //
 // If l is ±inf, or NaN, the result is unspecified.
 func (l Float) ToReal() Real {
-	// Generated from float.go:639.
+	// Generated from float.go:811.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_real(ctx.c, l.c)
@@ -398,7 +398,7 @@ func (l Float) ToReal() Real {
 // Note that NaN has many possible representations. This conversion
 // always uses the same representation.
 func (l Float) ToIEEEBV() BV {
-	// Generated from float.go:646.
+	// Generated from float.go:818.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_ieee_bv(ctx.c, l.c)
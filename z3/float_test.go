@@ -119,3 +119,65 @@ func TestFloatAsBigFloat(t *testing.T) {
 		}
 	}
 }
+
+func TestFloatFromBigFloat(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.Float64Sort()
+	for _, test := range []float64{0, math.Copysign(0, -1), 1, -1, 42, 0.1,
+		math.Inf(1), math.Inf(-1),
+		math.MaxFloat64, -math.MaxFloat64,
+		math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64,
+		0x1p-1022, 0x1.fffffffffffffp-1023} {
+		got := ctx.FromBigFloat(big.NewFloat(test), s).(Float)
+		want := ctx.FromFloat64(test, s)
+		if !simplifyBool(t, ctx, got.Eq(want)) {
+			t.Errorf("FromBigFloat(%v) = %s, want %s", test, got, want)
+		}
+		if val, isLit, exact := got.AsFloat64(); val != test || math.Signbit(val) != math.Signbit(test) || !isLit || !exact {
+			t.Errorf("(%s).AsFloat64() = %v, %v, %v, want %v, true, true", got, val, isLit, exact, test)
+		}
+	}
+}
+
+func TestFloatFromBigFloatRounding(t *testing.T) {
+	// Test that our implementation of rounding of big.Floats to
+	// fit in too-small floats matches Z3's, including subnormals
+	// and overflow.
+	ctx := NewContext(nil)
+	s := ctx.FloatSort(3, 4)
+	for rm := RoundingMode(0); rm < roundingModesNum; rm++ {
+		ctx.SetRoundingMode(rm)
+		for _, sign := range []int64{1, -1} {
+			for x := int64(0); x < 512; x++ {
+				val := new(big.Float).SetMantExp(big.NewFloat(float64(x*sign)), -6)
+				got := ctx.FromBigFloat(val, s).(Float)
+
+				// Round it using Z3.
+				rat, _ := val.Rat(nil)
+				want := ctx.Simplify(ctx.FromBigRat(rat).ToFloat(s), nil).(Float)
+
+				if !simplifyBool(t, ctx, got.Eq(want)) && !(x == 0 && sign < 0) {
+					t.Errorf("%v as float[3, 4] with rounding mode %v: want %s, got %s", val, rm, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestFloatAsFloat32(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.Float32Sort()
+	for _, test := range []float32{0, 1, -42, math.MaxFloat32, math.SmallestNonzeroFloat32} {
+		val, isLit, exact := ctx.FromFloat32(test, s).AsFloat32()
+		if val != test || !isLit || !exact {
+			t.Errorf("AsFloat32(%v) = %v, %v, %v, want %v, true, true", test, val, isLit, exact, test)
+		}
+	}
+
+	// Check inexact conversion.
+	f := ctx.FromFloat64(0.1, ctx.Float64Sort())
+	val, isLit, exact := f.AsFloat32()
+	if val != 0.1 || !isLit || exact {
+		t.Errorf("AsFloat32(%s) = %v, %v, %v, want 0.1, true, false", f, val, isLit, exact)
+	}
+}
@@ -5,6 +5,7 @@
 package z3
 
 import (
+	"fmt"
 	"runtime"
 	"unsafe"
 )
@@ -39,7 +40,7 @@ func wrapFuncDecl(ctx *Context, c C.Z3_func_decl) FuncDecl {
 	impl := &funcDeclImpl{ctx, c}
 	C.Z3_inc_ref(ctx.c, C.Z3_func_decl_to_ast(ctx.c, c))
 	runtime.SetFinalizer(impl, func(impl *funcDeclImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_dec_ref(impl.ctx.c, C.Z3_func_decl_to_ast(impl.ctx.c, impl.c))
 		})
 	})
@@ -93,6 +94,54 @@ func (ctx *Context) FreshFuncDecl(prefix string, domain []Sort, range_ Sort) Fun
 	return funcdecl
 }
 
+// RecFuncDecl creates a recursive function named "name".
+//
+// Unlike an uninterpreted function, a recursive function has a fixed
+// interpretation given by its definition, which may refer to the
+// function itself. This definition must be supplied with DefineRec
+// before the function is used.
+func (ctx *Context) RecFuncDecl(name string, domain []Sort, range_ Sort) FuncDecl {
+	sym := ctx.symbol(name)
+	cdomain := make([]C.Z3_sort, len(domain))
+	for i, sort := range domain {
+		cdomain[i] = sort.c
+	}
+	var funcdecl FuncDecl
+	ctx.do(func() {
+		var cdp *C.Z3_sort
+		if len(cdomain) > 0 {
+			cdp = &cdomain[0]
+		}
+		funcdecl = wrapFuncDecl(ctx, C.Z3_mk_rec_func_decl(ctx.c, sym, C.uint(len(cdomain)), cdp, range_.c))
+	})
+	runtime.KeepAlive(domain)
+	runtime.KeepAlive(range_)
+	return funcdecl
+}
+
+// DefineRec defines recursive function f, which must have been
+// created by RecFuncDecl, to be body.
+//
+// args are the formal parameters of f. They must be constants with
+// the sorts of f's domain and body may refer to them. body may also
+// apply f recursively.
+func (f FuncDecl) DefineRec(args []Value, body Value) {
+	cargs := make([]C.Z3_ast, len(args))
+	for i, arg := range args {
+		cargs[i] = arg.impl().c
+	}
+	f.ctx.do(func() {
+		var cap *C.Z3_ast
+		if len(cargs) > 0 {
+			cap = &cargs[0]
+		}
+		C.Z3_add_rec_def(f.ctx.c, f.c, C.uint(len(cargs)), cap, body.impl().c)
+	})
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(args)
+	runtime.KeepAlive(body)
+}
+
 // Context returns the Context that created f.
 func (f FuncDecl) Context() *Context {
 	if f.funcDeclImpl == nil {
@@ -111,6 +160,78 @@ func (f FuncDecl) String() string {
 	return res
 }
 
+// Name returns the name of f, such as "bvadd" or the name passed to
+// Context.FuncDecl.
+func (f FuncDecl) Name() string {
+	var res string
+	f.ctx.do(func() {
+		sym := C.Z3_get_decl_name(f.ctx.c, f.c)
+		res = C.GoString(C.Z3_get_symbol_string(f.ctx.c, sym))
+	})
+	runtime.KeepAlive(f)
+	return res
+}
+
+// IntParams returns the integer parameters of f. For example, the
+// parameters of an extract are the high and low bits, and the
+// parameter of a sign or zero extension is the number of added bits.
+//
+// It panics if f has a parameter that is not an integer.
+func (f FuncDecl) IntParams() []int {
+	var res []int
+	bad := -1
+	f.ctx.do(func() {
+		n := C.Z3_get_decl_num_parameters(f.ctx.c, f.c)
+		res = make([]int, n)
+		for i := C.uint(0); i < n; i++ {
+			if C.Z3_get_decl_parameter_kind(f.ctx.c, f.c, i) != C.Z3_PARAMETER_INT {
+				bad = int(i)
+				return
+			}
+			res[i] = int(C.Z3_get_decl_int_parameter(f.ctx.c, f.c, i))
+		}
+	})
+	runtime.KeepAlive(f)
+	if bad >= 0 {
+		panic(fmt.Sprintf("parameter %d of %s is not an integer", bad, f.Name()))
+	}
+	return res
+}
+
+// Domain returns the sorts of f's arguments.
+func (f FuncDecl) Domain() []Sort {
+	var res []Sort
+	f.ctx.do(func() {
+		n := C.Z3_get_domain_size(f.ctx.c, f.c)
+		res = make([]Sort, n)
+		for i := C.uint(0); i < n; i++ {
+			res[i] = wrapSort(f.ctx, C.Z3_get_domain(f.ctx.c, f.c, i), KindUnknown)
+		}
+	})
+	runtime.KeepAlive(f)
+	return res
+}
+
+// Range returns the sort of f's result.
+func (f FuncDecl) Range() Sort {
+	var res Sort
+	f.ctx.do(func() {
+		res = wrapSort(f.ctx, C.Z3_get_range(f.ctx.c, f.c), KindUnknown)
+	})
+	runtime.KeepAlive(f)
+	return res
+}
+
+// Kind returns the kind of f.
+func (f FuncDecl) Kind() DeclKind {
+	var res DeclKind
+	f.ctx.do(func() {
+		res = DeclKind(C.Z3_get_decl_kind(f.ctx.c, f.c))
+	})
+	runtime.KeepAlive(f)
+	return res
+}
+
 // AsAST returns the AST representation of f.
 func (f FuncDecl) AsAST() AST {
 	var ast AST
@@ -22,3 +22,65 @@ func TestFuncDecl(t *testing.T) {
 		t.Errorf("%s satisfiable: %s", s, err)
 	}
 }
+
+func TestRecFuncDecl(t *testing.T) {
+	// Define factorial recursively.
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	fact := ctx.RecFuncDecl("fact", []Sort{ints}, ints)
+	n := ctx.Const("n", ints).(Int)
+	zero, one := ctx.FromInt(0, ints).(Int), ctx.FromInt(1, ints).(Int)
+	fact.DefineRec([]Value{n}, n.LE(zero).IfThenElse(one, n.Mul(fact.Apply(n.Sub(one)).(Int))))
+
+	s := NewSolver(ctx)
+	x := ctx.IntConst("x")
+	s.Assert(fact.Apply(x).(Int).Eq(ctx.FromInt(120, ints).(Int)))
+	s.Assert(x.GT(zero))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %s", s, err)
+	}
+	if val, _, _ := s.Model().Eval(x, true).(Int).AsInt64(); val != 5 {
+		t.Errorf("want x = 5, got %d", val)
+	}
+}
+
+func TestFuncDeclKind(t *testing.T) {
+	ctx := NewContext(nil)
+	f := ctx.FuncDecl("f", []Sort{ctx.IntSort()}, ctx.IntSort())
+	if got := f.Kind(); got != DeclKindUninterpreted {
+		t.Errorf("Kind() = %v, want DeclKindUninterpreted", got)
+	}
+	if got := f.Name(); got != "f" {
+		t.Errorf("Name() = %q, want \"f\"", got)
+	}
+	if got := f.IntParams(); len(got) != 0 {
+		t.Errorf("IntParams() = %v, want []", got)
+	}
+
+	x := ctx.BVConst("x", 32)
+	ext := x.Extract(15, 8).AsAST().Decl()
+	if got := ext.Kind(); got != DeclKindExtract {
+		t.Errorf("Kind() = %v, want DeclKindExtract", got)
+	}
+	if got := ext.Name(); got != "extract" {
+		t.Errorf("Name() = %q, want \"extract\"", got)
+	}
+	if got := ext.IntParams(); len(got) != 2 || got[0] != 15 || got[1] != 8 {
+		t.Errorf("IntParams() = %v, want [15 8]", got)
+	}
+	if got := DeclKindBAdd.String(); got != "DeclKindBAdd" {
+		t.Errorf("String() = %q, want \"DeclKindBAdd\"", got)
+	}
+}
+
+func TestFuncDeclDomain(t *testing.T) {
+	ctx := NewContext(nil)
+	f := ctx.FuncDecl("f", []Sort{ctx.IntSort(), ctx.BVSort(8)}, ctx.BoolSort())
+	dom := f.Domain()
+	if len(dom) != 2 || dom[0].Kind() != KindInt || dom[1].BVSize() != 8 {
+		t.Errorf("Domain() = %v, want [Int (_ BitVec 8)]", dom)
+	}
+	if r := f.Range(); r.Kind() != KindBool {
+		t.Errorf("Range() = %v, want Bool", r)
+	}
+}
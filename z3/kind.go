@@ -29,6 +29,7 @@ const (
 	KindFiniteDomain  = Kind(C.Z3_FINITE_DOMAIN_SORT)
 	KindFloatingPoint = Kind(C.Z3_FLOATING_POINT_SORT)
 	KindRoundingMode  = Kind(C.Z3_ROUNDING_MODE_SORT)
+	KindSeq           = Kind(C.Z3_SEQ_SORT)
 	KindUnknown       = Kind(C.Z3_UNKNOWN_SORT)
 )
 
@@ -57,6 +58,8 @@ func (k Kind) String() string {
 		return "KindFloatingPoint"
 	case KindRoundingMode:
 		return "KindRoundingMode"
+	case KindSeq:
+		return "KindSeq"
 	case KindUnknown:
 		return "KindUnknown"
 	}
@@ -0,0 +1,868 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"runtime"
+	"strings"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// Values, Sorts, FuncDecls, and Models can be marshaled to JSON with
+// MarshalJSON or to a more compact binary form with MarshalBinary and
+// unmarshaled into any Context with UnmarshalValue, UnmarshalSort,
+// UnmarshalFuncDecl, and UnmarshalModel, which accept either form.
+//
+// Both forms encode a graph of three tables: sorts, function
+// declarations, and AST nodes. Each table entry refers to other
+// entries by their index, and only to earlier entries, so shared
+// subterms are encoded once. Sort and declaration kinds are encoded
+// by name, such as "BV" for KindBV and "BAdd" for DeclKindBAdd, so
+// the encoding does not depend on the Z3 version. In JSON, the
+// encoding of x+1 for an 8-bit bit-vector x is
+//
+//	{
+//	  "sorts": [{"kind": "BV", "size": 8}],
+//	  "decls": [
+//	    {"kind": "Uninterpreted", "name": "x", "range": 0},
+//	    {"kind": "BNum", "name": "bv", "range": 0},
+//	    {"kind": "BAdd", "name": "bvadd", "domain": [0, 0], "range": 0}
+//	  ],
+//	  "nodes": [
+//	    {"decl": 0},
+//	    {"decl": 1, "lit": "1"},
+//	    {"decl": 2, "args": [0, 1]}
+//	  ],
+//	  "value": 2
+//	}
+//
+// Quantifiers cannot be marshaled. Values of uninterpreted sorts in a
+// model, such as T!val!0, are unmarshaled as constants with the same
+// name, but the model's universe of the sort is lost.
+
+// graph is the marshaled form of a Value, Sort, FuncDecl, or Model.
+type graph struct {
+	Sorts []sortNode `json:"sorts,omitempty"`
+	Decls []declNode `json:"decls,omitempty"`
+	Nodes []astNode  `json:"nodes,omitempty"`
+
+	// Exactly one of the roots is set.
+	Value *int       `json:"value,omitempty"`
+	Sort  *int       `json:"sort,omitempty"`
+	Decl  *int       `json:"decl,omitempty"`
+	Model *modelNode `json:"model,omitempty"`
+}
+
+// sortNode is a marshaled Sort.
+type sortNode struct {
+	// Kind is the Kind without the "Kind" prefix, such as "BV".
+	Kind string `json:"kind"`
+
+	// Name is the name of an uninterpreted, finite-domain, or
+	// tuple sort.
+	Name string `json:"name,omitempty"`
+
+	// Size is the size of a bit-vector or finite-domain sort.
+	Size uint64 `json:"size,omitempty"`
+
+	// EBits and SBits are the sizes of a floating-point sort.
+	EBits int `json:"ebits,omitempty"`
+	SBits int `json:"sbits,omitempty"`
+
+	// Sorts are the domain and range of an array sort, the
+	// element sort of a sequence sort, or the field sorts of a
+	// tuple sort.
+	Sorts []int `json:"sorts,omitempty"`
+
+	// Fields are the field names of a tuple sort.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// declNode is a marshaled FuncDecl.
+type declNode struct {
+	// Kind is the DeclKind without the "DeclKind" prefix, such as
+	// "BAdd", or "Var" for a bound variable.
+	Kind string `json:"kind"`
+
+	// Name is the name of the function. Only uninterpreted
+	// functions are looked up by name.
+	Name string `json:"name,omitempty"`
+
+	// Params are the integer parameters of the function. For
+	// AsArray, Params[0] is the index of the array's function.
+	// For DTAccessor, it is the field index. For Var, it is the
+	// de Bruijn index of the variable.
+	Params []int `json:"params,omitempty"`
+
+	Domain []int `json:"domain,omitempty"`
+	Range  int   `json:"range"`
+}
+
+// astNode is a marshaled Value.
+type astNode struct {
+	Decl int   `json:"decl"`
+	Args []int `json:"args,omitempty"`
+
+	// Lit is the value of a numeral: a decimal integer, a
+	// fraction for reals, or a big.Float in 'p' format for
+	// floating-point numbers.
+	Lit string `json:"lit,omitempty"`
+}
+
+// modelNode is a marshaled Model.
+type modelNode struct {
+	Consts []constNode `json:"consts,omitempty"`
+	Funcs  []funcNode  `json:"funcs,omitempty"`
+}
+
+type constNode struct {
+	Decl  int `json:"decl"`
+	Value int `json:"value"`
+}
+
+type funcNode struct {
+	Decl    int         `json:"decl"`
+	Entries []entryNode `json:"entries,omitempty"`
+	Else    int         `json:"else"`
+}
+
+type entryNode struct {
+	Args  []int `json:"args"`
+	Value int   `json:"value"`
+}
+
+// marshalError is raised by encoders and decoders to abort.
+type marshalError struct {
+	err error
+}
+
+func marshalErrorf(format string, args ...interface{}) {
+	panic(marshalError{fmt.Errorf(format, args...)})
+}
+
+// catchMarshalError recovers a marshalError into *err.
+func catchMarshalError(err *error) {
+	if e := recover(); e != nil {
+		me, ok := e.(marshalError)
+		if !ok {
+			panic(e)
+		}
+		*err = me.err
+	}
+}
+
+// An encoder builds a graph.
+type encoder struct {
+	g     graph
+	sorts map[uint64]int
+	decls map[uint64]int
+	vars  map[[2]uint64]int
+	nodes map[uint64]int
+}
+
+// encode calls f with a new encoder and returns its graph.
+func encode(f func(e *encoder)) (g *graph, err error) {
+	defer catchMarshalError(&err)
+	e := &encoder{
+		sorts: make(map[uint64]int),
+		decls: make(map[uint64]int),
+		vars:  make(map[[2]uint64]int),
+		nodes: make(map[uint64]int),
+	}
+	f(e)
+	return &e.g, nil
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// MarshalJSON encodes the value as JSON.
+func (expr *valueImpl) MarshalJSON() ([]byte, error) {
+	return marshal(json.Marshal, func(e *encoder) {
+		e.g.Value = intPtr(e.value(expr.AsAST()))
+	})
+}
+
+// MarshalBinary encodes the value in binary.
+func (expr *valueImpl) MarshalBinary() ([]byte, error) {
+	return marshal(marshalBinaryGraph, func(e *encoder) {
+		e.g.Value = intPtr(e.value(expr.AsAST()))
+	})
+}
+
+// MarshalJSON encodes s as JSON.
+func (s Sort) MarshalJSON() ([]byte, error) {
+	return marshal(json.Marshal, func(e *encoder) {
+		e.g.Sort = intPtr(e.sort(s))
+	})
+}
+
+// MarshalBinary encodes s in binary.
+func (s Sort) MarshalBinary() ([]byte, error) {
+	return marshal(marshalBinaryGraph, func(e *encoder) {
+		e.g.Sort = intPtr(e.sort(s))
+	})
+}
+
+// MarshalJSON encodes f as JSON.
+func (f FuncDecl) MarshalJSON() ([]byte, error) {
+	return marshal(json.Marshal, func(e *encoder) {
+		e.g.Decl = intPtr(e.decl(f))
+	})
+}
+
+// MarshalBinary encodes f in binary.
+func (f FuncDecl) MarshalBinary() ([]byte, error) {
+	return marshal(marshalBinaryGraph, func(e *encoder) {
+		e.g.Decl = intPtr(e.decl(f))
+	})
+}
+
+// MarshalJSON encodes the interpretations in m as JSON.
+func (m *Model) MarshalJSON() ([]byte, error) {
+	return marshal(json.Marshal, func(e *encoder) {
+		e.model(m)
+	})
+}
+
+// MarshalBinary encodes the interpretations in m in binary.
+func (m *Model) MarshalBinary() ([]byte, error) {
+	return marshal(marshalBinaryGraph, func(e *encoder) {
+		e.model(m)
+	})
+}
+
+func marshal(format func(interface{}) ([]byte, error), f func(e *encoder)) ([]byte, error) {
+	g, err := encode(f)
+	if err != nil {
+		return nil, err
+	}
+	return format(g)
+}
+
+// sort adds s to the graph and returns its index.
+func (e *encoder) sort(s Sort) int {
+	id := s.AsAST().ID()
+	if i, ok := e.sorts[id]; ok {
+		return i
+	}
+	n := sortNode{Kind: strings.TrimPrefix(s.Kind().String(), "Kind")}
+	switch s.Kind() {
+	case KindBool, KindInt, KindReal, KindRoundingMode:
+	case KindBV:
+		n.Size = uint64(s.BVSize())
+	case KindFloatingPoint:
+		n.EBits, n.SBits = s.FloatSize()
+	case KindArray:
+		domain, range_ := s.DomainAndRange()
+		n.Sorts = []int{e.sort(domain), e.sort(range_)}
+	case KindSeq:
+		n.Sorts = []int{e.sort(s.SeqElem())}
+	case KindUninterpreted:
+		n.Name = s.name()
+	case KindFiniteDomain:
+		n.Name = s.name()
+		s.ctx.do(func() {
+			var size C.uint64_t
+			C.Z3_get_finite_domain_sort_size(s.ctx.c, s.c, &size)
+			n.Size = uint64(size)
+		})
+	case KindDatatype:
+		n.Name = s.name()
+		for i := 0; i < s.TupleNumFields(); i++ {
+			field := s.TupleField(i)
+			n.Fields = append(n.Fields, field.Name())
+			n.Sorts = append(n.Sorts, e.sort(field.Range()))
+		}
+	default:
+		marshalErrorf("cannot marshal sort %s", s)
+	}
+	i := len(e.g.Sorts)
+	e.g.Sorts = append(e.g.Sorts, n)
+	e.sorts[id] = i
+	return i
+}
+
+// name returns the name of s.
+func (s Sort) name() string {
+	var res string
+	s.ctx.do(func() {
+		sym := C.Z3_get_sort_name(s.ctx.c, s.c)
+		res = C.GoString(C.Z3_get_symbol_string(s.ctx.c, sym))
+	})
+	runtime.KeepAlive(s)
+	return res
+}
+
+// decl adds f to the graph and returns its index.
+func (e *encoder) decl(f FuncDecl) int {
+	id := f.AsAST().ID()
+	if i, ok := e.decls[id]; ok {
+		return i
+	}
+	kind := f.Kind()
+	if !buildable(kind) {
+		marshalErrorf("cannot marshal function %s", f)
+	}
+	n := declNode{Kind: strings.TrimPrefix(kind.String(), "DeclKind"), Name: f.Name()}
+	domain := f.Domain()
+	switch kind {
+	case DeclKindAsArray:
+		var array FuncDecl
+		f.ctx.do(func() {
+			array = wrapFuncDecl(f.ctx, C.Z3_get_decl_func_decl_parameter(f.ctx.c, f.c, 0))
+		})
+		n.Params = []int{e.decl(array)}
+	case DeclKindDTAccessor:
+		tuple := domain[0]
+		for i := 0; i < tuple.TupleNumFields(); i++ {
+			if tuple.TupleField(i).AsAST().Equal(f.AsAST()) {
+				n.Params = []int{i}
+			}
+		}
+	default:
+		// Other parameters, such as the values of numerals
+		// and the sorts of constant arrays, are implied by
+		// the node or the range.
+		if declArity[kind].params > 0 {
+			n.Params = f.IntParams()
+		}
+	}
+	for _, s := range domain {
+		n.Domain = append(n.Domain, e.sort(s))
+	}
+	n.Range = e.sort(f.Range())
+	i := len(e.g.Decls)
+	e.g.Decls = append(e.g.Decls, n)
+	e.decls[id] = i
+	return i
+}
+
+// value adds the value ast to the graph and returns its index.
+func (e *encoder) value(ast AST) int {
+	id := ast.ID()
+	if i, ok := e.nodes[id]; ok {
+		return i
+	}
+	var n astNode
+	switch kind := ast.Kind(); kind {
+	case ASTKindVar:
+		n.Decl = e.boundVar(ast)
+	case ASTKindApp, ASTKindNumeral:
+		for _, arg := range ast.Args() {
+			n.Args = append(n.Args, e.value(arg))
+		}
+		decl := ast.Decl()
+		n.Decl = e.decl(decl)
+		switch decl.Kind() {
+		case DeclKindANum:
+			switch v := ast.AsValue().(type) {
+			case Int:
+				x, _ := v.AsBigInt()
+				n.Lit = x.String()
+			case Real:
+				x, ok := v.AsBigRat()
+				if !ok {
+					marshalErrorf("cannot marshal irrational %s", v)
+				}
+				n.Lit = x.RatString()
+			}
+		case DeclKindBNum:
+			x, _ := ast.AsValue().(BV).AsBigUnsigned()
+			n.Lit = x.String()
+		case DeclKindFPANum:
+			x, _ := ast.AsValue().(Float).AsBigFloat()
+			n.Lit = x.Text('p', 0)
+		}
+	default:
+		marshalErrorf("cannot marshal AST of kind %s", kind)
+	}
+	i := len(e.g.Nodes)
+	e.g.Nodes = append(e.g.Nodes, n)
+	e.nodes[id] = i
+	return i
+}
+
+// boundVar adds a declaration for the bound variable ast and returns
+// its index.
+func (e *encoder) boundVar(ast AST) int {
+	var index C.uint
+	var sort Sort
+	ast.ctx.do(func() {
+		index = C.Z3_get_index_value(ast.ctx.c, ast.c)
+		sort = wrapSort(ast.ctx, C.Z3_get_sort(ast.ctx.c, ast.c), KindUnknown)
+	})
+	runtime.KeepAlive(ast)
+	s := e.sort(sort)
+	key := [2]uint64{uint64(index), uint64(s)}
+	if i, ok := e.vars[key]; ok {
+		return i
+	}
+	i := len(e.g.Decls)
+	e.g.Decls = append(e.g.Decls, declNode{Kind: "Var", Params: []int{int(index)}, Range: s})
+	e.vars[key] = i
+	return i
+}
+
+// model adds the interpretations in m to the graph.
+func (e *encoder) model(m *Model) {
+	type funcInterp struct {
+		decl    FuncDecl
+		entries [][]AST // Arguments followed by value
+		else_   AST
+	}
+	var consts [][2]AST // Declaration and value
+	var funcs []funcInterp
+	m.ctx.do(func() {
+		c := m.ctx.c
+		for i := C.uint(0); i < C.Z3_model_get_num_consts(c, m.c); i++ {
+			decl := C.Z3_model_get_const_decl(c, m.c, i)
+			val := C.Z3_model_get_const_interp(c, m.c, decl)
+			if val == nil {
+				continue
+			}
+			consts = append(consts, [2]AST{wrapAST(m.ctx, C.Z3_func_decl_to_ast(c, decl)), wrapAST(m.ctx, val)})
+		}
+		for i := C.uint(0); i < C.Z3_model_get_num_funcs(c, m.c); i++ {
+			decl := C.Z3_model_get_func_decl(c, m.c, i)
+			fi := C.Z3_model_get_func_interp(c, m.c, decl)
+			C.Z3_func_interp_inc_ref(c, fi)
+			interp := funcInterp{
+				decl:  wrapFuncDecl(m.ctx, decl),
+				else_: wrapAST(m.ctx, C.Z3_func_interp_get_else(c, fi)),
+			}
+			for j := C.uint(0); j < C.Z3_func_interp_get_num_entries(c, fi); j++ {
+				entry := C.Z3_func_interp_get_entry(c, fi, j)
+				C.Z3_func_entry_inc_ref(c, entry)
+				var asts []AST
+				for k := C.uint(0); k < C.Z3_func_entry_get_num_args(c, entry); k++ {
+					asts = append(asts, wrapAST(m.ctx, C.Z3_func_entry_get_arg(c, entry, k)))
+				}
+				asts = append(asts, wrapAST(m.ctx, C.Z3_func_entry_get_value(c, entry)))
+				interp.entries = append(interp.entries, asts)
+				C.Z3_func_entry_dec_ref(c, entry)
+			}
+			C.Z3_func_interp_dec_ref(c, fi)
+			funcs = append(funcs, interp)
+		}
+	})
+	runtime.KeepAlive(m)
+
+	mn := new(modelNode)
+	for _, kv := range consts {
+		mn.Consts = append(mn.Consts, constNode{e.decl(kv[0].AsFuncDecl()), e.value(kv[1])})
+	}
+	for _, fi := range funcs {
+		fn := funcNode{Decl: e.decl(fi.decl), Else: e.value(fi.else_)}
+		for _, asts := range fi.entries {
+			var en entryNode
+			for _, arg := range asts[:len(asts)-1] {
+				en.Args = append(en.Args, e.value(arg))
+			}
+			en.Value = e.value(asts[len(asts)-1])
+			fn.Entries = append(fn.Entries, en)
+		}
+		mn.Funcs = append(mn.Funcs, fn)
+	}
+	e.g.Model = mn
+}
+
+// UnmarshalValue decodes a Value in ctx from data produced by the
+// MarshalJSON or MarshalBinary method of a Value.
+func UnmarshalValue(ctx *Context, data []byte) (Value, error) {
+	var val Value
+	err := unmarshal(ctx, data, func(d *decoder) {
+		if d.g.Value == nil {
+			marshalErrorf("data does not contain a Value")
+		}
+		val = d.node(*d.g.Value).lift(KindUnknown)
+	})
+	return val, err
+}
+
+// UnmarshalSort decodes a Sort in ctx from data produced by
+// Sort.MarshalJSON or Sort.MarshalBinary.
+func UnmarshalSort(ctx *Context, data []byte) (Sort, error) {
+	var sort Sort
+	err := unmarshal(ctx, data, func(d *decoder) {
+		if d.g.Sort == nil {
+			marshalErrorf("data does not contain a Sort")
+		}
+		sort = d.sort(*d.g.Sort)
+	})
+	return sort, err
+}
+
+// UnmarshalFuncDecl decodes a FuncDecl in ctx from data produced by
+// FuncDecl.MarshalJSON or FuncDecl.MarshalBinary.
+func UnmarshalFuncDecl(ctx *Context, data []byte) (FuncDecl, error) {
+	var decl FuncDecl
+	err := unmarshal(ctx, data, func(d *decoder) {
+		if d.g.Decl == nil {
+			marshalErrorf("data does not contain a FuncDecl")
+		}
+		decl = d.funcDecl(*d.g.Decl)
+	})
+	return decl, err
+}
+
+// UnmarshalModel decodes a Model in ctx from data produced by
+// Model.MarshalJSON or Model.MarshalBinary.
+func UnmarshalModel(ctx *Context, data []byte) (*Model, error) {
+	var m *Model
+	err := unmarshal(ctx, data, func(d *decoder) {
+		mn := d.g.Model
+		if mn == nil {
+			marshalErrorf("data does not contain a Model")
+		}
+		m = ctx.NewModel()
+		for _, cn := range mn.Consts {
+			m.SetConst(d.funcDecl(cn.Decl), d.node(cn.Value))
+		}
+		for _, fn := range mn.Funcs {
+			entries := make([]FuncEntry, len(fn.Entries))
+			for i, en := range fn.Entries {
+				args := make([]Value, len(en.Args))
+				for j, arg := range en.Args {
+					args[j] = d.node(arg)
+				}
+				entries[i] = FuncEntry{args, d.node(en.Value)}
+			}
+			m.SetFunc(d.funcDecl(fn.Decl), entries, d.node(fn.Else))
+		}
+	})
+	return m, err
+}
+
+// A decoder builds Sorts, FuncDecls, and values from a graph. Each
+// table is decoded in order, so each entry's references have already
+// been decoded.
+type decoder struct {
+	ctx   *Context
+	g     *graph
+	sorts []Sort
+	decls []FuncDecl
+	nodes []value
+}
+
+func unmarshal(ctx *Context, data []byte, f func(d *decoder)) (err error) {
+	defer catchMarshalError(&err)
+	// Malformed data can still describe terms Z3 rejects, such as
+	// an interpreted function applied to arguments of the wrong
+	// sorts. Z3 reports these by panicking with its error message.
+	defer func() {
+		if e := recover(); e != nil {
+			msg, ok := e.(string)
+			if !ok {
+				panic(e)
+			}
+			err = fmt.Errorf("malformed data: %s", msg)
+		}
+	}()
+	g := new(graph)
+	if bytes.HasPrefix(data, []byte(binaryMagic)) {
+		g = unmarshalBinaryGraph(data)
+	} else if err := json.Unmarshal(data, g); err != nil {
+		return err
+	}
+	d := &decoder{ctx: ctx, g: g}
+	for i := range g.Sorts {
+		d.sorts = append(d.sorts, d.mkSort(g.Sorts[i]))
+	}
+	for i := range g.Decls {
+		d.decls = append(d.decls, d.mkDecl(g.Decls[i]))
+	}
+	for i := range g.Nodes {
+		d.nodes = append(d.nodes, d.mkNode(g.Nodes[i]))
+	}
+	f(d)
+	return nil
+}
+
+func (d *decoder) sort(i int) Sort {
+	if i < 0 || i >= len(d.sorts) {
+		marshalErrorf("bad sort reference %d", i)
+	}
+	return d.sorts[i]
+}
+
+// decl returns the i'th declaration, which may not have a
+// FuncDecl if it is interpreted.
+func (d *decoder) decl(i int) declNode {
+	if i < 0 || i >= len(d.decls) {
+		marshalErrorf("bad declaration reference %d", i)
+	}
+	return d.g.Decls[i]
+}
+
+// funcDecl returns the FuncDecl of the i'th declaration.
+func (d *decoder) funcDecl(i int) FuncDecl {
+	dn := d.decl(i)
+	if d.decls[i].funcDeclImpl != nil {
+		return d.decls[i]
+	}
+	if dn.Kind == "Var" {
+		marshalErrorf("bound variable is not a function")
+	}
+	// Get the declaration of an interpreted function by applying
+	// it to fresh constants.
+	// This avoids FreshConst because it can't lift rounding modes.
+	args := make([]value, len(dn.Domain))
+	for j, s := range dn.Domain {
+		sort := d.sort(s)
+		d.ctx.do(func() {
+			args[j] = wrapAST(d.ctx, C.Z3_mk_fresh_const(d.ctx.c, nil, sort.c)).asValue()
+		})
+	}
+	app := d.apply(dn, args)
+	d.decls[i] = app.AsAST().Decl()
+	return d.decls[i]
+}
+
+func (d *decoder) node(i int) value {
+	if i < 0 || i >= len(d.nodes) {
+		marshalErrorf("bad node reference %d", i)
+	}
+	return d.nodes[i]
+}
+
+func (expr *valueImpl) value() value {
+	return value{expr, noEq{}}
+}
+
+func (d *decoder) mkSort(n sortNode) Sort {
+	ctx := d.ctx
+	wantSorts := func(k int) {
+		if len(n.Sorts) != k {
+			marshalErrorf("%s sort has %d sorts, want %d", n.Kind, len(n.Sorts), k)
+		}
+	}
+	switch n.Kind {
+	case "Bool":
+		return ctx.BoolSort()
+	case "Int":
+		return ctx.IntSort()
+	case "Real":
+		return ctx.RealSort()
+	case "RoundingMode":
+		var s Sort
+		ctx.do(func() {
+			s = wrapSort(ctx, C.Z3_mk_fpa_rounding_mode_sort(ctx.c), KindRoundingMode)
+		})
+		return s
+	case "BV":
+		// Z3 crashes on some sizes that don't fit in an int32.
+		if n.Size == 0 || n.Size > math.MaxInt32 {
+			marshalErrorf("bad bit-vector sort size %d", n.Size)
+		}
+		return ctx.BVSort(int(n.Size))
+	case "FloatingPoint":
+		if n.EBits < 2 || n.EBits > 63 || n.SBits < 3 || n.SBits > math.MaxInt32 {
+			marshalErrorf("bad floating-point sort size %d, %d", n.EBits, n.SBits)
+		}
+		return ctx.FloatSort(n.EBits, n.SBits)
+	case "Array":
+		wantSorts(2)
+		return ctx.ArraySort(d.sort(n.Sorts[0]), d.sort(n.Sorts[1]))
+	case "Seq":
+		wantSorts(1)
+		return ctx.SeqSort(d.sort(n.Sorts[0]))
+	case "Uninterpreted":
+		return ctx.UninterpretedSort(n.Name)
+	case "FiniteDomain":
+		if n.Size == 0 {
+			marshalErrorf("finite-domain sort has size 0")
+		}
+		return ctx.FiniteDomainSort(n.Name, n.Size)
+	case "Datatype":
+		wantSorts(len(n.Fields))
+		sorts := make([]Sort, len(n.Sorts))
+		for i, s := range n.Sorts {
+			sorts[i] = d.sort(s)
+		}
+		return ctx.TupleSort(n.Name, n.Fields, sorts)
+	}
+	marshalErrorf("unknown sort kind %q", n.Kind)
+	panic("unreachable")
+}
+
+// mkDecl returns the FuncDecl for n if it is uninterpreted or a tuple
+// function. Other declarations are constructed when they are applied.
+func (d *decoder) mkDecl(n declNode) FuncDecl {
+	for _, s := range n.Domain {
+		d.sort(s)
+	}
+	range_ := d.sort(n.Range)
+	switch n.Kind {
+	case "Uninterpreted":
+		domain := make([]Sort, len(n.Domain))
+		for i, s := range n.Domain {
+			domain[i] = d.sort(s)
+		}
+		return d.ctx.FuncDecl(n.Name, domain, range_)
+	case "DTConstructor":
+		var f FuncDecl
+		d.ctx.do(func() {
+			f = wrapFuncDecl(d.ctx, C.Z3_get_tuple_sort_mk_decl(d.ctx.c, range_.c))
+		})
+		return f
+	case "DTAccessor":
+		if len(n.Domain) != 1 || len(n.Params) != 1 {
+			marshalErrorf("bad DTAccessor declaration")
+		}
+		tuple := d.sort(n.Domain[0])
+		if n.Params[0] < 0 || n.Params[0] >= tuple.TupleNumFields() {
+			marshalErrorf("bad DTAccessor field %d", n.Params[0])
+		}
+		return tuple.TupleField(n.Params[0])
+	case "Var":
+		if len(n.Params) != 1 || n.Params[0] < 0 {
+			marshalErrorf("bad bound variable declaration")
+		}
+		return FuncDecl{}
+	}
+	if kind, ok := declKindNames[n.Kind]; !ok || !buildable(kind) {
+		marshalErrorf("unknown function kind %q", n.Kind)
+	}
+	return FuncDecl{}
+}
+
+func (d *decoder) mkNode(n astNode) value {
+	dn := d.decl(n.Decl)
+	args := make([]value, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = d.node(arg)
+	}
+	if n.Lit != "" {
+		if len(args) != 0 {
+			marshalErrorf("numeral has arguments")
+		}
+		return d.numeral(dn, n.Lit)
+	}
+	// The domain of an n-ary function such as bvadd has only two
+	// sorts, regardless of the number of arguments.
+	nary := declArity[declKindNames[dn.Kind]].args < 0
+	if len(args) != len(dn.Domain) && !(nary && len(dn.Domain) > 0) {
+		marshalErrorf("%s applied to %d arguments, want %d", dn.Kind, len(args), len(dn.Domain))
+	}
+	for i, arg := range args {
+		want := d.sort(dn.Domain[len(dn.Domain)-1])
+		if i < len(dn.Domain) {
+			want = d.sort(dn.Domain[i])
+		}
+		if !arg.Sort().AsAST().Equal(want.AsAST()) {
+			marshalErrorf("argument %d of %s has sort %s, want %s", i, dn.Kind, arg.Sort(), want)
+		}
+	}
+	if f := d.decls[n.Decl]; f.funcDeclImpl != nil {
+		vals := make([]Value, len(args))
+		for i, arg := range args {
+			vals[i] = arg
+		}
+		return f.Apply(vals...).impl().value()
+	}
+	return d.apply(dn, args)
+}
+
+// numeral returns the numeral lit of the sort of n.
+func (d *decoder) numeral(n declNode, lit string) value {
+	sort := d.sort(n.Range)
+	switch n.Kind {
+	case "ANum", "BNum":
+		if sort.Kind() == KindReal {
+			x, ok := new(big.Rat).SetString(lit)
+			if !ok {
+				marshalErrorf("bad real numeral %q", lit)
+			}
+			return d.ctx.FromBigRat(x).impl().value()
+		}
+		x, ok := new(big.Int).SetString(lit, 10)
+		if !ok || sort.Kind() != KindInt && sort.Kind() != KindBV {
+			marshalErrorf("bad integer numeral %q", lit)
+		}
+		return d.ctx.FromBigInt(x, sort).impl().value()
+	case "FPANum":
+		_, sbits := sort.FloatSize()
+		x, _, err := new(big.Float).SetPrec(uint(sbits)+1).Parse(lit, 0)
+		if err != nil {
+			marshalErrorf("bad floating-point numeral %q", lit)
+		}
+		// floatFromBigFloat constructs the numeral from its
+		// bits. Simplify folds this back into a numeral.
+		return d.ctx.Simplify(d.ctx.floatFromBigFloat(x, sort), nil).impl().value()
+	}
+	marshalErrorf("%s is not a numeral", n.Kind)
+	panic("unreachable")
+}
+
+// apply applies the interpreted function or bound variable n to args.
+func (d *decoder) apply(n declNode, args []value) value {
+	range_ := d.sort(n.Range)
+	if n.Kind == "Var" {
+		var val value
+		d.ctx.do(func() {
+			val = wrapAST(d.ctx, C.Z3_mk_bound(d.ctx.c, C.uint(n.Params[0]), range_.c)).asValue()
+		})
+		return val
+	}
+	kind := declKindNames[n.Kind]
+	if kind == DeclKindAsArray {
+		if len(n.Params) != 1 || d.decl(n.Params[0]).Kind != "Uninterpreted" {
+			marshalErrorf("bad AsArray declaration")
+		}
+		f := d.decls[n.Params[0]]
+		var val value
+		d.ctx.do(func() {
+			val = wrapAST(d.ctx, C.Z3_mk_as_array(d.ctx.c, f.c)).asValue()
+		})
+		return val
+	}
+	if want, ok := declArity[kind]; ok {
+		if want.args >= 0 && len(args) != want.args || want.args < 0 && len(args) < -want.args {
+			marshalErrorf("%s applied to %d arguments", n.Kind, len(args))
+		}
+		if len(n.Params) < want.params {
+			marshalErrorf("%s has %d parameters, want %d", n.Kind, len(n.Params), want.params)
+		}
+	}
+	cargs := make([]C.Z3_ast, len(args))
+	for i, arg := range args {
+		cargs[i] = arg.c
+	}
+	var val value
+	d.ctx.do(func() {
+		val = wrapAST(d.ctx, build(d.ctx.c, kind, cargs, n.Params, range_.c)).asValue()
+	})
+	runtime.KeepAlive(args)
+	runtime.KeepAlive(range_)
+	if !val.Sort().AsAST().Equal(range_.AsAST()) {
+		marshalErrorf("%s has sort %s, want %s", n.Kind, val.Sort(), range_)
+	}
+	return val
+}
+
+func (ast AST) asValue() value {
+	return value{(*valueImpl)(ast.astImpl), noEq{}}
+}
+
+// declKindNames maps DeclKind names without the "DeclKind" prefix to
+// DeclKinds.
+var declKindNames = func() map[string]DeclKind {
+	m := make(map[string]DeclKind)
+	for _, k := range declKinds {
+		m[strings.TrimPrefix(k.String(), "DeclKind")] = k
+	}
+	return m
+}()
@@ -0,0 +1,234 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// marshalers returns the JSON and binary encodings of v.
+func marshalers(t *testing.T, v interface {
+	json.Marshaler
+	encoding.BinaryMarshaler
+}) map[string][]byte {
+	if tHelper != nil {
+		tHelper(t)
+	}
+
+	js, err := v.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON(%s): %s", v, err)
+	}
+	bin, err := v.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(%s): %s", v, err)
+	}
+	return map[string][]byte{"JSON": js, "binary": bin}
+}
+
+func TestMarshalValue(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.BVConst("x", 8), ctx.BVConst("y", 8)
+	i := ctx.IntConst("i")
+	r := ctx.RealConst("r")
+	p, q := ctx.BoolConst("p"), ctx.BoolConst("q")
+	f := ctx.Const("f", ctx.Float64Sort()).(Float)
+	k := func(v int64) BV { return ctx.FromInt(v, x.Sort()).(BV) }
+	fn := ctx.FuncDecl("fn", []Sort{x.Sort(), ctx.IntSort()}, ctx.BoolSort())
+	pair := ctx.TupleSort("pair", []string{"first", "second"}, []Sort{ctx.IntSort(), ctx.BoolSort()})
+	arr := ctx.Const("a", ctx.ArraySort(x.Sort(), ctx.IntSort())).(Array)
+	seq := ctx.SeqEmpty(ctx.SeqSort(x.Sort())).Concat(ctx.SeqUnit(x), ctx.SeqUnit(y))
+	s := x.Add(y)
+
+	for _, v := range []Value{
+		s.Mul(s).SLT(s.Sub(k(-3))),
+		ctx.Simplify(x.Add(y).Add(k(1)), nil).(BV).Extract(5, 2).SignExtend(4).UToInt(),
+		x.Concat(y).URsh(ctx.FromInt(3, ctx.BVSort(16)).(BV)).RotateLeft(ctx.FromInt(3, ctx.BVSort(16)).(BV)),
+		i.Div(i.Add(ctx.FromInt(-7, ctx.IntSort()).(Int))).ToReal().Mul(r).GE(ctx.FromBigRat(big.NewRat(-5, 3))),
+		p.Implies(q).Xor(ctx.Distinct(x, y, k(0))).IfThenElse(x, y),
+		f.Add(ctx.FromFloat64(1.5, f.Sort())).LT(ctx.FloatInf(f.Sort(), true)),
+		f.IsNaN().Or(f.Eq(ctx.FloatZero(f.Sort(), true)), f.Eq(ctx.FloatNaN(f.Sort()))),
+		ctx.FromFloat64(1e-310, f.Sort()),
+		x.SToFloat(ctx.Float32Sort()).ToFloat(f.Sort()).ToSBV(16),
+		fn.Apply(x, i),
+		ctx.Tuple(pair, i, p).SetField(0, i.Mul(i)).Field(1),
+		arr.Store(x, i).Select(y),
+		ctx.ConstArray(x.Sort(), i),
+		seq.Length(),
+		ctx.Const("u", ctx.UninterpretedSort("U")),
+		ctx.Const("d", ctx.FiniteDomainSort("D", 5)),
+	} {
+		for format, data := range marshalers(t, v.(interface {
+			json.Marshaler
+			encoding.BinaryMarshaler
+		})) {
+			got, err := UnmarshalValue(ctx, data)
+			if err != nil {
+				t.Errorf("%s: unmarshaling %s: %s", v, format, err)
+				continue
+			}
+			if !got.AsAST().Equal(v.AsAST()) {
+				t.Errorf("%s: %s round trip produced %s", v, format, got)
+			}
+
+			// The encoding doesn't depend on the context.
+			got2, err := UnmarshalValue(NewContext(nil), data)
+			if err != nil {
+				t.Errorf("%s: unmarshaling %s in new context: %s", v, format, err)
+			} else if got2.String() != v.String() {
+				t.Errorf("%s: %s round trip in new context produced %s", v, format, got2)
+			}
+		}
+	}
+}
+
+func TestMarshalShared(t *testing.T) {
+	// Shared subterms must be encoded once, so the encoding is
+	// linear in the size of the DAG, not the tree.
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 8)
+	for i := 0; i < 64; i++ {
+		x = x.Add(x)
+	}
+	data, err := x.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g graph
+	if err := json.Unmarshal(data, &g); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Nodes) != 65 {
+		t.Errorf("want 65 nodes, got %d", len(g.Nodes))
+	}
+	got, err := UnmarshalValue(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.AsAST().Equal(x.AsAST()) {
+		t.Errorf("round trip produced %s", got)
+	}
+}
+
+func TestMarshalSortFuncDecl(t *testing.T) {
+	ctx := NewContext(nil)
+	pair := ctx.TupleSort("pair", []string{"first", "second"}, []Sort{ctx.BVSort(4), ctx.Float32Sort()})
+	ctx2 := NewContext(nil)
+	for _, s := range []Sort{
+		pair,
+		ctx.ArraySort(ctx.SeqSort(ctx.IntSort()), pair),
+		ctx.UninterpretedSort("U"),
+		ctx.FiniteDomainSort("D", 10),
+	} {
+		for format, data := range marshalers(t, s) {
+			got, err := UnmarshalSort(ctx2, data)
+			if err != nil {
+				t.Errorf("%s: unmarshaling %s: %s", s, format, err)
+			} else if !got.AsAST().Translate(ctx).Equal(s.AsAST()) {
+				t.Errorf("%s: %s round trip produced %s", s, format, got)
+			}
+		}
+	}
+
+	x := ctx.BVConst("x", 8)
+	f := ctx.Const("f", ctx.Float32Sort()).(Float)
+	for _, fd := range []FuncDecl{
+		ctx.FuncDecl("f", []Sort{pair, ctx.RealSort()}, ctx.BoolSort()),
+		pair.TupleField(1),
+		x.Extract(6, 3).AsAST().Decl(),
+		x.Add(x).AsAST().Decl(),
+		f.Add(f).AsAST().Decl(),
+	} {
+		for format, data := range marshalers(t, fd) {
+			got, err := UnmarshalFuncDecl(ctx2, data)
+			if err != nil {
+				t.Errorf("%s: unmarshaling %s: %s", fd, format, err)
+			} else if !got.AsAST().Translate(ctx).Equal(fd.AsAST()) {
+				t.Errorf("%s: %s round trip produced %s", fd, format, got)
+			}
+		}
+	}
+}
+
+func TestMarshalModel(t *testing.T) {
+	ctx := NewContext(nil)
+	is := ctx.IntSort()
+	k := func(v int64) Int { return ctx.FromInt(v, is).(Int) }
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	f := ctx.FuncDecl("f", []Sort{is, is}, is)
+	a := ctx.Const("a", ctx.ArraySort(is, is)).(Array)
+	s := NewSolver(ctx)
+	s.Assert(x.GT(k(5)))
+	s.Assert(y.Eq(x.Mul(k(2))))
+	s.Assert(f.Apply(x, y).(Int).Eq(k(3)))
+	s.Assert(f.Apply(y, x).(Int).Eq(k(4)))
+	s.Assert(a.Select(x).(Int).Eq(y))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("not satisfiable: %v", err)
+	}
+	m := s.Model()
+
+	terms := []Value{x, y, f.Apply(x, y), f.Apply(y, x), a.Select(x), a.Select(y)}
+	for format, data := range marshalers(t, m) {
+		ctx2 := NewContext(nil)
+		m2, err := UnmarshalModel(ctx2, data)
+		if err != nil {
+			t.Errorf("unmarshaling %s: %s", format, err)
+			continue
+		}
+		for _, term := range terms {
+			want := m.Eval(term, true)
+			got := m2.Eval(term.AsAST().Translate(ctx2).AsValue(), true)
+			if !got.AsAST().Translate(ctx).Equal(want.AsAST()) {
+				t.Errorf("%s: in %s round trip, %s = %s, want %s", format, term, term, got, want)
+			}
+		}
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 8)
+	valid, err := x.Add(x).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin, err := x.Add(x).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		data string
+		want string
+	}{
+		{`{`, "unexpected end"},
+		{string(valid[:len(valid)-len(`"value":2}`)]) + `"sort":0}`, "does not contain a Value"},
+		{`{"sorts":[{"kind":"Bogus"}],"value":0}`, "unknown sort kind"},
+		{`{"sorts":[{"kind":"Array","sorts":[0,0]}]}`, "bad sort reference"},
+		{`{"sorts":[{"kind":"Int"}],"decls":[{"kind":"Bogus","range":0}]}`, "unknown function kind"},
+		{`{"sorts":[{"kind":"Int"}],"decls":[{"kind":"Uninterpreted","name":"x","range":0}],"nodes":[{"decl":0,"args":[0]}]}`, "bad node reference"},
+		{`{"sorts":[{"kind":"Int"}],"decls":[{"kind":"ANum","range":0}],"nodes":[{"decl":0,"lit":"x"}],"value":0}`, "bad integer numeral"},
+		{`{"sorts":[{"kind":"Int"},{"kind":"Bool"}],"decls":[{"kind":"Uninterpreted","name":"x","range":0},{"kind":"Not","domain":[1],"range":1}],"nodes":[{"decl":0},{"decl":1,"args":[0]}],"value":1}`, "argument 0 of Not has sort Int, want Bool"},
+		{`{"sorts":[{"kind":"BV","size":18446744073709551615}],"value":0}`, "bad bit-vector sort size"},
+		{`{"sorts":[{"kind":"FloatingPoint","ebits":100,"sbits":10}],"value":0}`, "bad floating-point sort size"},
+		{`{"sorts":[{"kind":"FloatingPoint","ebits":11,"sbits":-1}],"value":0}`, "bad floating-point sort size"},
+		{`{"sorts":[{"kind":"FiniteDomain","name":"d"}],"value":0}`, "finite-domain sort has size 0"},
+		{`{"sorts":[{"kind":"Int"}],"decls":[{"kind":"Uninterpreted","name":"x","range":0},{"kind":"BAdd","domain":[0,0],"range":0}],"nodes":[{"decl":0},{"decl":1,"args":[0,0]}],"value":1}`, "malformed data"},
+		{string(bin[:len(bin)-1]), "malformed binary data"},
+		{string(bin) + "\x00", "trailing data"},
+	} {
+		_, err := UnmarshalValue(ctx, []byte(test.data))
+		if err == nil {
+			t.Errorf("%q: want error matching %q, got success", test.data, test.want)
+		} else if !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%q: want error matching %q, got %s", test.data, test.want, err)
+		}
+	}
+}
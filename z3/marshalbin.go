@@ -0,0 +1,268 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "encoding/binary"
+
+// The binary encoding of a graph is binaryMagic followed by the sort,
+// declaration, and node tables and then the root. Integers are
+// varints, strings and lists are prefixed by their length, and the
+// arguments of a node are encoded relative to the node's own index,
+// since they are usually nearby.
+const binaryMagic = "z3\x00\x01"
+
+// Root tags.
+const (
+	rootValue = iota
+	rootSort
+	rootDecl
+	rootModel
+)
+
+type binaryWriter struct {
+	buf []byte
+}
+
+func (w *binaryWriter) uint64(x uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	w.buf = append(w.buf, tmp[:binary.PutUvarint(tmp[:], x)]...)
+}
+
+func (w *binaryWriter) uint(x int) {
+	w.uint64(uint64(x))
+}
+
+func (w *binaryWriter) int(x int) {
+	var tmp [binary.MaxVarintLen64]byte
+	w.buf = append(w.buf, tmp[:binary.PutVarint(tmp[:], int64(x))]...)
+}
+
+func (w *binaryWriter) string(s string) {
+	w.uint(len(s))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *binaryWriter) uints(xs []int) {
+	w.uint(len(xs))
+	for _, x := range xs {
+		w.uint(x)
+	}
+}
+
+// marshalBinaryGraph encodes g, which must be a *graph, in binary.
+// It has the signature of json.Marshal.
+func marshalBinaryGraph(g interface{}) ([]byte, error) {
+	w := &binaryWriter{buf: []byte(binaryMagic)}
+	gr := g.(*graph)
+
+	w.uint(len(gr.Sorts))
+	for _, n := range gr.Sorts {
+		w.string(n.Kind)
+		w.string(n.Name)
+		w.uint64(n.Size)
+		w.uint(n.EBits)
+		w.uint(n.SBits)
+		w.uints(n.Sorts)
+		w.uint(len(n.Fields))
+		for _, f := range n.Fields {
+			w.string(f)
+		}
+	}
+
+	w.uint(len(gr.Decls))
+	for _, n := range gr.Decls {
+		w.string(n.Kind)
+		w.string(n.Name)
+		w.uint(len(n.Params))
+		for _, p := range n.Params {
+			w.int(p)
+		}
+		w.uints(n.Domain)
+		w.uint(n.Range)
+	}
+
+	w.uint(len(gr.Nodes))
+	for i, n := range gr.Nodes {
+		w.uint(n.Decl)
+		w.uint(len(n.Args))
+		for _, arg := range n.Args {
+			w.uint(i - 1 - arg)
+		}
+		w.string(n.Lit)
+	}
+
+	switch {
+	case gr.Value != nil:
+		w.uint(rootValue)
+		w.uint(*gr.Value)
+	case gr.Sort != nil:
+		w.uint(rootSort)
+		w.uint(*gr.Sort)
+	case gr.Decl != nil:
+		w.uint(rootDecl)
+		w.uint(*gr.Decl)
+	case gr.Model != nil:
+		w.uint(rootModel)
+		w.uint(len(gr.Model.Consts))
+		for _, c := range gr.Model.Consts {
+			w.uint(c.Decl)
+			w.uint(c.Value)
+		}
+		w.uint(len(gr.Model.Funcs))
+		for _, f := range gr.Model.Funcs {
+			w.uint(f.Decl)
+			w.uint(len(f.Entries))
+			for _, e := range f.Entries {
+				w.uints(e.Args)
+				w.uint(e.Value)
+			}
+			w.uint(f.Else)
+		}
+	}
+	return w.buf, nil
+}
+
+type binaryReader struct {
+	buf []byte
+}
+
+func (r *binaryReader) uint64() uint64 {
+	x, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		marshalErrorf("malformed binary data")
+	}
+	r.buf = r.buf[n:]
+	return x
+}
+
+func (r *binaryReader) uint() int {
+	x := r.uint64()
+	if int(x) < 0 || uint64(int(x)) != x {
+		marshalErrorf("malformed binary data")
+	}
+	return int(x)
+}
+
+func (r *binaryReader) int() int {
+	x, n := binary.Varint(r.buf)
+	if n <= 0 || int64(int(x)) != x {
+		marshalErrorf("malformed binary data")
+	}
+	r.buf = r.buf[n:]
+	return int(x)
+}
+
+// len reads the length of a list or string. Every element takes at
+// least one byte, so this limits allocations to the size of the
+// data.
+func (r *binaryReader) len() int {
+	n := r.uint()
+	if n > len(r.buf) {
+		marshalErrorf("malformed binary data")
+	}
+	return n
+}
+
+func (r *binaryReader) string() string {
+	n := r.len()
+	s := string(r.buf[:n])
+	r.buf = r.buf[n:]
+	return s
+}
+
+func (r *binaryReader) uints() []int {
+	n := r.len()
+	if n == 0 {
+		return nil
+	}
+	xs := make([]int, n)
+	for i := range xs {
+		xs[i] = r.uint()
+	}
+	return xs
+}
+
+// unmarshalBinaryGraph decodes a graph encoded by marshalBinaryGraph.
+func unmarshalBinaryGraph(data []byte) *graph {
+	r := &binaryReader{buf: data[len(binaryMagic):]}
+	g := new(graph)
+
+	g.Sorts = make([]sortNode, r.len())
+	for i := range g.Sorts {
+		n := &g.Sorts[i]
+		n.Kind = r.string()
+		n.Name = r.string()
+		n.Size = r.uint64()
+		n.EBits = r.uint()
+		n.SBits = r.uint()
+		n.Sorts = r.uints()
+		if nf := r.len(); nf > 0 {
+			n.Fields = make([]string, nf)
+			for j := range n.Fields {
+				n.Fields[j] = r.string()
+			}
+		}
+	}
+
+	g.Decls = make([]declNode, r.len())
+	for i := range g.Decls {
+		n := &g.Decls[i]
+		n.Kind = r.string()
+		n.Name = r.string()
+		if np := r.len(); np > 0 {
+			n.Params = make([]int, np)
+			for j := range n.Params {
+				n.Params[j] = r.int()
+			}
+		}
+		n.Domain = r.uints()
+		n.Range = r.uint()
+	}
+
+	g.Nodes = make([]astNode, r.len())
+	for i := range g.Nodes {
+		n := &g.Nodes[i]
+		n.Decl = r.uint()
+		n.Args = r.uints()
+		for j, delta := range n.Args {
+			// Out-of-range results are caught by the decoder.
+			n.Args[j] = i - 1 - delta
+		}
+		n.Lit = r.string()
+	}
+
+	switch root := r.uint(); root {
+	case rootValue:
+		g.Value = intPtr(r.uint())
+	case rootSort:
+		g.Sort = intPtr(r.uint())
+	case rootDecl:
+		g.Decl = intPtr(r.uint())
+	case rootModel:
+		m := new(modelNode)
+		m.Consts = make([]constNode, r.len())
+		for i := range m.Consts {
+			m.Consts[i] = constNode{r.uint(), r.uint()}
+		}
+		m.Funcs = make([]funcNode, r.len())
+		for i := range m.Funcs {
+			f := &m.Funcs[i]
+			f.Decl = r.uint()
+			f.Entries = make([]entryNode, r.len())
+			for j := range f.Entries {
+				f.Entries[j].Args = r.uints()
+				f.Entries[j].Value = r.uint()
+			}
+			f.Else = r.uint()
+		}
+		g.Model = m
+	default:
+		marshalErrorf("unknown root %d", root)
+	}
+	if len(r.buf) != 0 {
+		marshalErrorf("%d bytes of trailing data", len(r.buf))
+	}
+	return g
+}
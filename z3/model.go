@@ -30,7 +30,7 @@ func wrapModel(ctx *Context, c C.Z3_model) *Model {
 	impl := &modelImpl{ctx, c}
 	C.Z3_model_inc_ref(ctx.c, c)
 	runtime.SetFinalizer(impl, func(impl *modelImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_model_dec_ref(impl.ctx.c, impl.c)
 		})
 	})
@@ -65,6 +65,96 @@ func (m *Model) Eval(val Value, completion bool) Value {
 	return ast.AsValue()
 }
 
+// EvalAll is like Eval, but evaluates each of vals in m. It is
+// equivalent to calling Eval on each value, but more efficient. The
+// i'th result is nil if vals[i] cannot be evaluated.
+func (m *Model) EvalAll(vals []Value, completion bool) []Value {
+	asts := make([]AST, len(vals))
+	m.ctx.do(func() {
+		for i, val := range vals {
+			var cast C.Z3_ast
+			if z3ToBool(C.Z3_model_eval(m.ctx.c, m.c, val.impl().c, boolToZ3(completion), &cast)) {
+				asts[i] = wrapAST(m.ctx, cast)
+			}
+		}
+	})
+	runtime.KeepAlive(m)
+	runtime.KeepAlive(vals)
+	res := make([]Value, len(vals))
+	for i, ast := range asts {
+		if ast.astImpl != nil {
+			res[i] = ast.AsValue()
+		}
+	}
+	return res
+}
+
+// NewModel returns a new, empty model. Interpretations can be added
+// to it with SetConst and SetFunc, for example to check candidate
+// values against a set of formulas using Eval.
+func (ctx *Context) NewModel() *Model {
+	var m *Model
+	ctx.do(func() {
+		m = wrapModel(ctx, C.Z3_mk_model(ctx.c))
+	})
+	return m
+}
+
+// SetConst sets the interpretation of the constant decl in m to val.
+// decl must take no arguments; the constant named "x" of sort s has
+// the declaration ctx.FuncDecl("x", nil, s). val must have decl's
+// range sort and should be concrete.
+func (m *Model) SetConst(decl FuncDecl, val Value) {
+	m.ctx.do(func() {
+		C.Z3_add_const_interp(m.ctx.c, m.c, decl.c, val.impl().c)
+	})
+	runtime.KeepAlive(m)
+	runtime.KeepAlive(decl)
+	runtime.KeepAlive(val)
+}
+
+// A FuncEntry is one point in the interpretation of a function: the
+// function maps Args to Value.
+type FuncEntry struct {
+	Args  []Value
+	Value Value
+}
+
+// SetFunc sets the interpretation of the function decl in m. decl
+// maps the arguments of each entry to its value and any other
+// arguments to else_. The arguments and values must have the sorts
+// of decl's domain and range and should be concrete.
+func (m *Model) SetFunc(decl FuncDecl, entries []FuncEntry, else_ Value) {
+	m.ctx.do(func() {
+		fi := C.Z3_add_func_interp(m.ctx.c, m.c, decl.c, else_.impl().c)
+		C.Z3_func_interp_inc_ref(m.ctx.c, fi)
+		defer C.Z3_func_interp_dec_ref(m.ctx.c, fi)
+		for _, e := range entries {
+			cargs := C.Z3_mk_ast_vector(m.ctx.c)
+			C.Z3_ast_vector_inc_ref(m.ctx.c, cargs)
+			for _, arg := range e.Args {
+				C.Z3_ast_vector_push(m.ctx.c, cargs, arg.impl().c)
+			}
+			C.Z3_func_interp_add_entry(m.ctx.c, fi, cargs, e.Value.impl().c)
+			C.Z3_ast_vector_dec_ref(m.ctx.c, cargs)
+		}
+	})
+	runtime.KeepAlive(m)
+	runtime.KeepAlive(decl)
+	runtime.KeepAlive(entries)
+	runtime.KeepAlive(else_)
+}
+
+// Translate copies m into the target Context.
+func (m *Model) Translate(target *Context) *Model {
+	var res *Model
+	m.ctx.doBoth(target, func() {
+		res = wrapModel(target, C.Z3_model_translate(m.ctx.c, m.c, target.c))
+	})
+	runtime.KeepAlive(m)
+	return res
+}
+
 // String returns a string representation of m.
 func (m *Model) String() string {
 	var res string
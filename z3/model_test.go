@@ -28,3 +28,69 @@ func TestModel(t *testing.T) {
 		t.Fatalf("expected x -> true, y -> false; got\n%s", m)
 	}
 }
+
+func TestModelTranslate(t *testing.T) {
+	ctx, ctx2 := NewContext(nil), NewContext(nil)
+	x := ctx.IntConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x.Eq(ctx.FromInt(42, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("formula not satisfiable: %v", err)
+	}
+	m := s.Model().Translate(ctx2)
+	val, isLit, _ := m.Eval(ctx2.IntConst("x"), false).(Int).AsInt64()
+	if !isLit || val != 42 {
+		t.Errorf("translated model has x = %v, want 42\n%s", val, m)
+	}
+}
+
+func TestModelEvalAll(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	s := NewSolver(ctx)
+	s.Assert(x.Eq(ctx.FromInt(3, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("formula not satisfiable: %v", err)
+	}
+	m := s.Model()
+	vals := m.EvalAll([]Value{x, x.Mul(x), y}, true)
+	for i, want := range []int64{3, 9, 0} {
+		got, isLit, _ := vals[i].(Int).AsInt64()
+		if !isLit || got != want {
+			t.Errorf("EvalAll()[%d] = %v, want %d", i, vals[i], want)
+		}
+	}
+}
+
+func TestNewModel(t *testing.T) {
+	ctx := NewContext(nil)
+	is := ctx.IntSort()
+	k := func(v int64) Int { return ctx.FromInt(v, is).(Int) }
+	x := ctx.IntConst("x")
+	f := ctx.FuncDecl("f", []Sort{is}, is)
+
+	m := ctx.NewModel()
+	m.SetConst(ctx.FuncDecl("x", nil, is), k(2))
+	m.SetFunc(f, []FuncEntry{{[]Value{k(2)}, k(20)}, {[]Value{k(3)}, k(30)}}, k(-1))
+
+	for _, test := range []struct {
+		val  Value
+		want int64
+	}{
+		{x, 2},
+		{f.Apply(x), 20},
+		{f.Apply(x.Add(k(1))), 30},
+		{f.Apply(k(7)), -1},
+	} {
+		got, isLit, _ := m.Eval(test.val, false).(Int).AsInt64()
+		if !isLit || got != test.want {
+			t.Errorf("%v = %v, want %d\n%s", test.val, got, test.want, m)
+		}
+	}
+
+	// Check a candidate against a constraint.
+	c := f.Apply(x).(Int).GT(k(10))
+	if val, _ := m.Eval(c, false).(Bool).AsBool(); !val {
+		t.Errorf("%v is false in\n%s", c, m)
+	}
+}
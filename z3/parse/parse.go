@@ -0,0 +1,665 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package parse builds symbolic z3 values from Go expression syntax.
+//
+// For example,
+//
+//	parse.Expr(ctx, "x + 2*y < z && f(x) != 0", env)
+//
+// returns the z3.Bool for that formula, where x, y, z, and f are
+// looked up in env.
+//
+// Expressions are type-checked much like Go expressions and operators
+// have the same meaning as the corresponding operations in package
+// st. A bit-vector of width 8, 16, 32, or 64 behaves like Go's intN,
+// or uintN if it is listed in Env.Unsigned; z3.Int behaves like
+// *big.Int and z3.Real like *big.Rat, but both use ordinary
+// operators; z3.Float behaves like float32 or float64; and string
+// sequences support +, ==, and !=. Operands must have identical
+// types, except that untyped constants such as 2 and 1.5 take the
+// type of the other operand. A constant expression with no other
+// type is an Int, Real, or Bool.
+//
+// Conversions to integer and floating-point types, such as uint8(x)
+// or float64(x), change the width and signedness of bit-vectors and
+// convert between bit-vectors and floats. Any other call must be to a
+// function in Env.Funcs.
+package parse
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/aclements/go-z3/internal/ops"
+	"github.com/aclements/go-z3/z3"
+)
+
+// Env gives the meaning of the identifiers in an expression.
+type Env struct {
+	// Vars maps names to values.
+	Vars map[string]z3.Value
+
+	// Funcs maps names to uninterpreted functions.
+	Funcs map[string]z3.FuncDecl
+
+	// Unsigned is the set of names in Vars and Funcs whose
+	// bit-vector values are unsigned. Other bit-vectors are
+	// signed.
+	Unsigned map[string]bool
+}
+
+// Expr parses the Go expression src and returns its value in ctx.
+// Identifiers in src refer to the values and functions in env, or to
+// the predeclared constants true and false.
+//
+// If src is not a valid expression, Expr returns an error that gives
+// the position of the problem in src.
+func Expr(ctx *z3.Context, src string, env *Env) (z3.Value, error) {
+	if env == nil {
+		env = new(Env)
+	}
+	fset := token.NewFileSet()
+	x, err := parser.ParseExprFrom(fset, "", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{ctx: ctx, env: env, fset: fset}
+	op, err := p.expr(x)
+	if err != nil {
+		return nil, err
+	}
+	if op.val == nil {
+		if op, err = p.defaultType(x, op); err != nil {
+			return nil, err
+		}
+	}
+	return op.val, nil
+}
+
+// An exprParser converts a Go AST into a z3.Value.
+type exprParser struct {
+	ctx  *z3.Context
+	env  *Env
+	fset *token.FileSet
+}
+
+// An operand is the result of evaluating an expression. It is either
+// an untyped constant or a typed z3.Value.
+type operand struct {
+	// konst is the value of an untyped constant.
+	konst constant.Value
+
+	// val and typ are the value and type of a typed operand.
+	val z3.Value
+	typ ops.Type
+}
+
+// errorf returns an error at the position of node n.
+func (p *exprParser) errorf(n ast.Node, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %s", p.fset.Position(n.Pos()), fmt.Sprintf(format, args...))
+}
+
+func (p *exprParser) expr(x ast.Expr) (operand, error) {
+	switch x := x.(type) {
+	case *ast.ParenExpr:
+		return p.expr(x.X)
+
+	case *ast.BasicLit:
+		k := constant.MakeFromLiteral(x.Value, x.Kind, 0)
+		if k.Kind() == constant.Unknown {
+			return operand{}, p.errorf(x, "malformed literal %s", x.Value)
+		}
+		if k.Kind() == constant.String {
+			return operand{}, p.errorf(x, "string literals are not supported")
+		}
+		return operand{konst: k}, nil
+
+	case *ast.Ident:
+		if v, ok := p.env.Vars[x.Name]; ok {
+			typ, err := p.typeOf(x, v.Sort(), p.env.Unsigned[x.Name])
+			return operand{val: v, typ: typ}, err
+		}
+		switch x.Name {
+		case "true", "false":
+			return operand{konst: constant.MakeBool(x.Name == "true")}, nil
+		}
+		return operand{}, p.errorf(x, "undefined: %s", x.Name)
+
+	case *ast.UnaryExpr:
+		return p.unary(x)
+
+	case *ast.BinaryExpr:
+		return p.binary(x)
+
+	case *ast.CallExpr:
+		return p.call(x)
+	}
+	return operand{}, p.errorf(x, "unsupported expression %T", x)
+}
+
+// typeOf returns the Go type for values of sort s.
+func (p *exprParser) typeOf(n ast.Node, s z3.Sort, unsigned bool) (ops.Type, error) {
+	var want ops.Type
+	switch s.Kind() {
+	case z3.KindBool:
+		want = ops.Type{Flags: ops.IsBool}
+	case z3.KindInt:
+		want = ops.Type{Flags: ops.IsBigInt}
+	case z3.KindReal:
+		want = ops.Type{Flags: ops.IsBigRat}
+	case z3.KindBV:
+		want = ops.Type{Flags: ops.IsInteger, Bits: s.BVSize()}
+		if unsigned {
+			want.Flags |= ops.IsUnsigned
+		}
+	case z3.KindFloatingPoint:
+		want = ops.Type{Flags: ops.IsFloat}
+		switch ebits, sbits := s.FloatSize(); {
+		case ebits == 8 && sbits == 24:
+			want.Bits = 32
+		case ebits == 11 && sbits == 53:
+			want.Bits = 64
+		default:
+			return ops.Type{}, p.errorf(n, "unsupported float sort %s", s)
+		}
+	case z3.KindSeq:
+		want = ops.Type{Flags: ops.IsString}
+	default:
+		return ops.Type{}, p.errorf(n, "unsupported sort %s", s)
+	}
+	for _, t := range ops.Types {
+		// Prefer the sized names for int and uint.
+		if t.ConType == "int" || t.ConType == "uint" || t.ConType == "uintptr" {
+			continue
+		}
+		if t.Flags == want.Flags && t.Bits == want.Bits {
+			return t, nil
+		}
+	}
+	if want.Flags&ops.IsInteger != 0 {
+		// An odd-sized bit-vector.
+		name := "int" + strconv.Itoa(want.Bits)
+		if unsigned {
+			name = "u" + name
+		}
+		want.StName, want.ConType, want.SymType = name, name, "BV"
+		return want, nil
+	}
+	return ops.Type{}, p.errorf(n, "unsupported sort %s", s)
+}
+
+// typeName returns the Go name of t.
+func typeName(t ops.Type) string {
+	switch t.Flags & (ops.IsBigInt | ops.IsBigRat) {
+	case ops.IsBigInt:
+		return "Int"
+	case ops.IsBigRat:
+		return "Real"
+	}
+	return t.ConType
+}
+
+// sortOf returns the z3 sort for values of type t.
+func (p *exprParser) sortOf(t ops.Type) z3.Sort {
+	switch {
+	case t.Flags&ops.IsBool != 0:
+		return p.ctx.BoolSort()
+	case t.Flags&ops.IsBigInt != 0:
+		return p.ctx.IntSort()
+	case t.Flags&ops.IsBigRat != 0:
+		return p.ctx.RealSort()
+	case t.Flags&ops.IsInteger != 0:
+		return p.ctx.BVSort(t.Bits)
+	case t.Flags&ops.IsFloat != 0 && t.Bits == 32:
+		return p.ctx.Float32Sort()
+	case t.Flags&ops.IsFloat != 0:
+		return p.ctx.Float64Sort()
+	}
+	panic("no sort for " + typeName(t))
+}
+
+// defaultType converts untyped constant op to its default type.
+func (p *exprParser) defaultType(n ast.Node, op operand) (operand, error) {
+	var t ops.Type
+	switch op.konst.Kind() {
+	case constant.Bool:
+		t = ops.Type{Flags: ops.IsBool}
+	case constant.Int:
+		t = ops.Type{Flags: ops.IsBigInt}
+	case constant.Float:
+		t = ops.Type{Flags: ops.IsBigRat}
+	default:
+		return operand{}, p.errorf(n, "unsupported constant %s", op.konst)
+	}
+	return p.convertConst(n, op.konst, t)
+}
+
+// convertConst converts untyped constant k to type t.
+func (p *exprParser) convertConst(n ast.Node, k constant.Value, t ops.Type) (operand, error) {
+	bad := func() (operand, error) {
+		return operand{}, p.errorf(n, "cannot use %s as %s value", k, typeName(t))
+	}
+	if t.Flags&ops.IsString != 0 {
+		return bad()
+	}
+	sort := p.sortOf(t)
+	var val z3.Value
+	switch {
+	case t.Flags&ops.IsBool != 0:
+		if k.Kind() != constant.Bool {
+			return bad()
+		}
+		val = p.ctx.FromBool(constant.BoolVal(k))
+
+	case t.Flags&(ops.IsInteger|ops.IsBigInt) != 0:
+		ik := constant.ToInt(k)
+		if ik.Kind() != constant.Int {
+			return bad()
+		}
+		v, ok := new(big.Int).SetString(ik.ExactString(), 10)
+		if !ok {
+			return bad()
+		}
+		if t.Flags&ops.IsInteger != 0 {
+			min, max := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), uint(t.Bits))
+			if t.Flags&ops.IsUnsigned == 0 {
+				max.Rsh(max, 1)
+				min.Neg(max)
+			}
+			if v.Cmp(min) < 0 || v.Cmp(max) >= 0 {
+				return operand{}, p.errorf(n, "constant %s overflows %s", k, typeName(t))
+			}
+		}
+		val = p.ctx.FromBigInt(v, sort)
+
+	case t.Flags&ops.IsBigRat != 0:
+		fk := constant.ToFloat(k)
+		if fk.Kind() != constant.Float {
+			return bad()
+		}
+		r, ok := new(big.Rat).SetString(fk.ExactString())
+		if !ok {
+			return bad()
+		}
+		val = p.ctx.FromBigRat(r)
+
+	case t.Flags&ops.IsFloat != 0:
+		fk := constant.ToFloat(k)
+		if fk.Kind() != constant.Float {
+			return bad()
+		}
+		r, ok := new(big.Rat).SetString(fk.ExactString())
+		if !ok {
+			return bad()
+		}
+		// Round the exact value once, as Go does.
+		f, _ := r.Float64()
+		if t.Bits == 32 {
+			f32, _ := r.Float32()
+			f = float64(f32)
+		}
+		if math.IsInf(f, 0) {
+			return operand{}, p.errorf(n, "constant %s overflows %s", k, typeName(t))
+		}
+		val = p.ctx.FromBigFloat(new(big.Float).SetFloat64(f), sort)
+
+	default:
+		return bad()
+	}
+	return operand{val: val, typ: t}, nil
+}
+
+// match converts x and y to a common type.
+func (p *exprParser) match(n ast.Node, x, y operand) (operand, operand, error) {
+	var err error
+	switch {
+	case x.val == nil && y.val != nil:
+		x, err = p.convertConst(n, x.konst, y.typ)
+	case x.val != nil && y.val == nil:
+		y, err = p.convertConst(n, y.konst, x.typ)
+	case x.val != nil && y.val != nil && x.typ != y.typ:
+		err = p.errorf(n, "mismatched types %s and %s", typeName(x.typ), typeName(y.typ))
+	}
+	return x, y, err
+}
+
+// call calls method on recv with args and returns the result.
+func call(recv z3.Value, method string, args ...z3.Value) z3.Value {
+	m := reflect.ValueOf(recv).MethodByName(method)
+	if !m.IsValid() {
+		panic(fmt.Sprintf("%T has no method %s", recv, method))
+	}
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		in[i] = reflect.ValueOf(arg)
+	}
+	return m.Call(in)[0].Interface().(z3.Value)
+}
+
+func findOp(table []ops.Op, tok token.Token) (ops.Op, bool) {
+	for _, op := range table {
+		if op.Tok == tok {
+			return op, true
+		}
+	}
+	return ops.Op{}, false
+}
+
+func (p *exprParser) unary(x *ast.UnaryExpr) (operand, error) {
+	op, ok := findOp(ops.UnOps, x.Op)
+	if !ok {
+		return operand{}, p.errorf(x, "unsupported operator %s", x.Op)
+	}
+	arg, err := p.expr(x.X)
+	if err != nil {
+		return operand{}, err
+	}
+	if arg.val == nil {
+		if (op.Flags&ops.IsBool != 0) != (arg.konst.Kind() == constant.Bool) {
+			return operand{}, p.errorf(x, "operator %s not defined on %s", x.Op, arg.konst)
+		}
+		if x.Op == token.XOR && arg.konst.Kind() != constant.Int {
+			return operand{}, p.errorf(x, "operator %s not defined on %s", x.Op, arg.konst)
+		}
+		return operand{konst: constant.UnaryOp(x.Op, arg.konst, 0)}, nil
+	}
+	if op.Flags&arg.typ.Flags&^ops.IsUnsigned == 0 {
+		return operand{}, p.errorf(x, "operator %s not defined on %s", x.Op, typeName(arg.typ))
+	}
+	if op.Flags&ops.OpPos != 0 {
+		return arg, nil
+	}
+	return operand{val: call(arg.val, op.Method), typ: arg.typ}, nil
+}
+
+func (p *exprParser) binary(x *ast.BinaryExpr) (operand, error) {
+	op, ok := findOp(ops.BinOps, x.Op)
+	if !ok {
+		return operand{}, p.errorf(x, "unsupported operator %s", x.Op)
+	}
+	l, err := p.expr(x.X)
+	if err != nil {
+		return operand{}, err
+	}
+	r, err := p.expr(x.Y)
+	if err != nil {
+		return operand{}, err
+	}
+
+	if op.Flags&ops.OpShift != 0 {
+		return p.shift(x, op, l, r)
+	}
+
+	if l.val == nil && r.val == nil {
+		return p.constBinary(x, l.konst, r.konst)
+	}
+	if l, r, err = p.match(x, l, r); err != nil {
+		return operand{}, err
+	}
+	t := l.typ
+	if op.Flags&t.Flags&^ops.IsUnsigned == 0 {
+		return operand{}, p.errorf(x, "operator %s not defined on %s", x.Op, typeName(t))
+	}
+	resType := t
+	if op.Flags&ops.OpCompare != 0 {
+		resType = ops.Type{Flags: ops.IsBool}
+		for _, bt := range ops.Types {
+			if bt.Flags == ops.IsBool {
+				resType = bt
+			}
+		}
+	}
+
+	// Map the operator to a z3 method the same way st does.
+	symop := op.Method
+	if symop == "Quo" && t.Flags&(ops.IsInteger|ops.IsFloat|ops.IsBigRat) != 0 {
+		symop = "Div"
+	}
+	if op.Flags&ops.Z3SignedPrefix != 0 {
+		switch {
+		case t.Flags&ops.IsUnsigned != 0:
+			symop = "U" + symop
+		case t.Flags&ops.IsInteger != 0:
+			symop = "S" + symop
+		}
+	}
+	xs, ys := l.val, r.val
+	var res z3.Value
+	switch {
+	case t.Flags&ops.IsFloat != 0 && symop == "Eq":
+		res = xs.(z3.Float).IEEEEq(ys.(z3.Float))
+	case t.Flags&ops.IsFloat != 0 && symop == "NE":
+		res = xs.(z3.Float).IEEEEq(ys.(z3.Float)).Not()
+	case t.Flags&ops.IsString != 0 && symop == "Add":
+		res = xs.(z3.Seq).Concat(ys.(z3.Seq))
+	case t.Flags&ops.IsString != 0 && symop != "Eq" && symop != "NE":
+		return operand{}, p.errorf(x, "operator %s not supported on strings", x.Op)
+	case symop == "AndNot":
+		res = xs.(z3.BV).And(ys.(z3.BV).Not())
+	case symop == "Quo", symop == "Rem":
+		res = intQuoRem(symop, xs.(z3.Int), ys.(z3.Int))
+	default:
+		res = call(xs, symop, ys)
+	}
+	return operand{val: res, typ: resType}, nil
+}
+
+// intQuoRem returns x/y or x%y with Go's truncated division. Z3's Int
+// Div and Mod use Euclidean division.
+func intQuoRem(symop string, x, y z3.Int) z3.Int {
+	ctx := x.Context()
+	zero := ctx.FromInt(0, ctx.IntSort()).(z3.Int)
+	one := ctx.FromInt(1, ctx.IntSort()).(z3.Int)
+	adj := x.Mod(y).Eq(zero).Or(x.GE(zero)).IfThenElse(zero, y.GE(zero).IfThenElse(one, one.Neg())).(z3.Int)
+	q := x.Div(y).Add(adj)
+	if symop == "Quo" {
+		return q
+	}
+	return x.Sub(q.Mul(y))
+}
+
+// constBinary evaluates a binary operator on untyped constants.
+func (p *exprParser) constBinary(x *ast.BinaryExpr, l, r constant.Value) (operand, error) {
+	if (l.Kind() == constant.Bool) != (r.Kind() == constant.Bool) {
+		return operand{}, p.errorf(x, "mismatched constants %s and %s", l, r)
+	}
+	switch x.Op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		if l.Kind() == constant.Bool && x.Op != token.EQL && x.Op != token.NEQ {
+			break
+		}
+		return operand{konst: constant.MakeBool(constant.Compare(l, x.Op, r))}, nil
+	case token.LAND, token.LOR:
+		if l.Kind() != constant.Bool {
+			break
+		}
+		return operand{konst: constant.BinaryOp(l, x.Op, r)}, nil
+	case token.ADD, token.SUB, token.MUL, token.QUO, token.REM, token.AND, token.OR, token.XOR, token.AND_NOT:
+		if l.Kind() == constant.Bool {
+			break
+		}
+		intOp := l.Kind() == constant.Int && r.Kind() == constant.Int
+		if x.Op == token.REM || x.Op == token.AND || x.Op == token.OR || x.Op == token.XOR || x.Op == token.AND_NOT {
+			if !intOp {
+				break
+			}
+		}
+		if (x.Op == token.QUO || x.Op == token.REM) && constant.Sign(r) == 0 {
+			return operand{}, p.errorf(x, "division by zero")
+		}
+		tok := x.Op
+		if tok == token.QUO && intOp {
+			tok = token.QUO_ASSIGN // Integer division.
+		}
+		return operand{konst: constant.BinaryOp(l, tok, r)}, nil
+	}
+	return operand{}, p.errorf(x, "operator %s not defined on %s", x.Op, l)
+}
+
+// shift evaluates the shift operator x with operands l and r.
+func (p *exprParser) shift(x *ast.BinaryExpr, op ops.Op, l, r operand) (operand, error) {
+	if r.val == nil {
+		n, ok := constant.Uint64Val(constant.ToInt(r.konst))
+		if !ok {
+			return operand{}, p.errorf(x, "invalid shift count %s", r.konst)
+		}
+		if l.val == nil {
+			if constant.ToInt(l.konst).Kind() != constant.Int {
+				return operand{}, p.errorf(x, "shifted operand %s must be integer", l.konst)
+			}
+			return operand{konst: constant.Shift(constant.ToInt(l.konst), x.Op, uint(n))}, nil
+		}
+		if l.typ.Flags&ops.IsInteger == 0 {
+			return operand{}, p.errorf(x, "operator %s not defined on %s", x.Op, typeName(l.typ))
+		}
+		// Z3 shifts by at least the width are the same as Go's.
+		if n > uint64(l.typ.Bits) {
+			n = uint64(l.typ.Bits)
+		}
+		r = operand{val: p.ctx.FromInt(int64(n), l.val.Sort()), typ: l.typ}
+	} else {
+		if r.typ.Flags&ops.IsInteger == 0 {
+			return operand{}, p.errorf(x, "shift count type %s, must be integer", typeName(r.typ))
+		}
+		if l.val == nil {
+			var err error
+			if l, err = p.convertConst(x, l.konst, r.typ); err != nil {
+				return operand{}, err
+			}
+		}
+		if l.typ.Flags&ops.IsInteger == 0 {
+			return operand{}, p.errorf(x, "operator %s not defined on %s", x.Op, typeName(l.typ))
+		}
+		r = operand{val: shiftCount(r.val.(z3.BV), l.typ.Bits), typ: l.typ}
+	}
+	symop := op.Method
+	if op.Flags&ops.Z3SignedPrefix != 0 {
+		if l.typ.Flags&ops.IsUnsigned != 0 {
+			symop = "U" + symop
+		} else {
+			symop = "S" + symop
+		}
+	}
+	return operand{val: call(l.val, symop, r.val), typ: l.typ}, nil
+}
+
+// shiftCount converts the unsigned shift count n to a bit-vector of
+// width bits that shifts by the same amount.
+func shiftCount(n z3.BV, bits int) z3.BV {
+	nbits := n.Sort().BVSize()
+	switch {
+	case nbits < bits:
+		return n.ZeroExtend(bits - nbits)
+	case nbits > bits:
+		ctx := n.Context()
+		w := ctx.FromInt(int64(bits), n.Sort()).(z3.BV)
+		return n.UGE(w).IfThenElse(ctx.FromInt(int64(bits), ctx.BVSort(bits)), n.Extract(bits-1, 0)).(z3.BV)
+	}
+	return n
+}
+
+func (p *exprParser) call(x *ast.CallExpr) (operand, error) {
+	id, ok := x.Fun.(*ast.Ident)
+	if !ok {
+		return operand{}, p.errorf(x, "unsupported call")
+	}
+	if f, ok := p.env.Funcs[id.Name]; ok {
+		return p.apply(x, id.Name, f)
+	}
+	for _, t := range ops.Types {
+		if t.ConType == id.Name && t.Flags&(ops.IsInteger|ops.IsFloat) != 0 {
+			return p.convert(x, t)
+		}
+	}
+	return operand{}, p.errorf(x, "undefined: %s", id.Name)
+}
+
+// apply applies uninterpreted function f to the arguments of x.
+func (p *exprParser) apply(x *ast.CallExpr, name string, f z3.FuncDecl) (operand, error) {
+	domain := f.Domain()
+	if len(x.Args) != len(domain) {
+		return operand{}, p.errorf(x, "%s takes %d arguments, got %d", name, len(domain), len(x.Args))
+	}
+	args := make([]z3.Value, len(x.Args))
+	for i, argx := range x.Args {
+		arg, err := p.expr(argx)
+		if err != nil {
+			return operand{}, err
+		}
+		want, err := p.typeOf(argx, domain[i], p.env.Unsigned[name])
+		if err != nil {
+			return operand{}, err
+		}
+		if arg.val == nil {
+			if arg, err = p.convertConst(argx, arg.konst, want); err != nil {
+				return operand{}, err
+			}
+		} else if arg.typ != want {
+			return operand{}, p.errorf(argx, "cannot use %s value as %s argument to %s", typeName(arg.typ), typeName(want), name)
+		}
+		args[i] = arg.val
+	}
+	typ, err := p.typeOf(x, f.Range(), p.env.Unsigned[name])
+	if err != nil {
+		return operand{}, err
+	}
+	return operand{val: f.Apply(args...), typ: typ}, nil
+}
+
+// convert converts the argument of x to type t.
+func (p *exprParser) convert(x *ast.CallExpr, t ops.Type) (operand, error) {
+	if len(x.Args) != 1 {
+		return operand{}, p.errorf(x, "conversion to %s takes 1 argument", t.ConType)
+	}
+	arg, err := p.expr(x.Args[0])
+	if err != nil {
+		return operand{}, err
+	}
+	if arg.val == nil {
+		return p.convertConst(x, arg.konst, t)
+	}
+	from := arg.typ
+	sort := p.sortOf(t)
+	var val z3.Value
+	switch {
+	case from.Flags&ops.IsInteger != 0 && t.Flags&ops.IsInteger != 0:
+		v := arg.val.(z3.BV)
+		switch {
+		case t.Bits < from.Bits:
+			v = v.Extract(t.Bits-1, 0)
+		case t.Bits > from.Bits && from.Flags&ops.IsUnsigned != 0:
+			v = v.ZeroExtend(t.Bits - from.Bits)
+		case t.Bits > from.Bits:
+			v = v.SignExtend(t.Bits - from.Bits)
+		}
+		val = v
+	case from.Flags&ops.IsInteger != 0 && t.Flags&ops.IsFloat != 0:
+		if from.Flags&ops.IsUnsigned != 0 {
+			val = arg.val.(z3.BV).UToFloat(sort)
+		} else {
+			val = arg.val.(z3.BV).SToFloat(sort)
+		}
+	case from.Flags&ops.IsFloat != 0 && t.Flags&ops.IsFloat != 0:
+		val = arg.val.(z3.Float).ToFloat(sort)
+	case from.Flags&ops.IsFloat != 0 && t.Flags&ops.IsInteger != 0:
+		// Go truncates toward zero.
+		f := arg.val.(z3.Float).Round(z3.RoundToZero)
+		if t.Flags&ops.IsUnsigned != 0 {
+			val = f.ToUBV(t.Bits)
+		} else {
+			val = f.ToSBV(t.Bits)
+		}
+	default:
+		return operand{}, p.errorf(x, "cannot convert %s to %s", typeName(from), t.ConType)
+	}
+	return operand{val: val, typ: t}, nil
+}
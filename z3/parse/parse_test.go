@@ -0,0 +1,175 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parse
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// equiv reports whether a and b are equal under all assignments.
+func equiv(t *testing.T, ctx *z3.Context, a, b z3.Value) bool {
+	t.Helper()
+	s := z3.NewSolver(ctx)
+	s.Assert(ctx.Distinct(a, b))
+	sat, err := s.Check()
+	if err != nil {
+		t.Fatalf("checking %s == %s: %s", a, b, err)
+	}
+	return !sat
+}
+
+func TestExpr(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x, y, z := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z")
+	a, b := ctx.BVConst("a", 8), ctx.BVConst("b", 8)
+	u := ctx.BVConst("u", 8)
+	w := ctx.BVConst("w", 32)
+	r := ctx.RealConst("r")
+	p := ctx.BoolConst("p")
+	fl := ctx.Const("fl", ctx.Float64Sort()).(z3.Float)
+	fs := ctx.Const("fs", ctx.Float32Sort()).(z3.Float)
+	f := ctx.FuncDecl("f", []z3.Sort{ctx.IntSort()}, ctx.IntSort())
+	g := ctx.FuncDecl("g", []z3.Sort{ctx.BVSort(8)}, ctx.BoolSort())
+	env := &Env{
+		Vars: map[string]z3.Value{
+			"x": x, "y": y, "z": z, "a": a, "b": b, "u": u, "w": w,
+			"r": r, "p": p, "fl": fl, "fs": fs,
+		},
+		Funcs:    map[string]z3.FuncDecl{"f": f, "g": g},
+		Unsigned: map[string]bool{"u": true},
+	}
+	i := func(v int64) z3.Int { return ctx.FromInt(v, ctx.IntSort()).(z3.Int) }
+	bv := func(v int64, bits int) z3.BV { return ctx.FromInt(v, ctx.BVSort(bits)).(z3.BV) }
+	zero := i(0)
+
+	for _, test := range []struct {
+		src  string
+		want z3.Value
+	}{
+		{"x + 2*y < z && f(x) != 0", x.Add(i(2).Mul(y)).LT(z).And(f.Apply(x).(z3.Int).NE(zero))},
+		{"1 + 2*3", i(7)},
+		{"7 / 2", i(3)},
+		{"1.5 * 2", ctx.FromInt(3, ctx.RealSort())},
+		{"1 < 2", ctx.FromBool(true)},
+		{"!p || false", p.Not()},
+		{"a < b", a.SLT(b)},
+		{"u < 200", u.ULT(bv(200, 8))},
+		{"a >> 1", a.SRsh(bv(1, 8))},
+		{"u >> 1", u.URsh(bv(1, 8))},
+		{"a << 100", bv(0, 8)},
+		{"a << u", a.Lsh(u)},
+		{"w << u", w.Lsh(u.ZeroExtend(24))},
+		{"a << w", a.Lsh(w.UGE(bv(8, 32)).IfThenElse(bv(8, 8), w.Extract(7, 0)).(z3.BV))},
+		{"a / b", a.SDiv(b)},
+		{"a % b", a.SRem(b)},
+		{"u % 3", u.URem(bv(3, 8))},
+		{"a &^ b", a.And(b.Not())},
+		{"^a", a.Not()},
+		{"-a", a.Neg()},
+		{"+x", x},
+		{"g(a) == p", g.Apply(a).(z3.Bool).Eq(p)},
+		{"g(-1)", g.Apply(bv(-1, 8))},
+		{"int32(a)", a.SignExtend(24)},
+		{"uint32(u)", u.ZeroExtend(24)},
+		{"int8(w)", w.Extract(7, 0)},
+		{"uint8(a) < 200", a.ULT(bv(200, 8))},
+		{"float64(a)", a.SToFloat(ctx.Float64Sort())},
+		{"fl == 0", fl.IEEEEq(ctx.FromInt(0, ctx.Float64Sort()).(z3.Float))},
+		// Rounding 0.002877 to 64 bits and then to 53 gives a
+		// different float64.
+		{"fl == 0.002877", fl.IEEEEq(ctx.FromBigFloat(big.NewFloat(0.002877), ctx.Float64Sort()).(z3.Float))},
+		{"fs == 0.1", fs.IEEEEq(ctx.FromBigFloat(big.NewFloat(float64(float32(0.1))), ctx.Float32Sort()).(z3.Float))},
+		{"r / 2", r.Div(ctx.FromInt(2, ctx.RealSort()).(z3.Real))},
+		{"r < 0.5", r.LT(ctx.FromBigRat(big.NewRat(1, 2)))},
+	} {
+		got, err := Expr(ctx, test.src, env)
+		if err != nil {
+			t.Errorf("%s: %s", test.src, err)
+			continue
+		}
+		if got.Sort().String() != test.want.Sort().String() {
+			t.Errorf("%s: got sort %s, want %s", test.src, got.Sort(), test.want.Sort())
+			continue
+		}
+		if !equiv(t, ctx, got, test.want) {
+			t.Errorf("%s: got %s, want %s", test.src, got, test.want)
+		}
+	}
+}
+
+func TestExprQuoRem(t *testing.T) {
+	// Int division must truncate like Go's *big.Int Quo and Rem.
+	ctx := z3.NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	env := &Env{Vars: map[string]z3.Value{"x": x, "y": y}}
+	quo, err := Expr(ctx, "x / y", env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rem, err := Expr(ctx, "x % y", env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range [][2]int64{{7, 2}, {-7, 2}, {7, -2}, {-7, -2}, {6, -3}} {
+		i := func(v int64) z3.Int { return ctx.FromInt(v, ctx.IntSort()).(z3.Int) }
+		s := z3.NewSolver(ctx)
+		s.Assert(x.Eq(i(c[0])))
+		s.Assert(y.Eq(i(c[1])))
+		if sat, err := s.Check(); !sat {
+			t.Fatalf("unsat: %v", err)
+		}
+		m := s.Model()
+		gotQ, _, _ := m.Eval(quo, true).(z3.Int).AsInt64()
+		gotR, _, _ := m.Eval(rem, true).(z3.Int).AsInt64()
+		if wantQ, wantR := c[0]/c[1], c[0]%c[1]; gotQ != wantQ || gotR != wantR {
+			t.Errorf("%d /,%% %d: got %d, %d; want %d, %d", c[0], c[1], gotQ, gotR, wantQ, wantR)
+		}
+	}
+}
+
+func TestExprErrors(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	env := &Env{
+		Vars: map[string]z3.Value{
+			"x":  ctx.IntConst("x"),
+			"a":  ctx.BVConst("a", 8),
+			"p":  ctx.BoolConst("p"),
+			"fl": ctx.Const("fl", ctx.Float64Sort()),
+			"fs": ctx.Const("fs", ctx.Float32Sort()),
+		},
+		Funcs: map[string]z3.FuncDecl{
+			"f": ctx.FuncDecl("f", []z3.Sort{ctx.IntSort()}, ctx.IntSort()),
+		},
+	}
+	for _, test := range []struct {
+		src, err string
+	}{
+		{"x +", "1:4: expected operand"},
+		{"y", "1:1: undefined: y"},
+		{"x + a", "1:1: mismatched types Int and int8"},
+		{"a + 300", "constant 300 overflows int8"},
+		{"fl < 1e309", "overflows float64"},
+		{"fs < -1e39", "overflows float32"},
+		{"p + p", "operator + not defined on bool"},
+		{"a & 1.5", "cannot use 1.5 as int8 value"},
+		{"f(x, x)", "f takes 1 arguments, got 2"},
+		{"f(a)", "cannot use int8 value as Int argument to f"},
+		{"1 / 0", "division by zero"},
+		{`"s"`, "string literals are not supported"},
+		{"x[1]", "unsupported expression"},
+		{"x << 1", "operator << not defined on Int"},
+	} {
+		_, err := Expr(ctx, test.src, env)
+		if err == nil {
+			t.Errorf("%s: expected error %q", test.src, test.err)
+		} else if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got error %q, want %q", test.src, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package portfolio checks satisfiability by running several solvers
+// in parallel.
+//
+// Z3's performance on a given problem can vary widely with its
+// random seed, tactic, and parameters. Check runs the same problem
+// under several such strategies at once, each in its own Context so
+// they don't block each other, and returns the first definitive
+// answer.
+//
+// CubeAndConquer instead splits a problem into independent
+// sub-problems using Solver.Cubes and checks them on a pool of
+// workers.
+package portfolio
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// A Strategy is one way of checking satisfiability in a portfolio.
+// The zero Strategy uses Z3's default solver and parameters.
+type Strategy struct {
+	// Config, if non-nil, configures the Context the strategy
+	// runs in. It must have been created with
+	// z3.NewContextConfig.
+	Config *z3.Config
+
+	// Tactic, if non-empty, is the name of the Z3 tactic to
+	// construct the strategy's solver from, such as "qfbv".
+	Tactic string
+
+	// Params, if non-nil, is called to set the parameters of
+	// the strategy's solver.
+	Params func(c *z3.Config)
+}
+
+// Seeds returns n strategies that use Z3's default solver with
+// random seeds 0 through n-1.
+func Seeds(n int) []Strategy {
+	strategies := make([]Strategy, n)
+	for i := range strategies {
+		seed := uint(i)
+		strategies[i].Params = func(c *z3.Config) {
+			c.SetUint("random_seed", seed)
+		}
+	}
+	return strategies
+}
+
+// A Result is the outcome of checking a portfolio.
+type Result struct {
+	// Sat is true if the assertions are satisfiable.
+	Sat bool
+
+	// Model is a model of the assertions in the Context of the
+	// checked solver if Sat is true, or nil otherwise.
+	Model *z3.Model
+
+	// Winner is the index of the strategy that produced the
+	// result. For CubeAndConquer, it is the index of the cube
+	// that produced a model, or -1 if the result is unsat.
+	Winner int
+}
+
+// A result is the outcome of one check in a portfolio.
+type result struct {
+	i     int
+	sat   bool
+	err   error
+	model *z3.Model
+}
+
+// interruptInterval is how often Check re-interrupts strategies that
+// have not yet stopped. A Context ignores interrupts that arrive
+// before its solver starts, so a single interrupt may be lost.
+const interruptInterval = 10 * time.Millisecond
+
+// Check checks the satisfiability of the assertions in s by running
+// each strategy concurrently in a new Context. When one strategy
+// determines satisfiability, Check interrupts the others, waits for
+// them to stop, and returns its result.
+//
+// If no strategy can determine satisfiability, Check returns a
+// *z3.ErrSatUnknown whose reason includes each strategy's reason.
+//
+// Check does not modify s. However, it uses s's Context while copying
+// s's assertions to each strategy.
+func Check(s *z3.Solver, strategies []Strategy) (*Result, error) {
+	if len(strategies) == 0 {
+		panic("portfolio has no strategies")
+	}
+
+	// Set up each strategy's solver.
+	ctxs := make([]*z3.Context, len(strategies))
+	solvers := make([]*z3.Solver, len(strategies))
+	for i, st := range strategies {
+		ctx := z3.NewContext(st.Config)
+		var solver *z3.Solver
+		if st.Tactic != "" {
+			solver = z3.NewSolverFromTactic(ctx, st.Tactic)
+			s.TranslateInto(solver)
+		} else {
+			solver = s.Translate(ctx)
+		}
+		if st.Params != nil {
+			st.Params(solver.Config())
+		}
+		ctxs[i], solvers[i] = ctx, solver
+	}
+
+	results := make(chan result, len(strategies))
+	var wg sync.WaitGroup
+	for i, solver := range solvers {
+		wg.Add(1)
+		go func(i int, solver *z3.Solver) {
+			defer wg.Done()
+			sat, err := solver.Check()
+			results <- result{i: i, sat: sat, err: err}
+		}(i, solver)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Wait for the first definitive result.
+	winner := -1
+	var sat bool
+	var reasons []string
+	for r := range results {
+		if r.err == nil {
+			winner, sat = r.i, r.sat
+			break
+		}
+		reasons = append(reasons, r.err.Error())
+	}
+
+	if winner >= 0 {
+		interruptAll(ctxs, winner, results)
+	}
+
+	if winner < 0 {
+		return nil, &z3.ErrSatUnknown{Reason: strings.Join(reasons, "; ")}
+	}
+	res := &Result{Sat: sat, Winner: winner}
+	if sat {
+		res.Model = solvers[winner].Model().Translate(s.Context())
+	}
+	return res, nil
+}
+
+// interruptAll interrupts each Context in ctxs except ctxs[skip]
+// until results is closed.
+func interruptAll(ctxs []*z3.Context, skip int, results <-chan result) {
+	ticker := time.NewTicker(interruptInterval)
+	defer ticker.Stop()
+	for {
+		for i, ctx := range ctxs {
+			if i != skip {
+				ctx.Interrupt()
+			}
+		}
+		select {
+		case _, ok := <-results:
+			if !ok {
+				return
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// CubeConfig configures CubeAndConquer.
+type CubeConfig struct {
+	// Workers is the number of cubes to check in parallel. If
+	// Workers is 0, it defaults to runtime.NumCPU().
+	Workers int
+
+	// Vars, if non-empty, are the Boolean variables to split on.
+	// They must be in the Context of the checked solver.
+	Vars []z3.Bool
+
+	// Backtrack is passed to Solver.Cubes. The zero value is
+	// Z3's default.
+	Backtrack int
+
+	// Params, if non-nil, is called to set the parameters of the
+	// solver that produces cubes, such as
+	// "sat.lookahead.cube.depth".
+	Params func(c *z3.Config)
+}
+
+// CubeAndConquer checks the satisfiability of the assertions in s by
+// splitting them into cubes and checking each cube on a pool of
+// workers, each with its own Context. The assertions are satisfiable
+// if any cube is, so CubeAndConquer stops as soon as a worker finds a
+// model. If config is nil, it uses the default configuration.
+//
+// If no cube is satisfiable but some cube cannot be decided,
+// CubeAndConquer returns a *z3.ErrSatUnknown.
+//
+// Like Check, CubeAndConquer does not modify s.
+func CubeAndConquer(s *z3.Solver, config *CubeConfig) (*Result, error) {
+	var cfg CubeConfig
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	backtrack := -1
+	if cfg.Backtrack != 0 {
+		backtrack = cfg.Backtrack
+	}
+
+	// Cube in a separate Context so s is unchanged and s's
+	// Context is free while cubing.
+	cubeCtx := z3.NewContext(nil)
+	cuber := s.Translate(cubeCtx)
+	if cfg.Params != nil {
+		cfg.Params(cuber.Config())
+	}
+	vars := make([]z3.Bool, len(cfg.Vars))
+	for i, v := range cfg.Vars {
+		vars[i] = v.AsAST().Translate(cubeCtx).AsValue().(z3.Bool)
+	}
+
+	// Start the workers. Each idle worker waits in idle for the
+	// producer to give it a cube.
+	idle := make(chan *cubeWorker, cfg.Workers)
+	results := make(chan result, cfg.Workers)
+	stop := make(chan struct{})
+	workers := make([]*cubeWorker, cfg.Workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		ctx := z3.NewContext(nil)
+		w := &cubeWorker{ctx: ctx, solver: s.Translate(ctx), jobs: make(chan cubeJob, 1)}
+		workers[i] = w
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.run(idle, results, stop, s.Context())
+		}()
+	}
+
+	// Produce cubes. The producer translates each cube into the
+	// Context of the idle worker that will check it, so workers
+	// never wait for the cuber's Context, which is locked while
+	// the next cube is computed.
+	go func() {
+		defer func() {
+			for _, w := range workers {
+				close(w.jobs)
+			}
+		}()
+		i := 0
+		cuber.Cubes(vars, backtrack)(func(cube []z3.Bool) bool {
+			select {
+			case w := <-idle:
+				lits := make([]z3.Bool, len(cube))
+				for j, lit := range cube {
+					lits[j] = lit.AsAST().Translate(w.ctx).AsValue().(z3.Bool)
+				}
+				w.jobs <- cubeJob{i, lits}
+				i++
+				return true
+			case <-stop:
+				return false
+			}
+		})
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var winner *result
+	var reasons []string
+	for r := range results {
+		if r.sat {
+			winner = &r
+			close(stop)
+			interruptWorkers(workers, cubeCtx, results)
+			break
+		}
+		if r.err != nil {
+			reasons = append(reasons, r.err.Error())
+		}
+	}
+
+	switch {
+	case winner != nil:
+		return &Result{Sat: true, Model: winner.model, Winner: winner.i}, nil
+	case reasons != nil:
+		return nil, &z3.ErrSatUnknown{Reason: strings.Join(reasons, "; ")}
+	}
+	return &Result{Sat: false, Winner: -1}, nil
+}
+
+// A cubeJob is a cube to check, in the Context of the worker checking
+// it, and its index.
+type cubeJob struct {
+	i    int
+	cube []z3.Bool
+}
+
+// A cubeWorker checks cubes in its own Context.
+//
+// Once a Context has been interrupted, any operation on it other than
+// Check may fail, so a worker's Context must only be interrupted
+// while it is checking a cube and the worker must not use its solver
+// again after that.
+type cubeWorker struct {
+	ctx    *z3.Context
+	solver *z3.Solver
+
+	// jobs receives the worker's next cube. It has a buffer of
+	// one, so the producer never blocks sending to an idle worker.
+	jobs chan cubeJob
+
+	// mu protects busy, which is true while the worker is in
+	// Check and may be interrupted.
+	mu   sync.Mutex
+	busy bool
+}
+
+// run sends w to idle whenever w is ready for a cube, checks each
+// cube from w.jobs until w.jobs is closed, and sends the results to
+// results. Models are translated to target. Once stop is closed, run
+// skips the remaining jobs.
+func (w *cubeWorker) run(idle chan<- *cubeWorker, results chan<- result, stop <-chan struct{}, target *z3.Context) {
+	stopped := func() bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return false
+		}
+	}
+	for {
+		// idle has room for every worker, and w is in it at
+		// most once.
+		idle <- w
+		j, ok := <-w.jobs
+		if !ok {
+			return
+		}
+		w.mu.Lock()
+		if stopped() {
+			w.mu.Unlock()
+			continue
+		}
+		w.solver.Push()
+		for _, lit := range j.cube {
+			w.solver.Assert(lit)
+		}
+		w.busy = true
+		w.mu.Unlock()
+
+		r := result{i: j.i}
+		r.sat, r.err = w.solver.Check()
+
+		w.mu.Lock()
+		w.busy = false
+		if stopped() {
+			// We may have been interrupted.
+			w.mu.Unlock()
+			continue
+		}
+		if r.sat {
+			r.model = w.solver.Model().Translate(target)
+		}
+		w.solver.Pop()
+		w.mu.Unlock()
+		results <- r
+	}
+}
+
+// interruptWorkers interrupts each worker that is checking a cube, as
+// well as the cuber's Context cubeCtx, until results is closed. The
+// caller must have closed the workers' stop channel. cubeCtx is not
+// used again, so it may be interrupted at any time.
+func interruptWorkers(workers []*cubeWorker, cubeCtx *z3.Context, results <-chan result) {
+	ticker := time.NewTicker(interruptInterval)
+	defer ticker.Stop()
+	for {
+		cubeCtx.Interrupt()
+		for _, w := range workers {
+			w.mu.Lock()
+			if w.busy {
+				w.ctx.Interrupt()
+			}
+			w.mu.Unlock()
+		}
+		select {
+		case _, ok := <-results:
+			if !ok {
+				return
+			}
+		case <-ticker.C:
+		}
+	}
+}
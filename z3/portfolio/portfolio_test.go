@@ -0,0 +1,107 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+func TestCheck(t *testing.T) {
+	// Find a non-trivial factorization. The full-size instance
+	// takes long enough that the strategies genuinely race.
+	bits, n := 28, int64(16381*16369)
+	if testing.Short() {
+		bits, n = 16, 251*241
+	}
+	ctx := z3.NewContext(nil)
+	x, y := ctx.BVConst("x", bits), ctx.BVConst("y", bits)
+	one := ctx.FromInt(1, x.Sort()).(z3.BV)
+	s := z3.NewSolver(ctx)
+	s.Assert(x.Mul(y).Eq(ctx.FromInt(n, x.Sort()).(z3.BV)))
+	s.Assert(x.UGT(one).And(y.UGT(one), x.UMulNoOverflow(y)))
+
+	strategies := append(Seeds(3), Strategy{Tactic: "qfbv"})
+	res, err := Check(s, strategies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Sat || res.Winner < 0 || res.Winner >= len(strategies) {
+		t.Fatalf("got %+v, want satisfiable", res)
+	}
+	// The model is in ctx.
+	xv, _, _ := res.Model.Eval(x, true).(z3.BV).AsUint64()
+	yv, _, _ := res.Model.Eval(y, true).(z3.BV).AsUint64()
+	if int64(xv*yv) != n || xv == 1 || yv == 1 {
+		t.Errorf("bad factorization %d * %d", xv, yv)
+	}
+
+	// Unsatisfiable.
+	s.Assert(x.ULT(one))
+	res, err = Check(s, Seeds(2))
+	if err != nil || res.Sat || res.Model != nil {
+		t.Errorf("got %+v, %v; want unsat", res, err)
+	}
+}
+
+func TestCheckUnknown(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x, y, z := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z")
+	zero := ctx.FromInt(0, ctx.IntSort()).(z3.Int)
+	cube := func(v z3.Int) z3.Int { return v.Mul(v, v) }
+	s := z3.NewSolver(ctx)
+	s.Assert(x.GT(zero).And(y.GT(zero), z.GT(zero)))
+	s.Assert(cube(x).Add(cube(y)).Eq(cube(z)))
+
+	timeout := z3.NewContextConfig().SetUint("timeout", 50)
+	_, err := Check(s, []Strategy{{Config: timeout}, {Config: timeout}})
+	if _, ok := err.(*z3.ErrSatUnknown); !ok {
+		t.Errorf("want *z3.ErrSatUnknown, got %v", err)
+	}
+}
+
+func TestCubeAndConquer(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x, y := ctx.BVConst("x", 16), ctx.BVConst("y", 16)
+	s := z3.NewSolver(ctx)
+	// x and y differ in exactly one of their low bits.
+	diff := x.Xor(y)
+	s.Assert(diff.ULT(ctx.FromInt(256, x.Sort()).(z3.BV)))
+	s.Assert(diff.And(diff.Sub(ctx.FromInt(1, x.Sort()).(z3.BV))).Eq(ctx.FromInt(0, x.Sort()).(z3.BV)))
+	s.Assert(x.Eq(y).Not())
+	s.Assert(x.UGT(ctx.FromInt(1000, x.Sort()).(z3.BV)))
+
+	cfg := &CubeConfig{
+		Workers: 4,
+		Params: func(c *z3.Config) {
+			c.SetUint("sat.lookahead.cube.depth", 3)
+		},
+	}
+	res, err := CubeAndConquer(s, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Sat {
+		t.Fatalf("got %+v, want satisfiable", res)
+	}
+	xv, _, _ := res.Model.Eval(x, true).(z3.BV).AsUint64()
+	yv, _, _ := res.Model.Eval(y, true).(z3.BV).AsUint64()
+	if d := xv ^ yv; d == 0 || d >= 256 || d&(d-1) != 0 || xv <= 1000 {
+		t.Errorf("bad model x=%d, y=%d", xv, yv)
+	}
+
+	// The high bits of x and y can't differ.
+	s.Assert(x.Extract(15, 8).Eq(y.Extract(15, 8)).Not())
+	before := s.String()
+	res, err = CubeAndConquer(s, cfg)
+	if err != nil || res.Sat || res.Winner != -1 {
+		t.Errorf("got %+v, %v; want unsat", res, err)
+	}
+	// s itself is unchanged.
+	if after := s.String(); after != before {
+		t.Errorf("CubeAndConquer changed s from\n%s\nto\n%s", before, after)
+	}
+}
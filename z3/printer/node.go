@@ -0,0 +1,200 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package printer
+
+import (
+	"go/token"
+	"strings"
+)
+
+// A node is a Go expression to print. Nodes track precedence so they
+// can be parenthesized and can be broken across lines.
+type node struct {
+	kind nodeKind
+
+	// text is the text of a leaf, the operator of a unary or
+	// binary expression, or the function name of a call.
+	text string
+
+	// recv is the receiver of a method call.
+	recv *node
+
+	// args are the operands or call arguments.
+	args []*node
+
+	// prec is the Go precedence of the node's outermost operator.
+	prec int
+
+	// flat caches the single-line form of the node.
+	flat string
+}
+
+type nodeKind int
+
+const (
+	leafNode nodeKind = iota
+	unaryNode
+	binNode
+	callNode
+	parenNode
+)
+
+const (
+	unaryPrec   = token.UnaryPrec
+	primaryPrec = token.HighestPrec
+)
+
+func leaf(text string) *node {
+	return &node{kind: leafNode, text: text, prec: primaryPrec}
+}
+
+func paren(x *node) *node {
+	return &node{kind: parenNode, args: []*node{x}, prec: primaryPrec}
+}
+
+func unary(op string, x *node) *node {
+	// Avoid "--x", which is a decrement.
+	if x.prec < unaryPrec || op == "-" && strings.HasPrefix(x.String(), "-") {
+		x = paren(x)
+	}
+	return &node{kind: unaryNode, text: op, args: []*node{x}, prec: unaryPrec}
+}
+
+func call(name string, args ...*node) *node {
+	return &node{kind: callNode, text: name, args: args, prec: primaryPrec}
+}
+
+func method(recv *node, name string, args ...*node) *node {
+	if recv.prec < primaryPrec || recv.kind == leafNode && !isIdent(recv.text) {
+		recv = paren(recv)
+	}
+	return &node{kind: callNode, text: name, recv: recv, args: args, prec: primaryPrec}
+}
+
+func isIdent(s string) bool {
+	return s != "" && !('0' <= s[0] && s[0] <= '9')
+}
+
+// binaryNode combines xs with binary operator tok. Operands that are
+// themselves tok expressions are flattened into the result if that
+// doesn't change the value. If assoc is false, operators that are
+// associative on integers, such as +, are not treated as associative,
+// as is the case for floating-point values.
+func binaryNode(tok token.Token, assoc bool, xs []*node) *node {
+	prec := tok.Precedence()
+	op := tok.String()
+	switch tok {
+	case token.ADD, token.MUL, token.AND, token.OR, token.XOR, token.LAND, token.LOR:
+	default:
+		assoc = false
+	}
+	n := &node{kind: binNode, text: op, prec: prec}
+	for i, x := range xs {
+		switch {
+		case x.kind == binNode && x.text == op && (assoc || i == 0) && prec != token.EQL.Precedence():
+			n.args = append(n.args, x.args...)
+			continue
+		case x.prec < prec, x.prec == prec && (i > 0 || prec == token.EQL.Precedence()):
+			x = paren(x)
+		}
+		n.args = append(n.args, x)
+	}
+	return n
+}
+
+// String returns the single-line form of n.
+func (n *node) String() string {
+	if n.flat != "" {
+		return n.flat
+	}
+	var b strings.Builder
+	switch n.kind {
+	case leafNode:
+		b.WriteString(n.text)
+	case parenNode:
+		b.WriteString("(" + n.args[0].String() + ")")
+	case unaryNode:
+		b.WriteString(n.text + n.args[0].String())
+	case binNode:
+		for i, x := range n.args {
+			if i > 0 {
+				b.WriteString(" " + n.text + " ")
+			}
+			b.WriteString(x.String())
+		}
+	case callNode:
+		if n.recv != nil {
+			b.WriteString(n.recv.String() + ".")
+		}
+		b.WriteString(n.text + "(")
+		for i, x := range n.args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(x.String())
+		}
+		b.WriteString(")")
+	}
+	n.flat = b.String()
+	return n.flat
+}
+
+const tabWidth = 8
+
+// render writes n to p.buf, breaking lines that would extend past
+// p.cfg.Width. The current line is indented by indent tabs and the
+// cursor is at column col.
+func (p *printer) render(n *node, indent, col int) int {
+	flat := n.String()
+	if p.cfg.Width <= 0 || col+len(flat) <= p.cfg.Width {
+		p.buf.WriteString(flat)
+		return col + len(flat)
+	}
+	newline := func(indent int) int {
+		p.buf.WriteString("\n" + strings.Repeat("\t", indent))
+		return indent * tabWidth
+	}
+	switch n.kind {
+	case leafNode:
+		p.buf.WriteString(flat)
+		col += len(flat)
+	case parenNode:
+		p.buf.WriteString("(")
+		col = p.render(n.args[0], indent, col+1)
+		p.buf.WriteString(")")
+		col++
+	case unaryNode:
+		p.buf.WriteString(n.text)
+		col = p.render(n.args[0], indent, col+len(n.text))
+	case binNode:
+		for i, x := range n.args {
+			if i > 0 {
+				p.buf.WriteString(" " + n.text)
+				col = newline(indent + 1)
+			}
+			col = p.render(x, indent+1, col)
+		}
+	case callNode:
+		if n.recv != nil {
+			col = p.render(n.recv, indent, col)
+			p.buf.WriteString(".")
+			col++
+		}
+		p.buf.WriteString(n.text + "(")
+		if len(n.args) == 0 {
+			p.buf.WriteString(")")
+			return col + len(n.text) + 2
+		}
+		for _, x := range n.args {
+			col = newline(indent + 1)
+			p.render(x, indent+1, col)
+			p.buf.WriteString(",")
+		}
+		newline(indent)
+		p.buf.WriteString(")")
+		col = indent*tabWidth + 1
+	}
+	return col
+}
@@ -0,0 +1,777 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package printer renders z3 values as Go source.
+//
+// Values are printed using the operators and conversions of package
+// st. For example, the S-expression
+//
+//	(bvule (bvadd x #x01) y)
+//
+// over 8-bit bit-vectors x and y is printed as
+//
+//	uint8(x + 1) <= uint8(y)
+//
+// Since Go integer operators carry their signedness in their operand
+// types rather than in the operator, bit-vector constants are signed
+// unless they are listed in Config.Unsigned, and the printer inserts
+// conversions wherever an operation needs the other signedness. Int
+// and Real values print like st's Integer and Real types, with Z3's
+// Euclidean integer division and modulus printed as the Div and Mod
+// methods of *big.Int. If-then-else prints as st.Ite. Floating-point
+// numerals print as the shortest decimal that rounds to their value.
+// Operations with no Go equivalent print as a call to a function
+// named after the Z3 operation, such as bvsmod(x, y). Symbolic Z3
+// names are spelled out, so Z3's = on floats, which unlike == is true
+// for identical NaNs, prints as eq(f, g).
+//
+// This is the inverse of package parse for the operations parse
+// supports.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/aclements/go-z3/internal/ops"
+	"github.com/aclements/go-z3/z3"
+)
+
+// A Config controls the output of Fprint.
+type Config struct {
+	// Width is the maximum line width. Lines longer than Width
+	// are broken after binary operators and around call
+	// arguments, indenting continuation lines by one tab, which
+	// counts as 8 columns. If Width is 0, lines are never broken.
+	Width int
+
+	// Unsigned is the set of constant and function names whose
+	// bit-vector values are unsigned, as in parse.Env. Other
+	// bit-vectors are signed.
+	Unsigned map[string]bool
+
+	// NoTemps disables temporaries for shared subterms. By
+	// default, every compound subterm that is used more than once
+	// is bound to a temporary by a statement like
+	//
+	//	t0 := x + y
+	//
+	// that precedes the final expression.
+	NoTemps bool
+}
+
+// Fprint prints v to w using the default Config.
+func Fprint(w io.Writer, v z3.Value) error {
+	return new(Config).Fprint(w, v)
+}
+
+// Sprint returns v as Go source using the default Config. If v cannot
+// be printed, Sprint returns its S-expression.
+func Sprint(v z3.Value) string {
+	var buf bytes.Buffer
+	if err := Fprint(&buf, v); err != nil {
+		return v.String()
+	}
+	return buf.String()
+}
+
+// Fprint prints v to w as Go source. The output is a sequence of
+// assignments to temporaries, each followed by a newline, and then a
+// Go expression equivalent to v.
+//
+// Fprint returns an error if v contains quantifiers or bound
+// variables, which have no Go equivalent.
+func (cfg *Config) Fprint(w io.Writer, v z3.Value) (err error) {
+	p := &printer{
+		cfg:   cfg,
+		refs:  make(map[uint64]int),
+		names: make(map[string]bool),
+		temps: make(map[uint64]expr),
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			if e, ok := e.(printError); ok {
+				err = e.err
+				return
+			}
+			panic(e)
+		}
+	}()
+
+	root := v.AsAST()
+	p.count(root)
+	e := p.expr(root)
+	n := p.typed(e)
+	for _, stmt := range p.stmts {
+		p.buf.WriteString(stmt.name + " := ")
+		p.render(stmt.n, 0, len(stmt.name)+4)
+		p.buf.WriteByte('\n')
+	}
+	p.render(n, 0, 0)
+	_, err = w.Write(p.buf.Bytes())
+	return err
+}
+
+// printError wraps an error raised while printing.
+type printError struct {
+	err error
+}
+
+type printer struct {
+	cfg *Config
+	buf bytes.Buffer
+
+	// refs counts the parents of each AST node, by ID.
+	refs map[uint64]int
+
+	// names is the set of constant and function names in the
+	// value, which temporaries must avoid.
+	names map[string]bool
+
+	// temps maps shared AST nodes to their temporaries.
+	temps  map[uint64]expr
+	stmts  []stmt
+	ntemps int
+}
+
+// A stmt binds a temporary.
+type stmt struct {
+	name string
+	n    *node
+}
+
+func (p *printer) errorf(format string, args ...interface{}) {
+	panic(printError{fmt.Errorf(format, args...)})
+}
+
+// count records the number of parents of each node in the DAG rooted
+// at ast.
+func (p *printer) count(ast z3.AST) {
+	id := ast.ID()
+	p.refs[id]++
+	if p.refs[id] > 1 {
+		return
+	}
+	switch ast.Kind() {
+	case z3.ASTKindApp:
+		decl := ast.Decl()
+		if decl.Kind() == z3.DeclKindUninterpreted {
+			p.names[decl.Name()] = true
+		}
+		for _, arg := range ast.Args() {
+			p.count(arg)
+		}
+	case z3.ASTKindNumeral:
+	default:
+		p.errorf("cannot print %s as Go", ast)
+	}
+}
+
+// An expr is a printed subterm and its Go type.
+type expr struct {
+	n   *node
+	typ ops.Type
+
+	// lit is the value of a bit-vector numeral, which is
+	// printed according to the type it is used as.
+	lit *big.Int
+
+	// untyped indicates n is an untyped constant.
+	untyped bool
+}
+
+var boolType = typeFor(ops.IsBool, 0)
+
+// typeFor returns the Go type with the given flags and size.
+func typeFor(flags ops.Flags, bits int) ops.Type {
+	for _, t := range ops.Types {
+		// Prefer the sized names for int and uint.
+		if t.ConType == "int" || t.ConType == "uint" || t.ConType == "uintptr" {
+			continue
+		}
+		if t.Flags == flags && t.Bits == bits {
+			return t
+		}
+	}
+	// An odd-sized bit-vector or an unsupported sort.
+	name := "int" + strconv.Itoa(bits)
+	if flags&ops.IsUnsigned != 0 {
+		name = "u" + name
+	}
+	return ops.Type{StName: name, ConType: name, SymType: "BV", Flags: flags, Bits: bits}
+}
+
+// bvType returns the bit-vector type of the given size and signedness.
+func bvType(bits int, unsigned bool) ops.Type {
+	if unsigned {
+		return typeFor(ops.IsInteger|ops.IsUnsigned, bits)
+	}
+	return typeFor(ops.IsInteger, bits)
+}
+
+// typeOf returns the Go type of values of sort s.
+func typeOf(s z3.Sort, unsigned bool) ops.Type {
+	switch s.Kind() {
+	case z3.KindBool:
+		return boolType
+	case z3.KindInt:
+		return typeFor(ops.IsBigInt, 0)
+	case z3.KindReal:
+		return typeFor(ops.IsBigRat, 0)
+	case z3.KindBV:
+		return bvType(s.BVSize(), unsigned)
+	case z3.KindFloatingPoint:
+		switch ebits, sbits := s.FloatSize(); {
+		case ebits == 8 && sbits == 24:
+			return typeFor(ops.IsFloat, 32)
+		case ebits == 11 && sbits == 53:
+			return typeFor(ops.IsFloat, 64)
+		}
+	case z3.KindSeq:
+		return typeFor(ops.IsString, 0)
+	}
+	// Some other sort. It has no Go type, but values of the
+	// sort can still be passed around.
+	return ops.Type{StName: s.String(), ConType: s.String()}
+}
+
+func isBV(t ops.Type) bool {
+	return t.Flags&ops.IsInteger != 0
+}
+
+// expr prints ast.
+func (p *printer) expr(ast z3.AST) expr {
+	id := ast.ID()
+	if e, ok := p.temps[id]; ok {
+		return e
+	}
+	e := p.app(ast)
+	if p.refs[id] > 1 && !p.cfg.NoTemps && len(ast.Args()) > 0 {
+		// Bind the shared subterm to a temporary.
+		name := p.tempName()
+		p.stmts = append(p.stmts, stmt{name, p.typed(e)})
+		e = expr{n: leaf(name), typ: e.typ}
+		p.temps[id] = e
+	}
+	return e
+}
+
+// tempName returns a fresh temporary name.
+func (p *printer) tempName() string {
+	for {
+		name := "t" + strconv.Itoa(p.ntemps)
+		p.ntemps++
+		if !p.names[name] {
+			return name
+		}
+	}
+}
+
+// typed returns the node for e, converting untyped constants to their
+// type.
+func (p *printer) typed(e expr) *node {
+	if e.lit != nil {
+		return call(e.typ.ConType, p.as(e, e.typ))
+	}
+	if e.untyped && (isBV(e.typ) || e.typ.Flags&ops.IsFloat != 0) {
+		return call(e.typ.ConType, e.n)
+	}
+	return e.n
+}
+
+// as returns the node for e used as a value of type t. e must be t or
+// a bit-vector of the same size as t.
+func (p *printer) as(e expr, t ops.Type) *node {
+	if e.lit != nil {
+		v := new(big.Int).Set(e.lit)
+		if t.Flags&ops.IsUnsigned == 0 && v.Bit(t.Bits-1) == 1 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(t.Bits)))
+		}
+		if v.Sign() < 0 {
+			return unary("-", leaf(v.Neg(v).String()))
+		}
+		return leaf(v.String())
+	}
+	if e.typ == t || !isBV(t) {
+		return e.n
+	}
+	return call(t.ConType, e.n)
+}
+
+// convert returns e converted to the bit-vector type t, extending it
+// according to e's own signedness.
+func (p *printer) convert(e expr, t ops.Type) expr {
+	if e.lit == nil && e.typ == t {
+		return e
+	}
+	if e.lit != nil && e.typ.Bits == t.Bits {
+		return expr{n: e.n, typ: t, lit: e.lit}
+	}
+	return expr{n: call(t.ConType, p.as(e, e.typ)), typ: t}
+}
+
+// common returns the type in which to combine args: the type of the
+// first argument that is not a numeral, or the default signed type.
+func common(args []expr) ops.Type {
+	for _, arg := range args {
+		if arg.lit == nil && !arg.untyped {
+			return arg.typ
+		}
+	}
+	return args[0].typ
+}
+
+// binary combines args with operator tok in type t. The result is a
+// bool for comparisons and otherwise has type res, or t if res is the
+// zero Type.
+func (p *printer) binary(tok token.Token, t, res ops.Type, args ...expr) expr {
+	untyped := true
+	for _, arg := range args {
+		if arg.lit == nil && !arg.untyped {
+			untyped = false
+		}
+	}
+	nodes := make([]*node, len(args))
+	for i, arg := range args {
+		nodes[i] = p.as(arg, t)
+	}
+	if untyped && (isBV(t) || t.Flags&ops.IsFloat != 0) {
+		// Give the expression a type.
+		nodes[0] = call(t.ConType, nodes[0])
+		untyped = false
+	}
+	assoc := t.Flags&ops.IsFloat == 0
+	switch {
+	case tok.Precedence() == token.EQL.Precedence():
+		res = boolType
+	case res == (ops.Type{}):
+		res = t
+	}
+	return expr{n: binaryNode(tok, assoc, nodes), typ: res, untyped: untyped && res != boolType}
+}
+
+// generic prints an application of decl with no Go equivalent as a
+// call to a function named after decl.
+func (p *printer) generic(decl z3.FuncDecl, sort z3.Sort, args []expr) expr {
+	nodes := make([]*node, len(args))
+	for i, arg := range args {
+		nodes[i] = p.typed(arg)
+	}
+	name := genericName(decl.Name())
+	if params := decl.IntParams(); len(params) > 0 {
+		for _, param := range params {
+			name += "_" + strconv.Itoa(param)
+		}
+	}
+	return expr{n: call(name, nodes...), typ: typeOf(sort, false)}
+}
+
+// genericName converts the name of a Z3 operation to a Go
+// identifier. Dotted names become camel case and symbolic names are
+// spelled out, so "fp.isNaN" becomes fpIsNaN and "^" becomes pow.
+func genericName(name string) string {
+	if n, ok := symbolNames[name]; ok {
+		return n
+	}
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if n, ok := symbolNames[part]; ok {
+			part = n
+		}
+		part = identifier(part)
+		if i > 0 && part != "" {
+			part = strings.ToUpper(part[:1]) + part[1:]
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, "")
+}
+
+// symbolNames gives Go names for the symbolic names of Z3 operations
+// and parts of operation names.
+var symbolNames = map[string]string{
+	"=":    "eq",
+	"=>":   "implies",
+	"+":    "add",
+	"-":    "sub",
+	"*":    "mul",
+	"/":    "div",
+	"^":    "pow",
+	"<":    "lt",
+	"<=":   "le",
+	">":    "gt",
+	">=":   "ge",
+	"++":   "concat",
+	"re.*": "reStar",
+	"re.+": "rePlus",
+}
+
+// identifier converts a Z3 symbol to a Go identifier.
+func identifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// tokFor returns the Go operator for the ops method name.
+func tokFor(method string) token.Token {
+	for _, op := range ops.BinOps {
+		if op.Method == method {
+			return op.Tok
+		}
+	}
+	panic("unknown method " + method)
+}
+
+var (
+	bvBinOps = map[z3.DeclKind]string{
+		z3.DeclKindBAdd: "Add",
+		z3.DeclKindBSub: "Sub",
+		z3.DeclKindBMul: "Mul",
+		z3.DeclKindBAnd: "And",
+		z3.DeclKindBOr:  "Or",
+		z3.DeclKindBXor: "Xor",
+	}
+	bvNotOps = map[z3.DeclKind]string{
+		z3.DeclKindBNand: "And",
+		z3.DeclKindBNor:  "Or",
+		z3.DeclKindBXnor: "Xor",
+	}
+	bvSignedOps = map[z3.DeclKind]struct {
+		method   string
+		unsigned bool
+	}{
+		z3.DeclKindBSDiv:  {"Quo", false},
+		z3.DeclKindBSDivI: {"Quo", false},
+		z3.DeclKindBUDiv:  {"Quo", true},
+		z3.DeclKindBUDivI: {"Quo", true},
+		z3.DeclKindBSRem:  {"Rem", false},
+		z3.DeclKindBSRemI: {"Rem", false},
+		z3.DeclKindBURem:  {"Rem", true},
+		z3.DeclKindBURemI: {"Rem", true},
+		z3.DeclKindSLEQ:   {"LE", false},
+		z3.DeclKindULEQ:   {"LE", true},
+		z3.DeclKindSGEQ:   {"GE", false},
+		z3.DeclKindUGEQ:   {"GE", true},
+		z3.DeclKindSLT:    {"LT", false},
+		z3.DeclKindULT:    {"LT", true},
+		z3.DeclKindSGT:    {"GT", false},
+		z3.DeclKindUGT:    {"GT", true},
+	}
+	arithOps = map[z3.DeclKind]string{
+		z3.DeclKindAdd: "Add",
+		z3.DeclKindSub: "Sub",
+		z3.DeclKindMul: "Mul",
+		z3.DeclKindDiv: "Quo",
+		z3.DeclKindLE:  "LE",
+		z3.DeclKindGE:  "GE",
+		z3.DeclKindLT:  "LT",
+		z3.DeclKindGT:  "GT",
+	}
+	floatOps = map[z3.DeclKind]string{
+		z3.DeclKindFPAAdd: "Add",
+		z3.DeclKindFPASub: "Sub",
+		z3.DeclKindFPAMul: "Mul",
+		z3.DeclKindFPADiv: "Quo",
+	}
+	floatCompareOps = map[z3.DeclKind]string{
+		z3.DeclKindFPAEq: "Eq",
+		z3.DeclKindFPALE: "LE",
+		z3.DeclKindFPAGE: "GE",
+		z3.DeclKindFPALT: "LT",
+		z3.DeclKindFPAGT: "GT",
+	}
+)
+
+// app prints the application or numeral ast.
+func (p *printer) app(ast z3.AST) expr {
+	decl := ast.Decl()
+	kind := decl.Kind()
+	switch kind {
+	case z3.DeclKindFPARoundNearestTiesToEven, z3.DeclKindFPARoundNearestTiesToAway,
+		z3.DeclKindFPARoundTowardPositive, z3.DeclKindFPARoundTowardNegative,
+		z3.DeclKindFPARoundTowardZero:
+		// Rounding modes are not Values. They only appear as
+		// arguments of operations with no Go equivalent.
+		return expr{n: leaf(decl.Name())}
+	}
+	sort := ast.AsValue().Sort()
+	asts := ast.Args()
+
+	switch kind {
+	case z3.DeclKindBNum:
+		v, _ := ast.AsValue().(z3.BV).AsBigUnsigned()
+		return expr{n: leaf(v.String()), typ: bvType(sort.BVSize(), false), lit: v}
+	case z3.DeclKindANum:
+		return p.numeral(ast.AsValue())
+	case z3.DeclKindFPANum, z3.DeclKindFPAPlusZero, z3.DeclKindFPAMinusZero,
+		z3.DeclKindFPAPlusInf, z3.DeclKindFPAMinusInf, z3.DeclKindFPANaN:
+		return p.float(ast.AsValue().(z3.Float))
+	case z3.DeclKindTrue:
+		return expr{n: leaf("true"), typ: boolType}
+	case z3.DeclKindFalse:
+		return expr{n: leaf("false"), typ: boolType}
+	case z3.DeclKindUninterpreted:
+		return p.uninterpreted(decl, sort, asts)
+	}
+
+	args := make([]expr, len(asts))
+	for i, arg := range asts {
+		args[i] = p.expr(arg)
+	}
+	if method, ok := bvBinOps[kind]; ok {
+		return p.binary(tokFor(method), common(args), ops.Type{}, args...)
+	}
+	if method, ok := bvNotOps[kind]; ok {
+		t := common(args)
+		x := p.binary(tokFor(method), t, ops.Type{}, args...)
+		return expr{n: unary("^", x.n), typ: t}
+	}
+	if op, ok := bvSignedOps[kind]; ok {
+		t := bvType(args[0].typ.Bits, op.unsigned)
+		return p.binary(tokFor(op.method), t, ops.Type{}, args...)
+	}
+	if method, ok := arithOps[kind]; ok {
+		return p.binary(tokFor(method), common(args), ops.Type{}, args...)
+	}
+	if method, ok := floatCompareOps[kind]; ok {
+		return p.binary(tokFor(method), common(args), ops.Type{}, args...)
+	}
+	if method, ok := floatOps[kind]; ok && p.isRNE(asts[0]) {
+		return p.binary(tokFor(method), common(args[1:]), ops.Type{}, args[1:]...)
+	}
+
+	switch kind {
+	case z3.DeclKindEq, z3.DeclKindIff:
+		if args[0].typ.Flags&ops.IsFloat != 0 {
+			// Z3 equality on floats is not IEEE equality.
+			break
+		}
+		return p.binary(token.EQL, common(args), ops.Type{}, args...)
+	case z3.DeclKindXor:
+		return p.binary(token.NEQ, boolType, ops.Type{}, args...)
+	case z3.DeclKindDistinct:
+		if args[0].typ.Flags&ops.IsFloat != 0 {
+			break
+		}
+		t := common(args)
+		var ne []expr
+		for i := range args {
+			for j := i + 1; j < len(args); j++ {
+				ne = append(ne, p.binary(token.NEQ, t, ops.Type{}, args[i], args[j]))
+			}
+		}
+		if len(ne) == 1 {
+			return ne[0]
+		}
+		return p.binary(token.LAND, boolType, ops.Type{}, ne...)
+	case z3.DeclKindAnd:
+		return p.binary(token.LAND, boolType, ops.Type{}, args...)
+	case z3.DeclKindOr:
+		return p.binary(token.LOR, boolType, ops.Type{}, args...)
+	case z3.DeclKindNot:
+		return expr{n: unary("!", args[0].n), typ: boolType}
+	case z3.DeclKindImplies:
+		not := expr{n: unary("!", args[0].n), typ: boolType}
+		return p.binary(token.LOR, boolType, ops.Type{}, not, args[1])
+	case z3.DeclKindITE:
+		t := common(args[1:])
+		return expr{n: call("Ite", args[0].n, p.as(args[1], t), p.as(args[2], t)), typ: t}
+
+	case z3.DeclKindUMinus, z3.DeclKindFPANeg:
+		return expr{n: unary("-", args[0].n), typ: args[0].typ, untyped: args[0].untyped}
+	case z3.DeclKindIDiv, z3.DeclKindMod:
+		// Z3 integer division and modulus are Euclidean, like
+		// big.Int's Div and Mod.
+		name := "Div"
+		if kind == z3.DeclKindMod {
+			name = "Mod"
+		}
+		return expr{n: method(args[0].n, name, args[1].n), typ: args[0].typ}
+
+	case z3.DeclKindBNeg:
+		return expr{n: unary("-", p.as(args[0], args[0].typ)), typ: args[0].typ, untyped: args[0].lit != nil}
+	case z3.DeclKindBNot:
+		return expr{n: unary("^", p.as(args[0], args[0].typ)), typ: args[0].typ, untyped: args[0].lit != nil}
+	case z3.DeclKindBShl, z3.DeclKindBLShr, z3.DeclKindBAShr:
+		t := args[0].typ
+		switch kind {
+		case z3.DeclKindBLShr:
+			t = bvType(t.Bits, true)
+		case z3.DeclKindBAShr:
+			t = bvType(t.Bits, false)
+		}
+		tok := token.SHL
+		if kind != z3.DeclKindBShl {
+			tok = token.SHR
+		}
+		x := p.typed(p.convert(args[0], t))
+		count := p.as(args[1], bvType(t.Bits, true))
+		return expr{n: binaryNode(tok, false, []*node{x, count}), typ: t}
+	case z3.DeclKindSignExt, z3.DeclKindZeroExt:
+		unsigned := kind == z3.DeclKindZeroExt
+		x := p.convert(args[0], bvType(args[0].typ.Bits, unsigned))
+		return p.convert(x, bvType(sort.BVSize(), unsigned))
+	case z3.DeclKindExtract:
+		params := decl.IntParams()
+		hi, lo := params[0], params[1]
+		x := args[0]
+		if lo != 0 {
+			x = p.convert(x, bvType(x.typ.Bits, true))
+			shift := binaryNode(token.SHR, false, []*node{p.typed(x), leaf(strconv.Itoa(lo))})
+			x = expr{n: shift, typ: x.typ}
+		}
+		return p.convert(x, bvType(hi-lo+1, x.typ.Flags&ops.IsUnsigned != 0))
+	case z3.DeclKindConcat:
+		t := bvType(sort.BVSize(), true)
+		parts := make([]expr, len(args))
+		shift := t.Bits
+		for i, arg := range args {
+			shift -= arg.typ.Bits
+			x := p.convert(p.convert(arg, bvType(arg.typ.Bits, true)), t)
+			if shift > 0 {
+				n := binaryNode(token.SHL, false, []*node{p.typed(x), leaf(strconv.Itoa(shift))})
+				x = expr{n: n, typ: t}
+			}
+			parts[i] = x
+		}
+		return p.binary(token.OR, t, ops.Type{}, parts...)
+
+	case z3.DeclKindSeqConcat:
+		return p.binary(token.ADD, args[0].typ, ops.Type{}, args...)
+
+	case z3.DeclKindFPAToFP:
+		if len(args) != 2 || !p.isRNE(asts[0]) {
+			break
+		}
+		t := typeOf(sort, false)
+		switch {
+		case isBV(args[1].typ):
+			return expr{n: call(t.ConType, p.as(args[1], bvType(args[1].typ.Bits, false))), typ: t}
+		case args[1].typ.Flags&ops.IsFloat != 0:
+			return expr{n: call(t.ConType, args[1].n), typ: t}
+		}
+	case z3.DeclKindFPAToFPUnsigned:
+		if !p.isRNE(asts[0]) {
+			break
+		}
+		t := typeOf(sort, false)
+		return expr{n: call(t.ConType, p.as(args[1], bvType(args[1].typ.Bits, true))), typ: t}
+	case z3.DeclKindFPAToSBV, z3.DeclKindFPAToUBV:
+		// Go float-to-integer conversions round toward zero.
+		if asts[0].Decl().Kind() != z3.DeclKindFPARoundTowardZero {
+			break
+		}
+		t := bvType(sort.BVSize(), kind == z3.DeclKindFPAToUBV)
+		return expr{n: call(t.ConType, args[1].n), typ: t}
+	}
+	return p.generic(decl, sort, args)
+}
+
+// isRNE reports whether ast is the round-to-nearest-even rounding
+// mode, which Go uses for floating-point arithmetic.
+func (p *printer) isRNE(ast z3.AST) bool {
+	return ast.Decl().Kind() == z3.DeclKindFPARoundNearestTiesToEven
+}
+
+// uninterpreted prints a constant or an application of an
+// uninterpreted function.
+func (p *printer) uninterpreted(decl z3.FuncDecl, sort z3.Sort, asts []z3.AST) expr {
+	name := decl.Name()
+	unsigned := p.cfg.Unsigned[name]
+	typ := typeOf(sort, unsigned)
+	if len(asts) == 0 {
+		return expr{n: leaf(identifier(name)), typ: typ}
+	}
+	domain := decl.Domain()
+	nodes := make([]*node, len(asts))
+	for i, arg := range asts {
+		e := p.expr(arg)
+		nodes[i] = p.as(e, typeOf(domain[i], unsigned))
+	}
+	return expr{n: call(identifier(name), nodes...), typ: typ}
+}
+
+// numeral prints an Int or Real numeral.
+func (p *printer) numeral(v z3.Value) expr {
+	switch v := v.(type) {
+	case z3.Int:
+		x, _ := v.AsBigInt()
+		n := signed(x.Sign() < 0, new(big.Int).Abs(x).String())
+		return expr{n: n, typ: typeOf(v.Sort(), false), untyped: true}
+	case z3.Real:
+		x, _ := v.AsBigRat()
+		return expr{n: p.rat(x), typ: typeOf(v.Sort(), false), untyped: true}
+	}
+	p.errorf("cannot print numeral %s", v)
+	panic("unreachable")
+}
+
+// rat prints x as an untyped floating-point constant.
+func (p *printer) rat(x *big.Rat) *node {
+	neg := x.Sign() < 0
+	abs := new(big.Rat).Abs(x)
+	if abs.IsInt() {
+		return signed(neg, abs.Num().String()+".0")
+	}
+	// Use a decimal if x has a short exact one.
+	for prec := 1; prec <= 20; prec++ {
+		s := abs.FloatString(prec)
+		if r, ok := new(big.Rat).SetString(s); ok && r.Cmp(abs) == 0 {
+			return signed(neg, s)
+		}
+	}
+	quo := binaryNode(token.QUO, false, []*node{leaf(abs.Num().String() + ".0"), leaf(abs.Denom().String())})
+	if neg {
+		return unary("-", paren(quo))
+	}
+	return paren(quo)
+}
+
+// float prints a floating-point numeral.
+func (p *printer) float(v z3.Float) expr {
+	t := typeOf(v.Sort(), false)
+	x, _ := v.AsBigFloat()
+	var n *node
+	switch {
+	case x == nil:
+		n = call("math.NaN")
+	case x.IsInf():
+		n = call("math.Inf", signed(x.Signbit(), "1"))
+	case x.Sign() == 0 && x.Signbit():
+		n = call("math.Copysign", leaf("0"), signed(true, "1"))
+	case t.Bits == 32 || t.Bits == 64:
+		// Print the shortest decimal that rounds to x.
+		f, _ := x.Float64()
+		s := strconv.FormatFloat(math.Abs(f), 'g', -1, t.Bits)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return expr{n: signed(f < 0, s), typ: t, untyped: true}
+	default:
+		r, _ := x.Rat(nil)
+		return expr{n: p.rat(r), typ: t, untyped: true}
+	}
+	if t.Bits == 32 {
+		n = call(t.ConType, n)
+	}
+	return expr{n: n, typ: t}
+}
+
+// signed returns a leaf for text, negated if neg.
+func signed(neg bool, text string) *node {
+	if neg {
+		return unary("-", leaf(text))
+	}
+	return leaf(text)
+}
@@ -0,0 +1,174 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package printer
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/aclements/go-z3/z3"
+	"github.com/aclements/go-z3/z3/parse"
+)
+
+func TestSprint(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x, y := ctx.BVConst("x", 8), ctx.BVConst("y", 8)
+	u := ctx.BVConst("u", 8)
+	i, j := ctx.IntConst("i"), ctx.IntConst("j")
+	r := ctx.RealConst("r")
+	p, q := ctx.BoolConst("p"), ctx.BoolConst("q")
+	f := ctx.Const("f", ctx.Float64Sort()).(z3.Float)
+	h := ctx.Const("h", ctx.Float32Sort()).(z3.Float)
+	float := func(v float64, sort z3.Sort) z3.Float {
+		// Simplify folds the float's bits into a numeral.
+		return ctx.Simplify(ctx.FromBigFloat(big.NewFloat(v), sort), nil).(z3.Float)
+	}
+	one := ctx.FromInt(1, ctx.BVSort(8)).(z3.BV)
+	fn := ctx.FuncDecl("fn", []z3.Sort{ctx.BVSort(8)}, ctx.IntSort())
+	cfg := &Config{Unsigned: map[string]bool{"u": true}}
+
+	for _, test := range []struct {
+		val  z3.Value
+		want string
+	}{
+		{x.Add(one).ULE(y), "uint8(x + 1) <= uint8(y)"},
+		{u.Add(one).ULE(y), "u + 1 <= uint8(y)"},
+		{x.Add(y).Mul(x), "(x + y) * x"},
+		{x.Sub(y.Sub(one)), "x - (y - 1)"},
+		{x.Sub(y).Sub(one), "x - y - 1"},
+		{x.Add(ctx.FromInt(-1, ctx.BVSort(8)).(z3.BV)), "x + -1"},
+		{u.Add(ctx.FromInt(-1, ctx.BVSort(8)).(z3.BV)), "u + 255"},
+		{one.Add(one), "int8(1) + 1"},
+		{x.Neg().Neg(), "-(-x)"},
+		{x.SDiv(y), "x / y"},
+		{x.UDiv(y), "uint8(x) / uint8(y)"},
+		{u.SRem(y), "int8(u) % y"},
+		{x.URsh(y), "uint8(x) >> uint8(y)"},
+		{x.SRsh(one), "x >> 1"},
+		{x.Lsh(y), "x << uint8(y)"},
+		{x.Extract(5, 2), "uint4(uint8(x) >> 2)"},
+		{x.Extract(3, 0), "int4(x)"},
+		{x.SignExtend(8), "int16(x)"},
+		{x.ZeroExtend(8), "uint16(uint8(x))"},
+		{x.Concat(y), "uint16(uint8(x)) << 8 | uint16(uint8(y))"},
+		{x.SMod(y), "bvsmod(x, y)"},
+		{ctx.Distinct(x, y, one), "x != y && x != 1 && y != 1"},
+		{p.Implies(q).IfThenElse(x, y), "Ite(!p || q, x, y)"},
+		{p.Xor(q).Not(), "!(p != q)"},
+		{fn.Apply(u), "fn(int8(u))"},
+		{i.Add(j.Mul(ctx.FromInt(-3, ctx.IntSort()).(z3.Int))).LT(i.Div(j)), "i + j * -3 < i.Div(j)"},
+		{i.Mod(ctx.FromInt(2, ctx.IntSort()).(z3.Int)), "i.Mod(2)"},
+		{r.Mul(ctx.FromBigRat(big.NewRat(1, 3))).GE(ctx.FromBigRat(big.NewRat(-5, 4))), "r * (1.0 / 3) >= -1.25"},
+		{ctx.FromBigRat(big.NewRat(2, 1)), "2.0"},
+		{f.Add(ctx.FromInt(1, f.Sort()).(z3.Float)).LT(f), "f + 1.0 < f"},
+		{f.IEEEEq(ctx.FloatNaN(f.Sort())), "f == math.NaN()"},
+		{x.SToFloat(ctx.Float32Sort()), "float32(x)"},
+		{u.UToFloat(ctx.Float32Sort()), "float32(u)"},
+		{f.ToFloat(ctx.Float32Sort()), "float32(f)"},
+		{ctx.IntConst("weird name"), "weird_name"},
+		{f.Eq(ctx.Const("g", f.Sort()).(z3.Float)), "eq(f, g)"},
+		{i.Exp(j), "pow(i, j)"},
+		{f.IsNaN(), "fpIsNaN(f)"},
+		{f.IEEEEq(float(0.1, f.Sort())), "f == 0.1"},
+		{f.IEEEEq(float(-1e300, f.Sort())), "f == -1e+300"},
+		{h.IEEEEq(float(float64(float32(0.1)), h.Sort())), "h == 0.1"},
+	} {
+		var buf bytes.Buffer
+		if err := cfg.Fprint(&buf, test.val); err != nil {
+			t.Errorf("%s: %s", test.val, err)
+		} else if got := buf.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.val, got, test.want)
+		}
+	}
+}
+
+func TestSprintTemps(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x, y := ctx.BVConst("x", 8), ctx.BVConst("t0", 8)
+	s := x.Add(y)
+	v := s.Mul(s).SLT(s.Sub(x))
+	want := "t1 := x + t0\nt1 * t1 < t1 - x"
+	if got := Sprint(v); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var buf bytes.Buffer
+	cfg := &Config{NoTemps: true}
+	if err := cfg.Fprint(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	want = "(x + t0) * (x + t0) < x + t0 - x"
+	if got := buf.String(); got != want {
+		t.Errorf("NoTemps: got %q, want %q", got, want)
+	}
+}
+
+func TestSprintWidth(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	var conds []z3.Bool
+	for _, name := range []string{"alpha", "beta", "gamma", "delta"} {
+		x := ctx.IntConst(name)
+		conds = append(conds, x.GT(ctx.FromInt(0, ctx.IntSort()).(z3.Int)))
+	}
+	v := conds[0].And(conds[1]).And(conds[2].Or(conds[3]))
+	cfg := &Config{Width: 32}
+	var buf bytes.Buffer
+	if err := cfg.Fprint(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	want := "alpha > 0 &&\n\tbeta > 0 &&\n\t(gamma > 0 || delta > 0)"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if width := len(strings.Replace(line, "\t", "        ", -1)); width > cfg.Width {
+			t.Errorf("line %q is %d columns wide", line, width)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// Printing and parsing must produce an equivalent value.
+	ctx := z3.NewContext(nil)
+	x, y := ctx.BVConst("x", 16), ctx.BVConst("y", 16)
+	u := ctx.BVConst("u", 16)
+	i := ctx.IntConst("i")
+	p := ctx.BoolConst("p")
+	c := func(v int64) z3.BV { return ctx.FromInt(v, ctx.BVSort(16)).(z3.BV) }
+	unsigned := map[string]bool{"u": true}
+	env := &parse.Env{
+		Vars:     map[string]z3.Value{"x": x, "y": y, "u": u, "i": i, "p": p},
+		Unsigned: unsigned,
+	}
+	cfg := &Config{Unsigned: unsigned, NoTemps: true}
+
+	for _, v := range []z3.Value{
+		x.Add(c(1)).ULE(y),
+		u.Mul(c(-2)).SLT(x.URsh(u)),
+		x.SRem(y).Eq(u.UDiv(c(3))),
+		x.Lsh(y).And(u.Not()).NE(c(0x7f)),
+		x.SignExtend(16).Extract(23, 8).Eq(y.ZeroExtend(16).Extract(15, 0)),
+		p.Implies(i.Mul(i).GT(i.Sub(ctx.FromInt(7, ctx.IntSort()).(z3.Int)))),
+		ctx.Distinct(x, y, u),
+	} {
+		var buf bytes.Buffer
+		if err := cfg.Fprint(&buf, v); err != nil {
+			t.Errorf("%s: %s", v, err)
+			continue
+		}
+		got, err := parse.Expr(ctx, buf.String(), env)
+		if err != nil {
+			t.Errorf("parsing %q: %s", buf.String(), err)
+			continue
+		}
+		s := z3.NewSolver(ctx)
+		s.Assert(ctx.Distinct(v, got))
+		if sat, err := s.Check(); sat || err != nil {
+			t.Errorf("%s printed as %q, which is not equivalent", v, buf.String())
+		}
+	}
+}
@@ -0,0 +1,349 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// Proof is a step in a proof produced by Solver.Proof. Each step
+// applies a ProofRule to the proofs of its premises to conclude a
+// new fact. The steps of a proof form a directed acyclic graph whose
+// leaves are steps with no premises, such as asserted facts.
+//
+// A Proof is a Value, but it has no Sort kind and cannot be used in
+// other expressions.
+type Proof value
+
+// ProofRule is the inference rule used in a proof step. The rules are
+// documented with the Z3_OP_PR_* constants in the Z3 API.
+type ProofRule DeclKind
+
+const (
+	ProofRuleUndef            = ProofRule(C.Z3_OP_PR_UNDEF)
+	ProofRuleTrue             = ProofRule(C.Z3_OP_PR_TRUE)
+	ProofRuleAsserted         = ProofRule(C.Z3_OP_PR_ASSERTED)
+	ProofRuleGoal             = ProofRule(C.Z3_OP_PR_GOAL)
+	ProofRuleModusPonens      = ProofRule(C.Z3_OP_PR_MODUS_PONENS)
+	ProofRuleReflexivity      = ProofRule(C.Z3_OP_PR_REFLEXIVITY)
+	ProofRuleSymmetry         = ProofRule(C.Z3_OP_PR_SYMMETRY)
+	ProofRuleTransitivity     = ProofRule(C.Z3_OP_PR_TRANSITIVITY)
+	ProofRuleTransitivityStar = ProofRule(C.Z3_OP_PR_TRANSITIVITY_STAR)
+	ProofRuleMonotonicity     = ProofRule(C.Z3_OP_PR_MONOTONICITY)
+	ProofRuleQuantIntro       = ProofRule(C.Z3_OP_PR_QUANT_INTRO)
+	ProofRuleBind             = ProofRule(C.Z3_OP_PR_BIND)
+	ProofRuleDistributivity   = ProofRule(C.Z3_OP_PR_DISTRIBUTIVITY)
+	ProofRuleAndElim          = ProofRule(C.Z3_OP_PR_AND_ELIM)
+	ProofRuleNotOrElim        = ProofRule(C.Z3_OP_PR_NOT_OR_ELIM)
+	ProofRuleRewrite          = ProofRule(C.Z3_OP_PR_REWRITE)
+	ProofRuleRewriteStar      = ProofRule(C.Z3_OP_PR_REWRITE_STAR)
+	ProofRulePullQuant        = ProofRule(C.Z3_OP_PR_PULL_QUANT)
+	ProofRulePushQuant        = ProofRule(C.Z3_OP_PR_PUSH_QUANT)
+	ProofRuleElimUnusedVars   = ProofRule(C.Z3_OP_PR_ELIM_UNUSED_VARS)
+	ProofRuleDer              = ProofRule(C.Z3_OP_PR_DER)
+	ProofRuleQuantInst        = ProofRule(C.Z3_OP_PR_QUANT_INST)
+	ProofRuleHypothesis       = ProofRule(C.Z3_OP_PR_HYPOTHESIS)
+	ProofRuleLemma            = ProofRule(C.Z3_OP_PR_LEMMA)
+	ProofRuleUnitResolution   = ProofRule(C.Z3_OP_PR_UNIT_RESOLUTION)
+	ProofRuleIffTrue          = ProofRule(C.Z3_OP_PR_IFF_TRUE)
+	ProofRuleIffFalse         = ProofRule(C.Z3_OP_PR_IFF_FALSE)
+	ProofRuleCommutativity    = ProofRule(C.Z3_OP_PR_COMMUTATIVITY)
+	ProofRuleDefAxiom         = ProofRule(C.Z3_OP_PR_DEF_AXIOM)
+	ProofRuleAssumptionAdd    = ProofRule(C.Z3_OP_PR_ASSUMPTION_ADD)
+	ProofRuleLemmaAdd         = ProofRule(C.Z3_OP_PR_LEMMA_ADD)
+	ProofRuleRedundantDel     = ProofRule(C.Z3_OP_PR_REDUNDANT_DEL)
+	ProofRuleClauseTrail      = ProofRule(C.Z3_OP_PR_CLAUSE_TRAIL)
+	ProofRuleDefIntro         = ProofRule(C.Z3_OP_PR_DEF_INTRO)
+	ProofRuleApplyDef         = ProofRule(C.Z3_OP_PR_APPLY_DEF)
+	ProofRuleIffOEQ           = ProofRule(C.Z3_OP_PR_IFF_OEQ)
+	ProofRuleNNFPos           = ProofRule(C.Z3_OP_PR_NNF_POS)
+	ProofRuleNNFNeg           = ProofRule(C.Z3_OP_PR_NNF_NEG)
+	ProofRuleSkolemize        = ProofRule(C.Z3_OP_PR_SKOLEMIZE)
+	ProofRuleModusPonensOEQ   = ProofRule(C.Z3_OP_PR_MODUS_PONENS_OEQ)
+	ProofRuleThLemma          = ProofRule(C.Z3_OP_PR_TH_LEMMA)
+	ProofRuleHyperResolve     = ProofRule(C.Z3_OP_PR_HYPER_RESOLVE)
+)
+
+// String returns r as a string like "ProofRuleModusPonens".
+func (r ProofRule) String() string {
+	switch r {
+	case ProofRuleUndef:
+		return "ProofRuleUndef"
+	case ProofRuleTrue:
+		return "ProofRuleTrue"
+	case ProofRuleAsserted:
+		return "ProofRuleAsserted"
+	case ProofRuleGoal:
+		return "ProofRuleGoal"
+	case ProofRuleModusPonens:
+		return "ProofRuleModusPonens"
+	case ProofRuleReflexivity:
+		return "ProofRuleReflexivity"
+	case ProofRuleSymmetry:
+		return "ProofRuleSymmetry"
+	case ProofRuleTransitivity:
+		return "ProofRuleTransitivity"
+	case ProofRuleTransitivityStar:
+		return "ProofRuleTransitivityStar"
+	case ProofRuleMonotonicity:
+		return "ProofRuleMonotonicity"
+	case ProofRuleQuantIntro:
+		return "ProofRuleQuantIntro"
+	case ProofRuleBind:
+		return "ProofRuleBind"
+	case ProofRuleDistributivity:
+		return "ProofRuleDistributivity"
+	case ProofRuleAndElim:
+		return "ProofRuleAndElim"
+	case ProofRuleNotOrElim:
+		return "ProofRuleNotOrElim"
+	case ProofRuleRewrite:
+		return "ProofRuleRewrite"
+	case ProofRuleRewriteStar:
+		return "ProofRuleRewriteStar"
+	case ProofRulePullQuant:
+		return "ProofRulePullQuant"
+	case ProofRulePushQuant:
+		return "ProofRulePushQuant"
+	case ProofRuleElimUnusedVars:
+		return "ProofRuleElimUnusedVars"
+	case ProofRuleDer:
+		return "ProofRuleDer"
+	case ProofRuleQuantInst:
+		return "ProofRuleQuantInst"
+	case ProofRuleHypothesis:
+		return "ProofRuleHypothesis"
+	case ProofRuleLemma:
+		return "ProofRuleLemma"
+	case ProofRuleUnitResolution:
+		return "ProofRuleUnitResolution"
+	case ProofRuleIffTrue:
+		return "ProofRuleIffTrue"
+	case ProofRuleIffFalse:
+		return "ProofRuleIffFalse"
+	case ProofRuleCommutativity:
+		return "ProofRuleCommutativity"
+	case ProofRuleDefAxiom:
+		return "ProofRuleDefAxiom"
+	case ProofRuleAssumptionAdd:
+		return "ProofRuleAssumptionAdd"
+	case ProofRuleLemmaAdd:
+		return "ProofRuleLemmaAdd"
+	case ProofRuleRedundantDel:
+		return "ProofRuleRedundantDel"
+	case ProofRuleClauseTrail:
+		return "ProofRuleClauseTrail"
+	case ProofRuleDefIntro:
+		return "ProofRuleDefIntro"
+	case ProofRuleApplyDef:
+		return "ProofRuleApplyDef"
+	case ProofRuleIffOEQ:
+		return "ProofRuleIffOEQ"
+	case ProofRuleNNFPos:
+		return "ProofRuleNNFPos"
+	case ProofRuleNNFNeg:
+		return "ProofRuleNNFNeg"
+	case ProofRuleSkolemize:
+		return "ProofRuleSkolemize"
+	case ProofRuleModusPonensOEQ:
+		return "ProofRuleModusPonensOEQ"
+	case ProofRuleThLemma:
+		return "ProofRuleThLemma"
+	case ProofRuleHyperResolve:
+		return "ProofRuleHyperResolve"
+	}
+	return "ProofRule(" + strconv.Itoa(int(r)) + ")"
+}
+
+// Decl returns the function declaration of p's proof rule.
+func (p Proof) Decl() FuncDecl {
+	var decl FuncDecl
+	p.ctx.do(func() {
+		app := C.Z3_to_app(p.ctx.c, p.c)
+		decl = wrapFuncDecl(p.ctx, C.Z3_get_app_decl(p.ctx.c, app))
+	})
+	runtime.KeepAlive(p)
+	return decl
+}
+
+// Rule returns the inference rule of proof step p.
+func (p Proof) Rule() ProofRule {
+	var kind C.Z3_decl_kind
+	p.ctx.do(func() {
+		if z3ToBool(C.Z3_is_app(p.ctx.c, p.c)) {
+			app := C.Z3_to_app(p.ctx.c, p.c)
+			kind = C.Z3_get_decl_kind(p.ctx.c, C.Z3_get_app_decl(p.ctx.c, app))
+		} else {
+			kind = C.Z3_OP_PR_UNDEF
+		}
+	})
+	runtime.KeepAlive(p)
+	return ProofRule(kind)
+}
+
+// args returns the arguments of p's application.
+func (p Proof) args() []value {
+	var n C.uint
+	p.ctx.do(func() {
+		if z3ToBool(C.Z3_is_app(p.ctx.c, p.c)) {
+			n = C.Z3_get_app_num_args(p.ctx.c, C.Z3_to_app(p.ctx.c, p.c))
+		}
+	})
+	args := make([]value, n)
+	for i := C.uint(0); i < n; i++ {
+		args[i] = wrapValue(p.ctx, func() C.Z3_ast {
+			return C.Z3_get_app_arg(p.ctx.c, C.Z3_to_app(p.ctx.c, p.c), i)
+		})
+	}
+	runtime.KeepAlive(p)
+	return args
+}
+
+// Premises returns the proofs of the premises of step p.
+func (p Proof) Premises() []Proof {
+	args := p.args()
+	if len(args) == 0 {
+		return nil
+	}
+	premises := make([]Proof, len(args)-1)
+	for i, arg := range args[:len(args)-1] {
+		premises[i] = Proof(arg)
+	}
+	return premises
+}
+
+// Conclusion returns the fact proved by step p. For a proof of
+// unsatisfiability produced by Solver.Proof, the conclusion of the
+// final step is false.
+func (p Proof) Conclusion() Bool {
+	args := p.args()
+	if len(args) == 0 {
+		panic("proof step has no conclusion")
+	}
+	return Bool(args[len(args)-1])
+}
+
+// Walk calls f on each step of proof p, including p itself. Each
+// distinct step is visited once, after all of its premises. If f
+// returns false, Walk stops.
+func (p Proof) Walk(f func(step Proof) bool) {
+	seen := make(map[uint64]bool)
+	var walk func(p Proof) bool
+	walk = func(p Proof) bool {
+		id := p.AsAST().ID()
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+		for _, premise := range p.Premises() {
+			if !walk(premise) {
+				return false
+			}
+		}
+		return f(p)
+	}
+	walk(p)
+}
+
+// premiseCounts gives the number of premises of proof rules that
+// take a fixed number of premises. Rules that aren't listed take a
+// variable number.
+var premiseCounts = map[ProofRule]int{
+	ProofRuleAsserted:       0,
+	ProofRuleGoal:           0,
+	ProofRuleHypothesis:     0,
+	ProofRuleReflexivity:    0,
+	ProofRuleCommutativity:  0,
+	ProofRuleDefAxiom:       0,
+	ProofRuleSymmetry:       1,
+	ProofRuleAndElim:        1,
+	ProofRuleNotOrElim:      1,
+	ProofRuleLemma:          1,
+	ProofRuleIffTrue:        1,
+	ProofRuleIffFalse:       1,
+	ProofRuleIffOEQ:         1,
+	ProofRuleModusPonens:    2,
+	ProofRuleTransitivity:   2,
+	ProofRuleModusPonensOEQ: 2,
+}
+
+// Check checks that p is a structurally well-formed proof of
+// unsatisfiability. That is, every step must apply a known proof
+// rule to the number of premises the rule expects and conclude a
+// Bool, and the conclusion of p must be false. Check does not check
+// that each step is a valid inference.
+//
+// If p is not well-formed, Check returns an error describing the
+// first problem it finds.
+func (p Proof) Check() error {
+	var err error
+	p.Walk(func(step Proof) bool {
+		err = step.checkStep()
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	if val, ok := p.Conclusion().AsBool(); val || !ok {
+		return fmt.Errorf("proof concludes %s, not false", p.Conclusion())
+	}
+	return nil
+}
+
+// checkStep checks the structure of step p, but not its premises.
+func (p Proof) checkStep() error {
+	rule := p.Rule()
+	if rule <= ProofRuleUndef || rule > ProofRuleHyperResolve {
+		return fmt.Errorf("proof step %s is not a proof rule", p)
+	}
+	args := p.args()
+	if len(args) == 0 {
+		return fmt.Errorf("%s step has no conclusion", rule)
+	}
+	if k := args[len(args)-1].Sort().Kind(); k != KindBool {
+		return fmt.Errorf("%s step concludes a %s, not a Bool", rule, k)
+	}
+	if want, ok := premiseCounts[rule]; ok && want != len(args)-1 {
+		return fmt.Errorf("%s step has %d premises, want %d", rule, len(args)-1, want)
+	}
+	return nil
+}
+
+// WriteDOT writes the proof DAG of p to w in Graphviz DOT format.
+// Each node is labeled with its rule and conclusion, and has an edge
+// to each of its premises.
+func (p Proof) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph proof {\n")
+	fmt.Fprintf(bw, "\tnode [shape=box];\n")
+	p.Walk(func(step Proof) bool {
+		id := step.AsAST().ID()
+		label := step.ruleName()
+		if args := step.args(); len(args) > 0 {
+			label += "\n" + args[len(args)-1].String()
+		}
+		fmt.Fprintf(bw, "\tn%d [label=%s];\n", id, strconv.Quote(label))
+		for _, premise := range step.Premises() {
+			fmt.Fprintf(bw, "\tn%d -> n%d;\n", id, premise.AsAST().ID())
+		}
+		return true
+	})
+	fmt.Fprintf(bw, "}\n")
+	return bw.Flush()
+}
+
+// ruleName returns Z3's name for the rule of step p, such as "mp".
+func (p Proof) ruleName() string {
+	return p.Decl().Name()
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestProof(t *testing.T) {
+	ctx := NewContext(NewContextConfig().SetBool("proof", true))
+	x := ctx.IntConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x.GT(ctx.FromInt(2, ctx.IntSort()).(Int)))
+	s.Assert(x.LT(ctx.FromInt(1, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("Check = %v, %v; want false, nil", sat, err)
+	}
+	proof := s.Proof()
+	if err := proof.Check(); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	// Both assertions are leaves of the proof.
+	steps, asserted := 0, 0
+	proof.Walk(func(step Proof) bool {
+		steps++
+		if step.Rule() == ProofRuleAsserted {
+			asserted++
+			if n := len(step.Premises()); n != 0 {
+				t.Errorf("asserted step has %d premises", n)
+			}
+		}
+		return true
+	})
+	if asserted != 2 {
+		t.Errorf("proof has %d asserted steps, want 2", asserted)
+	}
+	n := 0
+	proof.Walk(func(step Proof) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("Walk continued after f returned false")
+	}
+
+	var buf bytes.Buffer
+	if err := proof.WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	if !strings.HasPrefix(dot, "digraph proof {") || !strings.Contains(dot, `"asserted\n`) {
+		t.Errorf("bad DOT output:\n%s", dot)
+	}
+	if got := strings.Count(dot, "[label="); got != steps {
+		t.Errorf("DOT has %d nodes, want %d", got, steps)
+	}
+
+	// A premise is not a refutation.
+	if err := proof.Premises()[0].Check(); err == nil {
+		t.Errorf("Check of premise succeeded")
+	}
+}
+
+func TestProofRuleString(t *testing.T) {
+	if got, want := ProofRuleModusPonens.String(), "ProofRuleModusPonens"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := ProofRule(1).String(), "ProofRule(1)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+)
+
+/*
+#include <z3.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+extern void goZ3PropagatePush(void *ctx);
+extern void goZ3PropagatePop(void *ctx, unsigned num_scopes);
+extern void *goZ3PropagateFresh(void *ctx, Z3_context new_context);
+extern void goZ3PropagateFixed(void *ctx, Z3_solver_callback cb, unsigned id, Z3_ast value);
+extern void goZ3PropagateEq(void *ctx, Z3_solver_callback cb, unsigned x, unsigned y);
+extern void goZ3PropagateDiseq(void *ctx, Z3_solver_callback cb, unsigned x, unsigned y);
+extern void goZ3PropagateFinal(void *ctx, Z3_solver_callback cb);
+*/
+import "C"
+
+// A Propagator implements a custom theory for a Solver in Go.
+//
+// The solver notifies the Propagator as it assigns values to the
+// terms registered with Solver.RegisterTerm, and the Propagator can
+// respond by adding consequences of those assignments or reporting
+// conflicts. This lets a theory whose constraints are too large to
+// encode up front be enforced lazily.
+//
+// Push and Pop track the solver's backtracking: when the solver pops
+// n scopes, the Propagator must forget everything it learned since
+// the matching n calls to Push. In addition, a Propagator should
+// implement one or more of FixedPropagator, EqPropagator,
+// DiseqPropagator, and FinalPropagator to receive notifications.
+//
+// Propagator methods are called during Solver.Check and related
+// operations, on the goroutine that called them. They may use the
+// Solver's Context to construct values, but must not call methods of
+// the Solver itself. To allow this, the Context is unlocked while a
+// Propagator method runs, so other goroutines must not use the
+// Context while a Solver with a Propagator is checking.
+//
+// If a Propagator method panics, the solver stops and the check
+// returns an *ErrPropagator. The Propagator is not called again until
+// then.
+type Propagator interface {
+	// Push is called when the solver creates a backtracking
+	// point.
+	Push()
+
+	// Pop is called when the solver backtracks over n
+	// backtracking points.
+	Pop(n int)
+}
+
+// A FixedPropagator is notified when a registered term is assigned a
+// value.
+type FixedPropagator interface {
+	Propagator
+
+	// Fixed is called when the solver fixes the term with ID id
+	// to val.
+	Fixed(cb *PropagateCallback, id TermID, val Value)
+}
+
+// An EqPropagator is notified when two registered terms become equal.
+type EqPropagator interface {
+	Propagator
+
+	// Eq is called when the solver determines that the terms
+	// with IDs x and y are equal.
+	Eq(cb *PropagateCallback, x, y TermID)
+}
+
+// A DiseqPropagator is notified when two registered terms become
+// distinct.
+type DiseqPropagator interface {
+	Propagator
+
+	// Diseq is called when the solver determines that the terms
+	// with IDs x and y are distinct.
+	Diseq(cb *PropagateCallback, x, y TermID)
+}
+
+// A FinalPropagator is notified when the solver has a candidate
+// model.
+type FinalPropagator interface {
+	Propagator
+
+	// Final is called when the solver has assigned all
+	// registered terms. This is the Propagator's last chance to
+	// reject the assignment with PropagateCallback.Conflict.
+	Final(cb *PropagateCallback)
+}
+
+// ErrPropagator is returned by Solver.Check and related operations
+// when the Solver's Propagator fails.
+type ErrPropagator struct {
+	// Panic is the value a Propagator method panicked with, or
+	// nil if Z3 tried to copy the Propagator.
+	Panic interface{}
+}
+
+func (e *ErrPropagator) Error() string {
+	if e.Panic == nil {
+		return "cannot copy a Solver with a Propagator"
+	}
+	return fmt.Sprintf("Propagator panicked: %v", e.Panic)
+}
+
+// A TermID identifies a term registered with Solver.RegisterTerm.
+type TermID uint
+
+// A PropagateCallback lets a Propagator add consequences to the
+// solver. It is only valid until the Propagator method it was passed
+// to returns.
+type PropagateCallback struct {
+	ctx *Context
+	c   C.Z3_solver_callback
+}
+
+// Propagate adds the consequence that the current values of the terms
+// in fixed, together with the equalities between the terms in each
+// pair in eqs, imply conseq.
+func (cb *PropagateCallback) Propagate(fixed []TermID, eqs [][2]TermID, conseq Bool) {
+	cfixed := make([]C.uint, len(fixed))
+	for i, id := range fixed {
+		cfixed[i] = C.uint(id)
+	}
+	clhs := make([]C.uint, len(eqs))
+	crhs := make([]C.uint, len(eqs))
+	for i, eq := range eqs {
+		clhs[i], crhs[i] = C.uint(eq[0]), C.uint(eq[1])
+	}
+	var pfixed, plhs, prhs *C.uint
+	if len(fixed) > 0 {
+		pfixed = &cfixed[0]
+	}
+	if len(eqs) > 0 {
+		plhs, prhs = &clhs[0], &crhs[0]
+	}
+	cb.ctx.do(func() {
+		C.Z3_solver_propagate_consequence(cb.ctx.c, cb.c, C.uint(len(fixed)), pfixed, C.uint(len(eqs)), plhs, prhs, conseq.c)
+	})
+	runtime.KeepAlive(conseq)
+}
+
+// Conflict reports that the current values of the terms in fixed,
+// together with the equalities in eqs, are inconsistent with the
+// Propagator's theory. It is shorthand for a Propagate of false.
+func (cb *PropagateCallback) Conflict(fixed []TermID, eqs [][2]TermID) {
+	cb.Propagate(fixed, eqs, cb.ctx.FromBool(false))
+}
+
+// propagator is the state of a Propagator attached to a Solver.
+type propagator struct {
+	ctx *Context
+	p   Propagator
+
+	// h is a handle to this propagator and cptr is C memory
+	// holding h, which is passed to Z3 as the user context.
+	h    cgo.Handle
+	cptr *C.uintptr_t
+
+	// mu protects err, which is the first failure of p since the
+	// last check returned. Z3 may try to copy p from another
+	// thread.
+	mu  sync.Mutex
+	err *ErrPropagator
+}
+
+func (p *propagator) free() {
+	p.h.Delete()
+	C.free(unsafe.Pointer(p.cptr))
+}
+
+// SetPropagator attaches Propagator p to s. Terms for p to track can
+// then be added with RegisterTerm. Only solvers created by
+// NewSimpleSolver support Propagators. SetPropagator panics if s does
+// not support Propagators or already has one.
+//
+// A Solver with a Propagator cannot be copied by Translate or used in
+// a parallel mode that copies it. While s is checking, s's Context
+// must be used only by p's methods and not by other goroutines.
+func (s *Solver) SetPropagator(p Propagator) {
+	if s.prop != nil {
+		panic("Solver already has a Propagator")
+	}
+	prop := &propagator{ctx: s.ctx, p: p}
+	prop.h = cgo.NewHandle(prop)
+	prop.cptr = (*C.uintptr_t)(C.malloc(C.sizeof_uintptr_t))
+	*prop.cptr = C.uintptr_t(prop.h)
+	ok := false
+	defer func() {
+		if !ok {
+			prop.free()
+		}
+	}()
+	s.ctx.do(func() {
+		C.Z3_solver_propagate_init(s.ctx.c, s.c, unsafe.Pointer(prop.cptr),
+			(*C.Z3_push_eh)(C.goZ3PropagatePush),
+			(*C.Z3_pop_eh)(C.goZ3PropagatePop),
+			(*C.Z3_fresh_eh)(C.goZ3PropagateFresh))
+		if _, ok := p.(FixedPropagator); ok {
+			C.Z3_solver_propagate_fixed(s.ctx.c, s.c, (*C.Z3_fixed_eh)(C.goZ3PropagateFixed))
+		}
+		if _, ok := p.(EqPropagator); ok {
+			C.Z3_solver_propagate_eq(s.ctx.c, s.c, (*C.Z3_eq_eh)(C.goZ3PropagateEq))
+		}
+		if _, ok := p.(DiseqPropagator); ok {
+			C.Z3_solver_propagate_diseq(s.ctx.c, s.c, (*C.Z3_eq_eh)(C.goZ3PropagateDiseq))
+		}
+		if _, ok := p.(FinalPropagator); ok {
+			C.Z3_solver_propagate_final(s.ctx.c, s.c, (*C.Z3_final_eh)(C.goZ3PropagateFinal))
+		}
+	})
+	runtime.KeepAlive(s)
+	s.prop, ok = prop, true
+}
+
+// RegisterTerm registers term with the Propagator of s and returns
+// the ID that identifies it in Propagator callbacks. Z3 4.8 supports
+// Bool and BV terms. RegisterTerm panics if s has no Propagator.
+func (s *Solver) RegisterTerm(term Value) TermID {
+	if s.prop == nil {
+		panic("Solver has no Propagator")
+	}
+	var id C.uint
+	s.ctx.do(func() {
+		id = C.Z3_solver_propagate_register(s.ctx.c, s.c, term.impl().c)
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(term)
+	return TermID(id)
+}
+
+// lookupPropagator returns the propagator for the user context ctx,
+// or nil if ctx is nil, which is the user context of a failed copy.
+func lookupPropagator(ctx unsafe.Pointer) *propagator {
+	if ctx == nil {
+		return nil
+	}
+	return cgo.Handle(*(*C.uintptr_t)(ctx)).Value().(*propagator)
+}
+
+// callback calls f from a Z3 callback. Z3 calls callbacks from
+// operations that hold p.ctx.lock, so callback releases the lock
+// while f runs so f can use the Context.
+//
+// Z3 is in the middle of an operation at this point, so finalizers
+// must not call Z3 until the callback returns. Context.release
+// defers their work until then.
+//
+// A panic must not unwind through Z3's C frames, so callback
+// recovers it and fails p instead. It does not call f once p has
+// failed.
+func (p *propagator) callback(f func()) {
+	ctx := p.ctx
+	ctx.callbacks++
+	ctx.lock.Unlock()
+	defer func() {
+		ctx.lock.Lock()
+		ctx.callbacks--
+		if ctx.callbacks == 0 {
+			released := ctx.released
+			ctx.released = nil
+			for _, f := range released {
+				f()
+			}
+		}
+	}()
+	defer func() {
+		if e := recover(); e != nil {
+			p.fail(&ErrPropagator{e})
+		}
+	}()
+	p.mu.Lock()
+	failed := p.err != nil
+	p.mu.Unlock()
+	if !failed {
+		f()
+	}
+}
+
+// fail records err as p's failure and interrupts the running check.
+func (p *propagator) fail(err *ErrPropagator) {
+	p.mu.Lock()
+	if p.err == nil {
+		p.err = err
+	}
+	p.mu.Unlock()
+	p.ctx.Interrupt()
+}
+
+// propagatorErr returns and clears the failure of s's Propagator, if
+// any. Operations that can call the Propagator report this instead
+// of their result.
+func (s *Solver) propagatorErr() error {
+	if s.prop == nil {
+		return nil
+	}
+	p := s.prop
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := p.err
+	p.err = nil
+	if err == nil {
+		return nil
+	}
+	return err
+}
+
+//export goZ3PropagatePush
+func goZ3PropagatePush(ctx unsafe.Pointer) {
+	p := lookupPropagator(ctx)
+	if p == nil {
+		return
+	}
+	p.callback(p.p.Push)
+}
+
+//export goZ3PropagatePop
+func goZ3PropagatePop(ctx unsafe.Pointer, n C.uint) {
+	p := lookupPropagator(ctx)
+	if p == nil {
+		return
+	}
+	p.callback(func() { p.p.Pop(int(n)) })
+}
+
+//export goZ3PropagateFresh
+func goZ3PropagateFresh(ctx unsafe.Pointer, newCtx C.Z3_context) unsafe.Pointer {
+	// Propagators can't be copied. Fail the check rather than
+	// letting the copy ignore the Propagator's theory. Z3 may
+	// call this from another thread, so it must not use the
+	// Context except to interrupt it.
+	if p := lookupPropagator(ctx); p != nil {
+		p.fail(&ErrPropagator{})
+	}
+	return nil
+}
+
+//export goZ3PropagateFixed
+func goZ3PropagateFixed(ctx unsafe.Pointer, cb C.Z3_solver_callback, id C.uint, cval C.Z3_ast) {
+	p := lookupPropagator(ctx)
+	if p == nil {
+		return
+	}
+	// We hold the lock here, so we can wrap cval directly.
+	val := value{(*valueImpl)(wrapAST(p.ctx, cval).astImpl), noEq{}}
+	p.callback(func() {
+		cb := &PropagateCallback{p.ctx, cb}
+		p.p.(FixedPropagator).Fixed(cb, TermID(id), val.lift(KindUnknown))
+	})
+}
+
+//export goZ3PropagateEq
+func goZ3PropagateEq(ctx unsafe.Pointer, cb C.Z3_solver_callback, x, y C.uint) {
+	p := lookupPropagator(ctx)
+	if p == nil {
+		return
+	}
+	p.callback(func() {
+		p.p.(EqPropagator).Eq(&PropagateCallback{p.ctx, cb}, TermID(x), TermID(y))
+	})
+}
+
+//export goZ3PropagateDiseq
+func goZ3PropagateDiseq(ctx unsafe.Pointer, cb C.Z3_solver_callback, x, y C.uint) {
+	p := lookupPropagator(ctx)
+	if p == nil {
+		return
+	}
+	p.callback(func() {
+		p.p.(DiseqPropagator).Diseq(&PropagateCallback{p.ctx, cb}, TermID(x), TermID(y))
+	})
+}
+
+//export goZ3PropagateFinal
+func goZ3PropagateFinal(ctx unsafe.Pointer, cb C.Z3_solver_callback) {
+	p := lookupPropagator(ctx)
+	if p == nil {
+		return
+	}
+	p.callback(func() {
+		p.p.(FinalPropagator).Final(&PropagateCallback{p.ctx, cb})
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+// atMostOne is a Propagator for the theory that at most one
+// registered Bool is true.
+type atMostOne struct {
+	trues  []TermID
+	scopes []int
+	finals int
+}
+
+func (p *atMostOne) Push() {
+	p.scopes = append(p.scopes, len(p.trues))
+}
+
+func (p *atMostOne) Pop(n int) {
+	p.trues = p.trues[:p.scopes[len(p.scopes)-n]]
+	p.scopes = p.scopes[:len(p.scopes)-n]
+}
+
+func (p *atMostOne) Fixed(cb *PropagateCallback, id TermID, val Value) {
+	if v, _ := val.(Bool).AsBool(); !v {
+		return
+	}
+	if len(p.trues) > 0 {
+		cb.Conflict([]TermID{p.trues[0], id}, nil)
+	}
+	p.trues = append(p.trues, id)
+}
+
+func (p *atMostOne) Final(cb *PropagateCallback) {
+	p.finals++
+}
+
+func TestPropagator(t *testing.T) {
+	ctx := NewContext(nil)
+	vars := []Bool{ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c")}
+	a, b, c := vars[0], vars[1], vars[2]
+
+	newSolver := func() (*Solver, *atMostOne) {
+		s := NewSimpleSolver(ctx)
+		p := new(atMostOne)
+		s.SetPropagator(p)
+		for _, v := range vars {
+			s.RegisterTerm(v)
+		}
+		return s, p
+	}
+
+	// At least two are true, which contradicts the theory.
+	s, _ := newSolver()
+	s.Assert(a.Or(b))
+	s.Assert(b.Or(c))
+	s.Assert(a.Or(c))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("Check = %v, %v; want false, nil", sat, err)
+	}
+
+	// At least one is true.
+	s, p := newSolver()
+	s.Assert(a.Or(b, c))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check = %v, %v; want true, nil", sat, err)
+	}
+	m := s.Model()
+	n := 0
+	for _, v := range vars {
+		if val, _ := m.Eval(v, true).(Bool).AsBool(); val {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("model has %d true variables, want 1:\n%s", n, m)
+	}
+	if p.finals == 0 {
+		t.Errorf("Final not called")
+	}
+
+	wantPanic(t, "already has a Propagator", func() { s.SetPropagator(p) })
+	wantPanic(t, "only supported on the SMT solver", func() { NewSolver(ctx).SetPropagator(p) })
+	wantPanic(t, "has no Propagator", func() { NewSolver(ctx).RegisterTerm(a) })
+}
+
+// panicky is a Propagator that panics when a term is fixed and
+// releases an object during the callback.
+type panicky struct {
+	ctx      *Context
+	released bool
+}
+
+func (p *panicky) Push()     {}
+func (p *panicky) Pop(n int) {}
+
+func (p *panicky) Fixed(cb *PropagateCallback, id TermID, val Value) {
+	// Finalizers must not call Z3 during a callback.
+	p.ctx.release(func() { p.released = true })
+	if p.released {
+		panic("released during callback")
+	}
+	panic("boom")
+}
+
+func TestPropagatorPanic(t *testing.T) {
+	ctx := NewContext(nil)
+	a := ctx.BoolConst("a")
+	s := NewSimpleSolver(ctx)
+	p := &panicky{ctx: ctx}
+	s.SetPropagator(p)
+	s.RegisterTerm(a)
+	s.Assert(a)
+	sat, err := s.Check()
+	if err, ok := err.(*ErrPropagator); sat || !ok || err.Panic != "boom" {
+		t.Fatalf("Check = %v, %v; want false, Propagator panicked: boom", sat, err)
+	}
+	if !p.released {
+		t.Errorf("release deferred past callback")
+	}
+
+	// The Context is still usable.
+	s2 := NewSolver(ctx)
+	s2.Assert(a.Not())
+	if sat, err := s2.Check(); !sat || err != nil {
+		t.Errorf("Check after Propagator panic = %v, %v; want true, nil", sat, err)
+	}
+
+	wantPanic(t, "cannot copy a Solver with a Propagator", func() { s.Translate(NewContext(nil)) })
+}
@@ -112,8 +112,68 @@ func (lit Real) Approx(precision int) (lower, upper Real, isLiteralIrrational bo
 	return lower, upper, true
 }
 
-// TODO: AsBigFloat? AsFloat64? AsFloat32? I don't actually know how
-// to implement those without potentially double rounding.
+// AsBigFloat returns the value of lit as a math/big.Float with prec
+// bits of precision, rounded to nearest even. prec must be > 0. If
+// lit is not a literal, it returns nil, false.
+//
+// Unlike AsBigRat, this also works for irrational literals, which are
+// correctly rounded.
+func (lit Real) AsBigFloat(prec uint) (val *big.Float, isLiteral bool) {
+	res, isLiteral := lit.round(func(r *big.Rat) *big.Float {
+		return new(big.Float).SetPrec(prec).SetRat(r)
+	})
+	return res, isLiteral
+}
+
+// AsFloat64 returns the value of lit as a float64. If lit is not a
+// literal, it returns 0, false, false. If lit cannot be represented
+// exactly as a float64, it is rounded to the nearest float64 and
+// AsFloat64 returns false for exact.
+func (lit Real) AsFloat64() (val float64, isLiteral, exact bool) {
+	if rat, ok := lit.AsBigRat(); ok {
+		val, exact = rat.Float64()
+		return val, true, exact
+	}
+	res, isLiteral := lit.round(func(r *big.Rat) *big.Float {
+		f, _ := r.Float64()
+		return big.NewFloat(f)
+	})
+	if !isLiteral {
+		return 0, false, false
+	}
+	val, _ = res.Float64()
+	return val, true, false
+}
+
+// maxApproxDigits limits how far Real.round will refine an irrational
+// literal.
+const maxApproxDigits = 1 << 12
+
+// round applies the rounding function round to lit. round must be
+// monotonic.
+//
+// Rounding an irrational literal is done by refining its rational
+// bounds until round produces the same result for both bounds. This
+// avoids double rounding. Since the rounding boundaries are rational
+// and lit is not, this converges, though we give up and return the
+// rounding of the lower bound after maxApproxDigits.
+func (lit Real) round(round func(*big.Rat) *big.Float) (val *big.Float, isLiteral bool) {
+	if rat, ok := lit.AsBigRat(); ok {
+		return round(rat), true
+	}
+	for digits := 20; ; digits *= 2 {
+		lower, upper, ok := lit.Approx(digits)
+		if !ok {
+			return nil, false
+		}
+		l, _ := lower.AsBigRat()
+		u, _ := upper.AsBigRat()
+		lf, uf := round(l), round(u)
+		if lf.Cmp(uf) == 0 || digits >= maxApproxDigits {
+			return lf, true
+		}
+	}
+}
 
 //go:generate go run genwrap.go -t Real $GOFILE intreal.go
 
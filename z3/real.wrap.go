@@ -31,7 +31,7 @@ func (l Real) NE(r Real) Bool {
 //
 // If r is 0, the result is unconstrained.
 func (l Real) Div(r Real) Real {
-	// Generated from real.go:124.
+	// Generated from real.go:184.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_div(ctx.c, l.c, r.c)
@@ -45,7 +45,7 @@ func (l Real) Div(r Real) Real {
 //
 // Note that this is not truncation. For example, ToInt(-1.3) is -2.
 func (l Real) ToInt() Int {
-	// Generated from real.go:130.
+	// Generated from real.go:190.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_real2int(ctx.c, l.c)
@@ -56,7 +56,7 @@ func (l Real) ToInt() Int {
 
 // IsInt returns a Value that is true if l has no fractional part.
 func (l Real) IsInt() Bool {
-	// Generated from real.go:134.
+	// Generated from real.go:194.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_is_int(ctx.c, l.c)
@@ -70,7 +70,7 @@ func (l Real) IsInt() Bool {
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Real) ToFloat(s Sort) Float {
-	// Generated from real.go:141.
+	// Generated from real.go:201.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -86,7 +86,7 @@ func (l Real) ToFloat(s Sort) Float {
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Real) ToFloatExp(exp Int, s Sort) Float {
-	// Generated from real.go:148.
+	// Generated from real.go:208.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
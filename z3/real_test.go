@@ -67,3 +67,37 @@ func TestRealIrrational(t *testing.T) {
 		}
 	}
 }
+
+func TestRealAsFloat(t *testing.T) {
+	ctx := NewContext(nil)
+	third := ctx.FromBigRat(big.NewRat(1, 3))
+	if val, isLit, exact := third.AsFloat64(); val != 1.0/3 || !isLit || exact {
+		t.Errorf("(%s).AsFloat64() = %v, %v, %v, want %v, true, false", third, val, isLit, exact, 1.0/3)
+	}
+	if val, isLit := third.AsBigFloat(200); !isLit || val.Prec() != 200 {
+		t.Errorf("(%s).AsBigFloat(200) = %v, %v, want 200-bit value, true", third, val, isLit)
+	}
+
+	root2 := ctx.Simplify(ctx.FromInt(2, ctx.IntSort()).(Int).ToReal().Exp(ctx.FromBigRat(big.NewRat(1, 2))), nil).(Real)
+	if val, isLit, exact := root2.AsFloat64(); val != math.Sqrt2 || !isLit || exact {
+		t.Errorf("(%s).AsFloat64() = %v, %v, %v, want %v, true, false", root2, val, isLit, exact, math.Sqrt2)
+	}
+	want := new(big.Float).SetPrec(200).SetInt64(2)
+	want.Sqrt(want)
+	if val, isLit := root2.AsBigFloat(200); !isLit || val.Cmp(want) != 0 {
+		t.Errorf("(%s).AsBigFloat(200) = %v, %v, want %v, true", root2, val, isLit, want)
+	}
+
+	x := ctx.RealConst("x")
+	if _, isLit, _ := x.AsFloat64(); isLit {
+		t.Errorf("(%s).AsFloat64() returned true for isLiteral", x)
+	}
+}
+
+func TestRealFromBigFloat(t *testing.T) {
+	ctx := NewContext(nil)
+	got := ctx.FromBigFloat(big.NewFloat(0.375), ctx.RealSort()).(Real)
+	if val, isLit := got.AsBigRat(); !isLit || val.Cmp(big.NewRat(3, 8)) != 0 {
+		t.Errorf("FromBigFloat(0.375) = %s, want 3/8", got)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+import "runtime"
+
+// Seq is a symbolic value representing a finite sequence of values
+// of some element sort.
+//
+// Sequences are indexed by Int values starting at 0. Unlike Arrays,
+// sequences have a length, and can be concatenated and searched.
+//
+// Seq implements Value.
+type Seq value
+
+func init() {
+	kindWrappers[KindSeq] = func(x value) Value {
+		return Seq(x)
+	}
+}
+
+// SeqSort returns a sort for sequences of elem values.
+func (ctx *Context) SeqSort(elem Sort) Sort {
+	var sort Sort
+	ctx.do(func() {
+		sort = wrapSort(ctx, C.Z3_mk_seq_sort(ctx.c, elem.c), KindSeq)
+	})
+	runtime.KeepAlive(elem)
+	return sort
+}
+
+// SeqEmpty returns the empty sequence of sort s. s must be a sequence
+// sort.
+func (ctx *Context) SeqEmpty(s Sort) Seq {
+	val := Seq(wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_empty(ctx.c, s.c)
+	}))
+	runtime.KeepAlive(s)
+	return val
+}
+
+// SeqUnit returns the sequence of length 1 containing elem.
+func (ctx *Context) SeqUnit(elem Value) Seq {
+	val := Seq(wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_unit(ctx.c, elem.impl().c)
+	}))
+	runtime.KeepAlive(elem)
+	return val
+}
+
+// SeqElem returns the element sort of sequence sort s.
+func (s Sort) SeqElem() Sort {
+	var elem Sort
+	s.ctx.do(func() {
+		elem = wrapSort(s.ctx, C.Z3_get_seq_sort_basis(s.ctx.c, s.c), KindUnknown)
+	})
+	runtime.KeepAlive(s)
+	return elem
+}
+
+//go:generate go run genwrap.go -t Seq $GOFILE
+
+// Concat returns the concatenation of l and all arguments.
+//
+// All sequences must have the same sort.
+//
+//wrap:expr Concat Z3_mk_seq_concat l r...
+
+// Length returns the length of l.
+//
+//wrap:expr Length:Int Z3_mk_seq_length l
+
+// HasPrefix returns true if prefix is a prefix of l.
+//
+//wrap:expr HasPrefix:Bool l prefix : Z3_mk_seq_prefix prefix l
+
+// HasSuffix returns true if suffix is a suffix of l.
+//
+//wrap:expr HasSuffix:Bool l suffix : Z3_mk_seq_suffix suffix l
+
+// Contains returns true if sub is a subsequence of l.
+//
+//wrap:expr Contains:Bool l sub : Z3_mk_seq_contains l sub
+
+// Extract returns the subsequence of l starting at offset and
+// containing (at most) length elements.
+//
+// If offset is out of bounds or length is negative, the result is the
+// empty sequence. If offset+length is past the end of l, the result
+// is truncated.
+//
+//wrap:expr Extract l offset:Int length:Int : Z3_mk_seq_extract l offset length
+
+// At returns the unit sequence containing the element of l at index
+// i, or the empty sequence if i is out of bounds.
+//
+//wrap:expr At l i:Int : Z3_mk_seq_at l i
+
+// Nth returns the element of l at index i.
+//
+// If i is out of bounds, the result is unconstrained.
+//
+//wrap:expr Nth:Value l i:Int : Z3_mk_seq_nth l i
+
+// IndexOf returns the index of the first occurrence of sub in l at or
+// after offset, or -1 if there is no such occurrence.
+//
+//wrap:expr IndexOf:Int l sub offset:Int : Z3_mk_seq_index l sub offset
+
+// LastIndexOf returns the index of the last occurrence of sub in l,
+// or -1 if there is no such occurrence.
+//
+//wrap:expr LastIndexOf:Int l sub : Z3_mk_seq_last_index l sub
+
+// Replace returns l with the first occurrence of src replaced with
+// dst. If src does not occur in l, the result is l.
+//
+//wrap:expr Replace l src dst : Z3_mk_seq_replace l src dst
@@ -0,0 +1,179 @@
+// Generated by genwrap.go. DO NOT EDIT
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// Eq returns a Value that is true if l and r are equal.
+func (l Seq) Eq(r Seq) Bool {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// NE returns a Value that is true if l and r are not equal.
+func (l Seq) NE(r Seq) Bool {
+	return l.ctx.Distinct(l, r)
+}
+
+// Concat returns the concatenation of l and all arguments.
+//
+// All sequences must have the same sort.
+func (l Seq) Concat(r ...Seq) Seq {
+	// Generated from seq.go:74.
+	ctx := l.ctx
+	cargs := make([]C.Z3_ast, len(r)+1)
+	cargs[0] = l.c
+	for i, arg := range r {
+		cargs[i+1] = arg.c
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_concat(ctx.c, C.uint(len(cargs)), &cargs[0])
+	})
+	runtime.KeepAlive(&cargs[0])
+	return Seq(val)
+}
+
+// Length returns the length of l.
+func (l Seq) Length() Int {
+	// Generated from seq.go:78.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_length(ctx.c, l.c)
+	})
+	runtime.KeepAlive(l)
+	return Int(val)
+}
+
+// HasPrefix returns true if prefix is a prefix of l.
+func (l Seq) HasPrefix(prefix Seq) Bool {
+	// Generated from seq.go:82.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_prefix(ctx.c, prefix.c, l.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(prefix)
+	return Bool(val)
+}
+
+// HasSuffix returns true if suffix is a suffix of l.
+func (l Seq) HasSuffix(suffix Seq) Bool {
+	// Generated from seq.go:86.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_suffix(ctx.c, suffix.c, l.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(suffix)
+	return Bool(val)
+}
+
+// Contains returns true if sub is a subsequence of l.
+func (l Seq) Contains(sub Seq) Bool {
+	// Generated from seq.go:90.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_contains(ctx.c, l.c, sub.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(sub)
+	return Bool(val)
+}
+
+// Extract returns the subsequence of l starting at offset and
+// containing (at most) length elements.
+//
+// If offset is out of bounds or length is negative, the result is the
+// empty sequence. If offset+length is past the end of l, the result
+// is truncated.
+func (l Seq) Extract(offset Int, length Int) Seq {
+	// Generated from seq.go:99.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_extract(ctx.c, l.c, offset.c, length.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(offset)
+	runtime.KeepAlive(length)
+	return Seq(val)
+}
+
+// At returns the unit sequence containing the element of l at index
+// i, or the empty sequence if i is out of bounds.
+func (l Seq) At(i Int) Seq {
+	// Generated from seq.go:104.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_at(ctx.c, l.c, i.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(i)
+	return Seq(val)
+}
+
+// Nth returns the element of l at index i.
+//
+// If i is out of bounds, the result is unconstrained.
+func (l Seq) Nth(i Int) Value {
+	// Generated from seq.go:110.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_nth(ctx.c, l.c, i.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(i)
+	return val.lift(KindUnknown)
+}
+
+// IndexOf returns the index of the first occurrence of sub in l at or
+// after offset, or -1 if there is no such occurrence.
+func (l Seq) IndexOf(sub Seq, offset Int) Int {
+	// Generated from seq.go:115.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_index(ctx.c, l.c, sub.c, offset.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(sub)
+	runtime.KeepAlive(offset)
+	return Int(val)
+}
+
+// LastIndexOf returns the index of the last occurrence of sub in l,
+// or -1 if there is no such occurrence.
+func (l Seq) LastIndexOf(sub Seq) Int {
+	// Generated from seq.go:120.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_last_index(ctx.c, l.c, sub.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(sub)
+	return Int(val)
+}
+
+// Replace returns l with the first occurrence of src replaced with
+// dst. If src does not occur in l, the result is l.
+func (l Seq) Replace(src Seq, dst Seq) Seq {
+	// Generated from seq.go:125.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_replace(ctx.c, l.c, src.c, dst.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(src)
+	runtime.KeepAlive(dst)
+	return Seq(val)
+}
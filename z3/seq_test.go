@@ -0,0 +1,37 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSeq(t *testing.T) {
+	ctx := NewContext(nil)
+	bytes := ctx.BVSort(8)
+	ints := ctx.IntSort()
+	seq := func(vals ...int64) Seq {
+		s := ctx.SeqEmpty(ctx.SeqSort(bytes))
+		for _, v := range vals {
+			s = s.Concat(ctx.SeqUnit(ctx.FromInt(v, bytes)))
+		}
+		return s
+	}
+
+	abc := seq('a', 'b', 'c')
+	if !simplifyBool(t, ctx, abc.Length().Eq(ctx.FromInt(3, ints).(Int))) {
+		t.Errorf("len(%s) != 3", abc)
+	}
+	if !simplifyBool(t, ctx, abc.HasPrefix(seq('a', 'b'))) {
+		t.Errorf("%s does not have prefix ab", abc)
+	}
+	if !simplifyBool(t, ctx, abc.IndexOf(seq('c'), ctx.FromInt(0, ints).(Int)).Eq(ctx.FromInt(2, ints).(Int))) {
+		t.Errorf("index of c in %s != 2", abc)
+	}
+	if !simplifyBool(t, ctx, abc.Nth(ctx.FromInt(1, ints).(Int)).(BV).Eq(ctx.FromInt('b', bytes).(BV))) {
+		t.Errorf("%s[1] != b", abc)
+	}
+	if elem := abc.Sort().SeqElem(); elem.Kind() != KindBV || elem.BVSize() != 8 {
+		t.Errorf("element sort of %s is %s, want (_ BitVec 8)", abc, elem)
+	}
+}
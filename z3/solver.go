@@ -4,7 +4,13 @@
 
 package z3
 
-import "runtime"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+)
 
 /*
 #cgo LDFLAGS: -lz3
@@ -26,28 +32,180 @@ type Solver struct {
 type solverImpl struct {
 	ctx *Context
 	c   C.Z3_solver
+
+	// prop is the Propagator set by SetPropagator, or nil.
+	prop *propagator
 }
 
 // NewSolver returns a new, empty solver.
 func NewSolver(ctx *Context) *Solver {
 	var impl *solverImpl
 	ctx.do(func() {
-		impl = &solverImpl{
-			ctx,
-			C.Z3_mk_solver(ctx.c),
-		}
+		impl = wrapSolver(ctx, C.Z3_mk_solver(ctx.c))
 	})
+	return &Solver{impl, noEq{}}
+}
+
+// NewSimpleSolver returns a new, empty solver that checks
+// satisfiability directly with Z3's incremental core, without the
+// preprocessing NewSolver may apply. Unlike other solvers, it
+// maintains a Trail.
+func NewSimpleSolver(ctx *Context) *Solver {
+	var impl *solverImpl
+	ctx.do(func() {
+		impl = wrapSolver(ctx, C.Z3_mk_simple_solver(ctx.c))
+	})
+	return &Solver{impl, noEq{}}
+}
+
+// NewSolverFromTactic returns a new, empty solver that checks
+// satisfiability using the named Z3 tactic, such as "qfbv" or
+// "qflia". It panics if there is no tactic with the given name.
+func NewSolverFromTactic(ctx *Context, tactic string) *Solver {
+	cname := C.CString(tactic)
+	defer C.free(unsafe.Pointer(cname))
+	var impl *solverImpl
 	ctx.do(func() {
-		C.Z3_solver_inc_ref(ctx.c, impl.c)
+		t := C.Z3_mk_tactic(ctx.c, cname)
+		C.Z3_tactic_inc_ref(ctx.c, t)
+		defer C.Z3_tactic_dec_ref(ctx.c, t)
+		impl = wrapSolver(ctx, C.Z3_mk_solver_from_tactic(ctx.c, t))
 	})
+	return &Solver{impl, noEq{}}
+}
+
+// wrapSolver wraps a C Z3_solver. This must be called with the
+// ctx.lock held.
+func wrapSolver(ctx *Context, c C.Z3_solver) *solverImpl {
+	impl := &solverImpl{ctx, c, nil}
+	C.Z3_solver_inc_ref(ctx.c, c)
 	runtime.SetFinalizer(impl, func(impl *solverImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_solver_dec_ref(impl.ctx.c, impl.c)
 		})
+		if impl.prop != nil {
+			impl.prop.free()
+		}
 	})
+	return impl
+}
+
+// Translate returns a copy of s, including its assertions, in the
+// target Context. Since Contexts are independent, the copy can be
+// checked concurrently with s. Translate panics if s has a
+// Propagator.
+func (s *Solver) Translate(target *Context) *Solver {
+	if s.prop != nil {
+		panic("cannot copy a Solver with a Propagator")
+	}
+	var impl *solverImpl
+	s.ctx.doBoth(target, func() {
+		impl = wrapSolver(target, C.Z3_solver_translate(s.ctx.c, s.c, target.c))
+	})
+	runtime.KeepAlive(s)
 	return &Solver{impl, noEq{}}
 }
 
+// TranslateInto adds a copy of each of s's assertions to dst, which
+// may be in a different Context. Unlike Translate, dst can be any
+// kind of solver, such as one created by NewSolverFromTactic.
+func (s *Solver) TranslateInto(dst *Solver) {
+	f := func() {
+		cvec := C.Z3_solver_get_assertions(s.ctx.c, s.c)
+		C.Z3_ast_vector_inc_ref(s.ctx.c, cvec)
+		defer C.Z3_ast_vector_dec_ref(s.ctx.c, cvec)
+		if dst.ctx != s.ctx {
+			cvec = C.Z3_ast_vector_translate(s.ctx.c, cvec, dst.ctx.c)
+			C.Z3_ast_vector_inc_ref(dst.ctx.c, cvec)
+			defer C.Z3_ast_vector_dec_ref(dst.ctx.c, cvec)
+		}
+		n := C.Z3_ast_vector_size(dst.ctx.c, cvec)
+		for i := C.uint(0); i < n; i++ {
+			C.Z3_solver_assert(dst.ctx.c, dst.c, C.Z3_ast_vector_get(dst.ctx.c, cvec, i))
+		}
+	}
+	s.ctx.doBoth(dst.ctx, f)
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(dst)
+}
+
+// Config returns a *Config object for dynamically changing s's
+// parameters. For example, s.Config().SetUint("random_seed", 42)
+// changes the random seed used by s.
+func (s *Solver) Config() *Config {
+	cfg := newConfig(nil)
+	cfg.set = func(name string, value interface{}) {
+		one := newConfig(nil)
+		one.m[name] = value
+		cparams := one.toC(s.ctx)
+		s.ctx.do(func() {
+			defer C.Z3_params_dec_ref(s.ctx.c, cparams)
+			C.Z3_solver_set_params(s.ctx.c, s.c, cparams)
+		})
+		runtime.KeepAlive(s)
+	}
+	return cfg
+}
+
+// Assertions returns the predicates asserted in s.
+func (s *Solver) Assertions() []Bool {
+	return s.boolVector(func() C.Z3_ast_vector {
+		return C.Z3_solver_get_assertions(s.ctx.c, s.c)
+	})
+}
+
+// Units returns the unit literals that s has derived from its
+// assertions in the last Check. These are literals that hold in every
+// model.
+func (s *Solver) Units() []Bool {
+	return s.boolVector(func() C.Z3_ast_vector {
+		return C.Z3_solver_get_units(s.ctx.c, s.c)
+	})
+}
+
+// NonUnits returns the assertions of s that are not units, after the
+// simplifications performed by the last Check.
+func (s *Solver) NonUnits() []Bool {
+	return s.boolVector(func() C.Z3_ast_vector {
+		return C.Z3_solver_get_non_units(s.ctx.c, s.c)
+	})
+}
+
+// Trail returns the literals assigned by s's SAT solver in the last
+// Check, in the order they were assigned. Only solvers created by
+// NewSimpleSolver maintain a trail; for other solvers, Trail panics.
+func (s *Solver) Trail() []Bool {
+	return s.boolVector(func() C.Z3_ast_vector {
+		return C.Z3_solver_get_trail(s.ctx.c, s.c)
+	})
+}
+
+// boolVector calls f with the ctx.lock held and returns the Bools in
+// the AST vector it returns.
+func (s *Solver) boolVector(f func() C.Z3_ast_vector) []Bool {
+	var cvec C.Z3_ast_vector
+	var n C.uint
+	s.ctx.do(func() {
+		cvec = f()
+		C.Z3_ast_vector_inc_ref(s.ctx.c, cvec)
+		n = C.Z3_ast_vector_size(s.ctx.c, cvec)
+	})
+	defer s.ctx.do(func() { C.Z3_ast_vector_dec_ref(s.ctx.c, cvec) })
+	res := make([]Bool, n)
+	for i := C.uint(0); i < n; i++ {
+		res[i] = Bool(wrapValue(s.ctx, func() C.Z3_ast {
+			return C.Z3_ast_vector_get(s.ctx.c, cvec, i)
+		}))
+	}
+	runtime.KeepAlive(s)
+	return res
+}
+
+// Context returns the Context of s.
+func (s *Solver) Context() *Context {
+	return s.ctx
+}
+
 // Assert adds val to the set of predicates that must be satisfied.
 func (s *Solver) Assert(val Bool) {
 	s.ctx.do(func() {
@@ -68,12 +226,32 @@ func (s *Solver) Push() {
 
 // Pop removes assertions that were added since the matching Push.
 func (s *Solver) Pop() {
+	s.PopN(1)
+}
+
+// PopN pops n scopes, as if by n calls to Pop. It panics if n is
+// greater than s.NumScopes().
+func (s *Solver) PopN(n int) {
+	if n < 0 || n > s.NumScopes() {
+		panic(fmt.Sprintf("cannot pop %d of %d scopes", n, s.NumScopes()))
+	}
 	s.ctx.do(func() {
-		C.Z3_solver_pop(s.ctx.c, s.c, 1)
+		C.Z3_solver_pop(s.ctx.c, s.c, C.uint(n))
 	})
 	runtime.KeepAlive(s)
 }
 
+// NumScopes returns the number of scopes pushed on s that have not
+// been popped.
+func (s *Solver) NumScopes() int {
+	var n C.uint
+	s.ctx.do(func() {
+		n = C.Z3_solver_get_num_scopes(s.ctx.c, s.c)
+	})
+	runtime.KeepAlive(s)
+	return int(n)
+}
+
 // Reset removes all assertions from the Solver and resets its stack.
 func (s *Solver) Reset() {
 	s.ctx.do(func() {
@@ -94,25 +272,131 @@ func (e *ErrSatUnknown) Error() string {
 	return e.Reason
 }
 
+// ErrUnsat is returned by queries that require the predicates in a
+// Solver to be satisfiable when they are not.
+var ErrUnsat = errors.New("predicates are unsatisfiable")
+
 // Check determines whether the predicates in Solver s are satisfiable
 // or unsatisfiable. If Z3 is unable to determine satisfiability, it
-// returns an *ErrSatUnknown error.
+// returns an *ErrSatUnknown error. If s's Propagator fails, it
+// returns an *ErrPropagator.
 func (s *Solver) Check() (sat bool, err error) {
 	var res C.Z3_lbool
 	s.ctx.do(func() {
 		res = C.Z3_solver_check(s.ctx.c, s.c)
 	})
+	if err := s.propagatorErr(); err != nil {
+		return false, err
+	}
 	if res == C.Z3_L_UNDEF {
-		// Get the reason.
-		s.ctx.do(func() {
-			cerr := C.Z3_solver_get_reason_unknown(s.ctx.c, s.c)
-			err = &ErrSatUnknown{C.GoString(cerr)}
-		})
+		err = s.reasonUnknown()
 	}
 	runtime.KeepAlive(s)
 	return res == C.Z3_L_TRUE, err
 }
 
+// reasonUnknown returns an *ErrSatUnknown giving the reason the last
+// check of s was inconclusive.
+func (s *Solver) reasonUnknown() error {
+	var err error
+	s.ctx.do(func() {
+		cerr := C.Z3_solver_get_reason_unknown(s.ctx.c, s.c)
+		err = &ErrSatUnknown{C.GoString(cerr)}
+	})
+	runtime.KeepAlive(s)
+	return err
+}
+
+// Consequences returns the consequences of the predicates in s and
+// assumptions for the values of vars. Each consequence has the form
+// (=> (and a1 a2 ...) (= v val)), where a1, a2, ... are a subset of
+// assumptions, v is in vars, and val is the value v must have. If v
+// is a Bool, the consequence is (=> (and ...) v) or (=> (and ...)
+// (not v)) instead. vars that are not forced to a single value have
+// no consequence.
+//
+// If the predicates and assumptions are unsatisfiable, Consequences
+// returns ErrUnsat. If Z3 cannot determine the consequences, it
+// returns an *ErrSatUnknown.
+func (s *Solver) Consequences(assumptions []Bool, vars []Value) ([]Bool, error) {
+	var res C.Z3_lbool
+	var cout C.Z3_ast_vector
+	conseqs := s.boolVector(func() C.Z3_ast_vector {
+		cassumptions := C.Z3_mk_ast_vector(s.ctx.c)
+		C.Z3_ast_vector_inc_ref(s.ctx.c, cassumptions)
+		defer C.Z3_ast_vector_dec_ref(s.ctx.c, cassumptions)
+		for _, a := range assumptions {
+			C.Z3_ast_vector_push(s.ctx.c, cassumptions, a.c)
+		}
+		cvars := C.Z3_mk_ast_vector(s.ctx.c)
+		C.Z3_ast_vector_inc_ref(s.ctx.c, cvars)
+		defer C.Z3_ast_vector_dec_ref(s.ctx.c, cvars)
+		for _, v := range vars {
+			C.Z3_ast_vector_push(s.ctx.c, cvars, v.impl().c)
+		}
+		cout = C.Z3_mk_ast_vector(s.ctx.c)
+		C.Z3_ast_vector_inc_ref(s.ctx.c, cout)
+		res = C.Z3_solver_get_consequences(s.ctx.c, s.c, cassumptions, cvars, cout)
+		return cout
+	})
+	s.ctx.do(func() { C.Z3_ast_vector_dec_ref(s.ctx.c, cout) })
+	runtime.KeepAlive(assumptions)
+	runtime.KeepAlive(vars)
+	if err := s.propagatorErr(); err != nil {
+		return nil, err
+	}
+	switch res {
+	case C.Z3_L_FALSE:
+		return nil, ErrUnsat
+	case C.Z3_L_UNDEF:
+		return nil, s.reasonUnknown()
+	}
+	return conseqs, nil
+}
+
+// ImpliedEqualities partitions terms into classes of terms that the
+// predicates in s force to be equal. It returns a class ID for each
+// term: two terms have the same ID if and only if s implies they are
+// equal. Terms with different IDs are not necessarily distinct.
+//
+// ImpliedEqualities checks the satisfiability of s. If s is
+// unsatisfiable, it returns ErrUnsat. If Z3 cannot determine
+// satisfiability, it returns an *ErrSatUnknown.
+func (s *Solver) ImpliedEqualities(terms []Value) ([]int, error) {
+	if len(terms) == 0 {
+		sat, err := s.Check()
+		if err == nil && !sat {
+			err = ErrUnsat
+		}
+		return nil, err
+	}
+	cterms := make([]C.Z3_ast, len(terms))
+	for i, t := range terms {
+		cterms[i] = t.impl().c
+	}
+	cids := make([]C.uint, len(terms))
+	var res C.Z3_lbool
+	s.ctx.do(func() {
+		res = C.Z3_get_implied_equalities(s.ctx.c, s.c, C.uint(len(terms)), &cterms[0], &cids[0])
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(terms)
+	if err := s.propagatorErr(); err != nil {
+		return nil, err
+	}
+	switch res {
+	case C.Z3_L_FALSE:
+		return nil, ErrUnsat
+	case C.Z3_L_UNDEF:
+		return nil, s.reasonUnknown()
+	}
+	ids := make([]int, len(terms))
+	for i, id := range cids {
+		ids[i] = int(id)
+	}
+	return ids, nil
+}
+
 // Model returns the model for the last Check. Model panics if Check
 // has not been called or the last Check did not return true.
 func (s *Solver) Model() *Model {
@@ -124,6 +408,18 @@ func (s *Solver) Model() *Model {
 	return model
 }
 
+// Proof returns the proof of unsatisfiability for the last Check.
+// Proof generation must be enabled by setting the "proof" parameter
+// in the Config passed to NewContext. Proof panics if proof
+// generation is disabled or the last Check did not return false.
+func (s *Solver) Proof() Proof {
+	val := wrapValue(s.ctx, func() C.Z3_ast {
+		return C.Z3_solver_get_proof(s.ctx.c, s.c)
+	})
+	runtime.KeepAlive(s)
+	return Proof(val)
+}
+
 // String returns a string representation of s.
 func (s *Solver) String() string {
 	var res string
@@ -133,3 +429,214 @@ func (s *Solver) String() string {
 	runtime.KeepAlive(s)
 	return res
 }
+
+// Cubes splits the search space of s into cubes, which are
+// conjunctions of literals, for cube-and-conquer solving. It returns
+// a function that calls yield with each cube in turn until yield
+// returns false or there are no more cubes.
+//
+// The assertions of s are satisfiable if and only if they are
+// satisfiable together with at least one of the cubes, and the cubes
+// can be checked independently, for example in separate Contexts. A
+// cube with no literals stands for the entire remaining search space
+// and is always the last cube. If s is unsatisfiable, there may be no
+// cubes at all.
+//
+// vars, if non-empty, are the Boolean variables to split on. By
+// default, Z3 chooses the variables itself. backtrack is the decision
+// level Z3 backtracks to after each cube, or -1 for Z3's default.
+//
+// Cubing is controlled by solver parameters such as
+// "sat.lookahead.cube.cutoff" and "sat.lookahead.cube.depth". Cubes
+// changes the state of s, so it should generally be called on a copy
+// made by Translate.
+func (s *Solver) Cubes(vars []Bool, backtrack int) func(yield func(cube []Bool) bool) {
+	return func(yield func(cube []Bool) bool) {
+		level := C.uint(math.MaxUint32)
+		if backtrack >= 0 {
+			level = C.uint(backtrack)
+		}
+		var cvars C.Z3_ast_vector
+		s.ctx.do(func() {
+			cvars = C.Z3_mk_ast_vector(s.ctx.c)
+			C.Z3_ast_vector_inc_ref(s.ctx.c, cvars)
+			for _, v := range vars {
+				C.Z3_ast_vector_push(s.ctx.c, cvars, v.c)
+			}
+		})
+		defer s.ctx.do(func() { C.Z3_ast_vector_dec_ref(s.ctx.c, cvars) })
+		runtime.KeepAlive(vars)
+
+		for {
+			cube := s.cube(cvars, level)
+			if len(cube) == 1 {
+				if val, ok := cube[0].AsBool(); ok {
+					if !val {
+						// No more cubes.
+						return
+					}
+					// The "true" cube covers the rest of
+					// the search space.
+					cube = cube[:0]
+				}
+			}
+			if !yield(cube) || len(cube) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// cube returns the next cube of s.
+func (s *Solver) cube(cvars C.Z3_ast_vector, level C.uint) []Bool {
+	return s.boolVector(func() C.Z3_ast_vector {
+		return C.Z3_solver_cube(s.ctx.c, s.c, cvars, level)
+	})
+}
+
+// Block asserts that the values of projection in s must differ from
+// their values in m, so the next Check finds a different model, if
+// there is one. Each value in projection must be a constant of s, such
+// as one returned by Context.Const.
+//
+// Block is the step Models uses between models. Calling Check, Model,
+// and Block in a loop enumerates models without the scope that Models
+// pushes, so the blocking clauses remain asserted afterward.
+func (s *Solver) Block(m *Model, projection []Value) {
+	if len(projection) == 0 {
+		panic("Block requires a non-empty projection")
+	}
+	diffs := make([]Bool, len(projection))
+	for i, v := range projection {
+		diffs[i] = s.ctx.Distinct(v, m.Eval(v, true))
+	}
+	s.Assert(diffs[0].Or(diffs[1:]...))
+}
+
+// Models enumerates the models of s that have distinct values of
+// projection. It returns a function that calls yield with each model
+// in turn until yield returns false, there are no more models, or
+// limit models have been produced. If limit <= 0, there is no limit.
+//
+// If Z3 cannot determine whether there is another model, Models calls
+// yield with a nil model and an *ErrSatUnknown error and stops.
+//
+// Models blocks each model it yields using Block within a new scope
+// of s, which it pops before returning, so s is unchanged afterward.
+// yield must not call Push or Pop on s without balancing them.
+func (s *Solver) Models(projection []Value, limit int) func(yield func(m *Model, err error) bool) {
+	return func(yield func(m *Model, err error) bool) {
+		s.Push()
+		defer s.Pop()
+		for n := 0; limit <= 0 || n < limit; n++ {
+			sat, err := s.Check()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !sat {
+				return
+			}
+			m := s.Model()
+			if !yield(m, nil) {
+				return
+			}
+			s.Block(m, projection)
+		}
+	}
+}
+
+// CountModels returns the number of models of s that have distinct
+// values of projection, counting at most limit models. If limit <= 0,
+// there is no limit. If Z3 cannot determine whether there is another
+// model, CountModels returns the count so far and an *ErrSatUnknown
+// error. Like Models, CountModels leaves s unchanged.
+func (s *Solver) CountModels(projection []Value, limit int) (int, error) {
+	n := 0
+	var err error
+	s.Models(projection, limit)(func(m *Model, merr error) bool {
+		if merr != nil {
+			err = merr
+			return false
+		}
+		n++
+		return true
+	})
+	return n, err
+}
+
+// MinimalModels is like Models, but enumerates only models in which
+// the set of true variables in projection is minimal: there is no
+// model in which a strict subset of them is true. Each model yielded
+// has a distinct set of true variables, so MinimalModels produces the
+// shortest distinct solutions to s, such as the minimal
+// configurations of a feature model.
+//
+// Finding each minimal model may take several checks. Like Models,
+// MinimalModels leaves s unchanged.
+func (s *Solver) MinimalModels(projection []Bool, limit int) func(yield func(m *Model, err error) bool) {
+	return func(yield func(m *Model, err error) bool) {
+		s.Push()
+		defer s.Pop()
+		for n := 0; limit <= 0 || n < limit; n++ {
+			sat, err := s.Check()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !sat {
+				return
+			}
+			m, trues, err := s.shrink(s.Model(), projection)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(m, nil) {
+				return
+			}
+			if len(trues) == 0 {
+				// The empty set is a subset of every
+				// other solution.
+				return
+			}
+			// Block m and every superset of it.
+			nots := make([]Bool, len(trues))
+			for i, t := range trues {
+				nots[i] = t.Not()
+			}
+			s.Assert(nots[0].Or(nots[1:]...))
+		}
+	}
+}
+
+// shrink repeatedly replaces m with a model of s in which a strict
+// subset of the variables in vars is true until there is no such
+// model. It returns the final model and its true variables.
+func (s *Solver) shrink(m *Model, vars []Bool) (*Model, []Bool, error) {
+	s.Push()
+	defer s.Pop()
+	for {
+		var trues, nots []Bool
+		for _, v := range vars {
+			if val, _ := m.Eval(v, true).(Bool).AsBool(); val {
+				trues = append(trues, v)
+				nots = append(nots, v.Not())
+			} else {
+				s.Assert(v.Not())
+			}
+		}
+		if len(trues) == 0 {
+			return m, nil, nil
+		}
+		s.Assert(nots[0].Or(nots[1:]...))
+		sat, err := s.Check()
+		if err != nil {
+			return nil, nil, err
+		}
+		if !sat {
+			return m, trues, nil
+		}
+		m = s.Model()
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSolverTranslate(t *testing.T) {
+	ctx, ctx2 := NewContext(nil), NewContext(nil)
+	x := ctx.BVConst("x", 8)
+	s := NewSolver(ctx)
+	s.Assert(x.Mul(ctx.FromInt(3, x.Sort()).(BV)).Eq(ctx.FromInt(1, x.Sort()).(BV)))
+
+	s2 := s.Translate(ctx2)
+	if got, want := s2.String(), s.String(); got != want {
+		t.Fatalf("translated solver is\n%s\nwant\n%s", got, want)
+	}
+	// Adding to the copy doesn't affect the original.
+	s2.Assert(ctx2.FromBool(false))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("original not satisfiable: %v", err)
+	}
+	if sat, _ := s2.Check(); sat {
+		t.Fatalf("translated solver is satisfiable")
+	}
+}
+
+func TestSolverFromTactic(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 8)
+	s := NewSolverFromTactic(ctx, "qfbv")
+	s.Assert(x.UGT(ctx.FromInt(250, x.Sort()).(BV)))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("not satisfiable: %v", err)
+	}
+	wantPanic(t, "unknown tactic", func() {
+		NewSolverFromTactic(ctx, "no-such-tactic")
+	})
+}
+
+func TestSolverTranslateInto(t *testing.T) {
+	ctx, ctx2 := NewContext(nil), NewContext(nil)
+	x := ctx.BVConst("x", 8)
+	s := NewSolver(ctx)
+	s.Assert(x.UGT(ctx.FromInt(250, x.Sort()).(BV)))
+	s.Assert(x.ULT(ctx.FromInt(252, x.Sort()).(BV)))
+
+	for _, dst := range []*Solver{NewSolverFromTactic(ctx2, "qfbv"), NewSolverFromTactic(ctx, "qfbv")} {
+		s.TranslateInto(dst)
+		if sat, err := dst.Check(); !sat {
+			t.Fatalf("not satisfiable: %v", err)
+		}
+		dctx := dst.Context()
+		xv, _, _ := dst.Model().Eval(dctx.BVConst("x", 8), true).(BV).AsUint64()
+		if xv != 251 {
+			t.Errorf("x = %d, want 251", xv)
+		}
+	}
+}
+
+func TestSolverConfig(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	s.Config().SetUint("random_seed", 42).SetBool("model", true)
+	x := ctx.IntConst("x")
+	s.Assert(x.GT(ctx.FromInt(0, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("not satisfiable: %v", err)
+	}
+	wantPanic(t, "", func() {
+		s.Config().SetUint("no_such_param", 1)
+	})
+}
+
+func TestSolverCubes(t *testing.T) {
+	ctx := NewContext(nil)
+	vars := make([]Bool, 6)
+	for i := range vars {
+		vars[i] = ctx.BoolConst(string(rune('a' + i)))
+	}
+	s := NewSolver(ctx)
+	// At least one of each adjacent pair, but not all.
+	for i := 0; i+1 < len(vars); i++ {
+		s.Assert(vars[i].Or(vars[i+1]))
+	}
+	s.Assert(vars[0].And(vars[1:]...).Not())
+	s.Config().SetUint("sat.lookahead.cube.depth", 2)
+
+	var cubes []Bool
+	s.Translate(ctx).Cubes(nil, -1)(func(cube []Bool) bool {
+		cubes = append(cubes, ctx.FromBool(true).And(cube...))
+		return true
+	})
+	if len(cubes) < 2 {
+		t.Fatalf("got %d cubes, want at least 2", len(cubes))
+	}
+
+	// The cubes cover every solution.
+	s.Assert(ctx.FromBool(false).Or(cubes...).Not())
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("cubes %v do not cover all solutions", cubes)
+	}
+
+	// Stopping early.
+	n := 0
+	s.Cubes(nil, -1)(func(cube []Bool) bool {
+		n++
+		return false
+	})
+	if n > 1 {
+		t.Errorf("yield called %d times after returning false", n)
+	}
+}
+
+func TestSolverModels(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 4)
+	y := ctx.BVConst("y", 4)
+	k := func(v int) BV { return ctx.FromInt(int64(v), x.Sort()).(BV) }
+	s := NewSolver(ctx)
+	s.Assert(x.ULT(k(5)).And(y.Eq(x.Add(k(1)))))
+	before := s.String()
+
+	seen := make(map[int]bool)
+	s.Models([]Value{x}, 0)(func(m *Model, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, _, _ := m.Eval(x, true).(BV).AsUint64()
+		if seen[int(v)] {
+			t.Errorf("duplicate model x=%d", v)
+		}
+		seen[int(v)] = true
+		return true
+	})
+	if len(seen) != 5 {
+		t.Errorf("got %d models, want 5", len(seen))
+	}
+
+	// The blocking clauses were popped.
+	if got := s.String(); got != before {
+		t.Errorf("solver after Models is\n%s\nwant\n%s", got, before)
+	}
+
+	if n, err := s.CountModels([]Value{x, y}, 0); n != 5 || err != nil {
+		t.Errorf("CountModels = %d, %v; want 5, nil", n, err)
+	}
+	if n, err := s.CountModels([]Value{x}, 3); n != 3 || err != nil {
+		t.Errorf("CountModels with limit = %d, %v; want 3, nil", n, err)
+	}
+
+	// Block without a scope.
+	for i := 0; ; i++ {
+		sat, err := s.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sat {
+			if i != 5 {
+				t.Errorf("got %d models with Block, want 5", i)
+			}
+			break
+		}
+		s.Block(s.Model(), []Value{x})
+	}
+	wantPanic(t, "non-empty projection", func() {
+		s.Block(nil, nil)
+	})
+}
+
+func TestSolverMinimalModels(t *testing.T) {
+	ctx := NewContext(nil)
+	a, b, c, d := ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c"), ctx.BoolConst("d")
+	s := NewSolver(ctx)
+	// Minimal solutions are {a, c}, {b, c}, and {d}.
+	s.Assert(a.Or(b).And(c).Or(d))
+	before := s.String()
+	vars := []Bool{a, b, c, d}
+
+	var got []string
+	s.MinimalModels(vars, 0)(func(m *Model, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		set := ""
+		for _, v := range vars {
+			if val, _ := m.Eval(v, true).(Bool).AsBool(); val {
+				set += v.String()
+			}
+		}
+		got = append(got, set)
+		return true
+	})
+	sort.Strings(got)
+	want := []string{"ac", "bc", "d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("minimal models %v, want %v", got, want)
+	}
+	if got := s.String(); got != before {
+		t.Errorf("solver after MinimalModels is\n%s\nwant\n%s", got, before)
+	}
+}
+
+func TestSolverIntrospection(t *testing.T) {
+	ctx := NewContext(NewContextConfig().SetBool("proof", true))
+	a, b, c := ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c")
+	s := NewSolver(ctx)
+	s.Assert(a)
+	s.Assert(b.Or(c))
+
+	if n := s.NumScopes(); n != 0 {
+		t.Errorf("NumScopes = %d, want 0", n)
+	}
+	s.Push()
+	s.Assert(b.Not())
+	s.Push()
+	s.Assert(c.Not())
+	if n := s.NumScopes(); n != 2 {
+		t.Errorf("NumScopes = %d, want 2", n)
+	}
+	if n := len(s.Assertions()); n != 4 {
+		t.Errorf("len(Assertions()) = %d, want 4", n)
+	}
+
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("Check = %v, %v; want false, nil", sat, err)
+	}
+	if err := s.Proof().Check(); err != nil {
+		t.Errorf("bad proof: %v", err)
+	}
+
+	s.PopN(2)
+	if n := s.NumScopes(); n != 0 {
+		t.Errorf("NumScopes after PopN(2) = %d, want 0", n)
+	}
+	if n := len(s.Assertions()); n != 2 {
+		t.Errorf("len(Assertions()) after PopN(2) = %d, want 2", n)
+	}
+	wantPanic(t, "cannot pop 1 of 0 scopes", func() { s.PopN(1) })
+
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check = %v, %v; want true, nil", sat, err)
+	}
+	// a is a unit and b || c is not.
+	units, nonUnits := s.Units(), s.NonUnits()
+	found := false
+	for _, u := range units {
+		found = found || u.String() == "a"
+	}
+	if !found {
+		t.Errorf("Units() = %v, want to contain a", units)
+	}
+	for _, u := range nonUnits {
+		if u.String() == "a" {
+			t.Errorf("NonUnits() = %v, should not contain a", nonUnits)
+		}
+	}
+}
+
+func TestSolverTrail(t *testing.T) {
+	ctx := NewContext(nil)
+	a, b := ctx.BoolConst("a"), ctx.BoolConst("b")
+	s := NewSimpleSolver(ctx)
+	s.Assert(a.And(a.Not().Or(b)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check = %v, %v; want true, nil", sat, err)
+	}
+	if trail := s.Trail(); len(trail) == 0 {
+		t.Errorf("Trail() is empty")
+	}
+	wantPanic(t, "cannot retrieve trail", func() {
+		s := NewSolverFromTactic(ctx, "qfbv")
+		s.Assert(a)
+		s.Check()
+		s.Trail()
+	})
+}
+
+func TestSolverConsequences(t *testing.T) {
+	ctx := NewContext(nil)
+	a, b, c := ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c")
+	x := ctx.IntConst("x")
+	one := ctx.FromInt(1, ctx.IntSort()).(Int)
+	s := NewSolver(ctx)
+	s.Assert(a.Implies(b))
+	s.Assert(b.Implies(x.Eq(one)))
+
+	conseqs, err := s.Consequences([]Bool{a}, []Value{b, c, x})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{"(=> a b)": true, "(=> a (= x 1))": true}
+	if len(conseqs) != len(want) {
+		t.Errorf("got consequences %v, want %v", conseqs, want)
+	}
+	for _, cq := range conseqs {
+		if !want[cq.String()] {
+			t.Errorf("unexpected consequence %v", cq)
+		}
+	}
+
+	if _, err := s.Consequences([]Bool{a, b.Not()}, []Value{c}); err != ErrUnsat {
+		t.Errorf("Consequences of unsatisfiable assumptions: got %v, want ErrUnsat", err)
+	}
+}
+
+func TestSolverImpliedEqualities(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y, z, w := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z"), ctx.IntConst("w")
+	s := NewSolver(ctx)
+	s.Assert(x.Eq(y))
+	s.Assert(y.Eq(z.Add(ctx.FromInt(0, ctx.IntSort()).(Int))))
+
+	ids, err := s.ImpliedEqualities([]Value{x, y, z, w})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids[0] != ids[1] || ids[1] != ids[2] {
+		t.Errorf("x, y, z not in the same class: %v", ids)
+	}
+	if ids[3] == ids[0] {
+		t.Errorf("w in the same class as x: %v", ids)
+	}
+
+	s.Assert(x.Eq(y).Not())
+	if _, err := s.ImpliedEqualities([]Value{x}); err != ErrUnsat {
+		t.Errorf("got %v, want ErrUnsat", err)
+	}
+	if _, err := s.ImpliedEqualities(nil); err != ErrUnsat {
+		t.Errorf("with no terms: got %v, want ErrUnsat", err)
+	}
+}
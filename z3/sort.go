@@ -41,7 +41,7 @@ func wrapSort(ctx *Context, c C.Z3_sort, kind Kind) Sort {
 	}
 	impl := &sortImpl{ctx, c, kind}
 	runtime.SetFinalizer(impl, func(impl *sortImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_dec_ref(impl.ctx.c, C.Z3_sort_to_ast(impl.ctx.c, impl.c))
 		})
 	})
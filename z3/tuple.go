@@ -0,0 +1,114 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+import "runtime"
+
+// Tuple is a symbolic value representing a fixed-size record of
+// fields, each of which may have a different sort.
+//
+// A tuple sort is a datatype with a single constructor. Currently
+// tuples are the only datatypes supported by this package, so all
+// values with datatype sorts are Tuples.
+//
+// Tuple implements Value.
+type Tuple value
+
+func init() {
+	kindWrappers[KindDatatype] = func(x value) Value {
+		return Tuple(x)
+	}
+}
+
+// TupleSort returns a tuple sort named name with the given field
+// names and sorts.
+//
+// Two tuple sorts with the same name must have the same fields.
+func (ctx *Context) TupleSort(name string, fieldNames []string, fieldSorts []Sort) Sort {
+	if len(fieldNames) != len(fieldSorts) {
+		panic("fieldNames and fieldSorts must have the same length")
+	}
+	sym := ctx.symbol(name)
+	csyms := make([]C.Z3_symbol, len(fieldNames))
+	for i, name := range fieldNames {
+		csyms[i] = ctx.symbol(name)
+	}
+	csorts := make([]C.Z3_sort, len(fieldSorts))
+	for i, sort := range fieldSorts {
+		csorts[i] = sort.c
+	}
+	cprojs := make([]C.Z3_func_decl, len(fieldSorts))
+	var sort Sort
+	ctx.do(func() {
+		var csymp *C.Z3_symbol
+		var csortp *C.Z3_sort
+		var cprojp *C.Z3_func_decl
+		if len(csyms) > 0 {
+			csymp, csortp, cprojp = &csyms[0], &csorts[0], &cprojs[0]
+		}
+		var mk C.Z3_func_decl
+		sort = wrapSort(ctx, C.Z3_mk_tuple_sort(ctx.c, sym, C.uint(len(csyms)), csymp, csortp, &mk, cprojp), KindDatatype)
+	})
+	runtime.KeepAlive(fieldSorts)
+	return sort
+}
+
+// TupleNumFields returns the number of fields of tuple sort s.
+func (s Sort) TupleNumFields() int {
+	var n int
+	s.ctx.do(func() {
+		n = int(C.Z3_get_tuple_sort_num_fields(s.ctx.c, s.c))
+	})
+	runtime.KeepAlive(s)
+	return n
+}
+
+// TupleField returns the projection function for field i of tuple
+// sort s. This function maps a tuple of sort s to its i'th field.
+func (s Sort) TupleField(i int) FuncDecl {
+	var f FuncDecl
+	s.ctx.do(func() {
+		f = wrapFuncDecl(s.ctx, C.Z3_get_tuple_sort_field_decl(s.ctx.c, s.c, C.uint(i)))
+	})
+	runtime.KeepAlive(s)
+	return f
+}
+
+// Tuple returns a tuple of sort s with the given field values. s must
+// be a tuple sort and fields must match its field sorts.
+func (ctx *Context) Tuple(s Sort, fields ...Value) Tuple {
+	var mk FuncDecl
+	ctx.do(func() {
+		mk = wrapFuncDecl(ctx, C.Z3_get_tuple_sort_mk_decl(ctx.c, s.c))
+	})
+	runtime.KeepAlive(s)
+	return mk.Apply(fields...).(Tuple)
+}
+
+// Field returns field i of tuple x.
+func (x Tuple) Field(i int) Value {
+	return x.Sort().TupleField(i).Apply(x)
+}
+
+// SetField returns a copy of tuple x with field i set to v.
+func (x Tuple) SetField(i int, v Value) Tuple {
+	sort := x.Sort()
+	fields := make([]Value, sort.TupleNumFields())
+	for j := range fields {
+		if j == i {
+			fields[j] = v
+		} else {
+			fields[j] = x.Field(j)
+		}
+	}
+	return x.ctx.Tuple(sort, fields...)
+}
+
+//go:generate go run genwrap.go -t Tuple $GOFILE
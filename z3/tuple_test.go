@@ -0,0 +1,41 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestTuple(t *testing.T) {
+	ctx := NewContext(nil)
+	intSort, boolSort := ctx.IntSort(), ctx.BoolSort()
+	pair := ctx.TupleSort("pair", []string{"first", "second"}, []Sort{intSort, boolSort})
+	if n := pair.TupleNumFields(); n != 2 {
+		t.Fatalf("want 2 fields, got %d", n)
+	}
+
+	x := ctx.Tuple(pair, ctx.FromInt(1, intSort), ctx.FromBool(true))
+	if !simplifyBool(t, ctx, x.Field(0).(Int).Eq(ctx.FromInt(1, intSort).(Int))) {
+		t.Errorf("%s.first != 1", x)
+	}
+	y := x.SetField(1, ctx.FromBool(false))
+	if simplifyBool(t, ctx, y.Field(1).(Bool)) {
+		t.Errorf("%s.second != false", y)
+	}
+	if simplifyBool(t, ctx, x.Eq(y)) {
+		t.Errorf("%s == %s", x, y)
+	}
+
+	// Solve for a tuple.
+	z := ctx.Const("z", pair).(Tuple)
+	s := NewSolver(ctx)
+	s.Assert(z.Field(0).(Int).GT(ctx.FromInt(5, intSort).(Int)))
+	s.Assert(z.NE(x))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("not satisfiable: %v", err)
+	}
+	zv := s.Model().Eval(z, true).(Tuple)
+	if v, ok, _ := ctx.Simplify(zv.Field(0), nil).(Int).AsInt64(); !ok || v <= 5 {
+		t.Errorf("want z.first > 5, got %s", zv)
+	}
+}